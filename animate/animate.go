@@ -0,0 +1,222 @@
+// Package animate fades numeric Resolume parameters from the client, such as
+// Layer.Master, Composition.Master or any RangeParameter.
+//
+// A fade sends a stream of SetParameterByID calls following an easing curve
+// over a duration in time or in beats of the composition tempo. Concurrent
+// fades of one Animator share its request rate, and values are clamped to the
+// parameter's Min and Max.
+package animate
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/FlowingSPDG/resolume-go"
+)
+
+// Length is the duration of a fade, in time or in beats
+type Length struct {
+	duration time.Duration
+	beats    float64
+}
+
+// Time returns a length in time
+func Time(d time.Duration) Length {
+	return Length{duration: d}
+}
+
+// Beats returns a length in beats, converted with the composition tempo when the fade starts
+func Beats(n float64) Length {
+	return Length{beats: n}
+}
+
+// Animator runs fades through a Client
+type Animator struct {
+	client   *resolume.Client
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// Option configures an Animator
+type Option func(*Animator)
+
+// WithMaxRate sets the maximum number of updates per second sent by all fades
+// of the Animator together, 30 by default. A rate that is not positive, or
+// too high to wait between updates, is ignored and keeps the default, as
+// fades are always rate limited.
+func WithMaxRate(perSecond float64) Option {
+	return func(a *Animator) {
+		if interval := time.Duration(float64(time.Second) / perSecond); perSecond > 0 && interval > 0 {
+			a.interval = interval
+		}
+	}
+}
+
+// New creates an Animator
+func New(client *resolume.Client, opts ...Option) *Animator {
+	a := &Animator{
+		client:   client,
+		interval: time.Second / 30,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Fade fades a parameter from one value to another. It retrieves the
+// parameter first to clamp the values to its Min and Max, and returns when the
+// fade is complete or ctx is done.
+func (a *Animator) Fade(ctx context.Context, paramID int64, from, to float64, length Length, easing Easing) error {
+	p, err := a.client.GetParameterContext(ctx, paramID)
+	if err != nil {
+		return err
+	}
+	r, err := rangeOf(p)
+	if err != nil {
+		return err
+	}
+	return a.run(ctx, r, from, to, length, easing)
+}
+
+// FadeTo fades a parameter from its current value
+func (a *Animator) FadeTo(ctx context.Context, paramID int64, to float64, length Length, easing Easing) error {
+	p, err := a.client.GetParameterContext(ctx, paramID)
+	if err != nil {
+		return err
+	}
+	r, err := rangeOf(p)
+	if err != nil {
+		return err
+	}
+	return a.run(ctx, r, r.value, to, length, easing)
+}
+
+// FadeParameter fades a parameter taken from a composition, such as
+// layer.Master, from its value there, without retrieving it first
+func (a *Animator) FadeParameter(ctx context.Context, p *resolume.RangeParameter, to float64, length Length, easing Easing) error {
+	r := valueRange{id: p.ID, value: p.Value, min: p.Min, max: p.Max}
+	return a.run(ctx, r, p.Value, to, length, easing)
+}
+
+// valueRange is the id, value and bounds of a numeric parameter
+type valueRange struct {
+	id       int64
+	value    float64
+	min, max float64
+	integer  bool
+}
+
+// rangeOf returns the bounds of a numeric parameter
+func rangeOf(p resolume.Parameter) (valueRange, error) {
+	switch p := p.(type) {
+	case *resolume.RangeParameter:
+		return valueRange{id: p.ID, value: p.Value, min: p.Min, max: p.Max}, nil
+	case *resolume.IntegerParameter:
+		return valueRange{id: p.ID, value: float64(p.Value), min: math.Inf(-1), max: math.Inf(1), integer: true}, nil
+	default:
+		return valueRange{}, fmt.Errorf("parameter %d is a %s, not a number", p.ParameterID(), p.ParameterType())
+	}
+}
+
+// clamp limits v to the bounds, if the parameter has any
+func (r valueRange) clamp(v float64) float64 {
+	if r.integer {
+		v = math.Round(v)
+	}
+	if r.min < r.max {
+		v = math.Max(r.min, math.Min(r.max, v))
+	}
+	return v
+}
+
+// run sends the values of a fade until it is complete
+func (a *Animator) run(ctx context.Context, r valueRange, from, to float64, length Length, easing Easing) error {
+	if easing == nil {
+		easing = Linear
+	}
+	duration, err := a.duration(ctx, length)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	last := math.NaN()
+	for {
+		if err := a.wait(ctx); err != nil {
+			return err
+		}
+		t := 1.0
+		if duration > 0 {
+			t = math.Min(1, float64(time.Since(start))/float64(duration))
+		}
+		v := r.clamp(from + (to-from)*easing(t))
+		if v != last {
+			if err := a.client.SetParameterByIDContext(ctx, r.id, map[string]interface{}{"value": v}); err != nil {
+				return err
+			}
+			last = v
+		}
+		if t >= 1 {
+			return nil
+		}
+	}
+}
+
+// Wait blocks for a length in time or beats, or until ctx is done
+func (a *Animator) Wait(ctx context.Context, length Length) error {
+	duration, err := a.duration(ctx, length)
+	if err != nil {
+		return err
+	}
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// duration converts a length in beats with the current tempo
+func (a *Animator) duration(ctx context.Context, length Length) (time.Duration, error) {
+	if length.beats == 0 {
+		return length.duration, nil
+	}
+	composition, err := a.client.GetCompositionContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if composition.TempoController == nil || composition.TempoController.Tempo == nil || composition.TempoController.Tempo.Value <= 0 {
+		return 0, fmt.Errorf("the composition has no tempo")
+	}
+	bpm := composition.TempoController.Tempo.Value
+	return time.Duration(length.beats * float64(time.Minute) / bpm), nil
+}
+
+// wait blocks until the next update slot of the Animator, shared by all its fades
+func (a *Animator) wait(ctx context.Context) error {
+	a.mu.Lock()
+	now := time.Now()
+	at := a.next
+	if at.Before(now) {
+		at = now
+	}
+	a.next = at.Add(a.interval)
+	a.mu.Unlock()
+
+	timer := time.NewTimer(time.Until(at))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
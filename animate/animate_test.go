@@ -0,0 +1,201 @@
+package animate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/FlowingSPDG/resolume-go"
+)
+
+// fakeResolume serves a range parameter with id 5, a string parameter with id
+// 6 and a composition at 600 BPM, and records the values set
+type fakeResolume struct {
+	mu     sync.Mutex
+	values map[string][]float64
+}
+
+func newFakeResolume(t *testing.T) (*resolume.Client, *fakeResolume) {
+	t.Helper()
+	f := &fakeResolume{values: map[string][]float64{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/parameter/by-id/5":
+			w.Write([]byte(`{"id": 5, "valuetype": "ParamRange", "value": 0.25, "min": 0, "max": 1}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/parameter/by-id/6":
+			w.Write([]byte(`{"id": 6, "valuetype": "ParamString", "value": "text"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/composition":
+			w.Write([]byte(`{"tempo_controller": {"tempo": {"id": 1, "valuetype": "ParamRange", "value": 600}}}`))
+		case r.Method == http.MethodPut:
+			var body struct {
+				Value float64 `json:"value"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			f.mu.Lock()
+			f.values[r.URL.Path] = append(f.values[r.URL.Path], body.Value)
+			f.mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := resolume.NewClientFromURL(server.URL+"/api/v1", resolume.WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewClientFromURL() error = %v", err)
+	}
+	return client, f
+}
+
+func (f *fakeResolume) sent(path string) []float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]float64(nil), f.values[path]...)
+}
+
+func TestFadeClamps(t *testing.T) {
+	client, f := newFakeResolume(t)
+	a := New(client, WithMaxRate(200))
+
+	if err := a.Fade(context.Background(), 5, 0, 2, Time(50*time.Millisecond), Linear); err != nil {
+		t.Fatalf("Fade() error = %v", err)
+	}
+	values := f.sent("/api/v1/parameter/by-id/5")
+	if len(values) < 3 {
+		t.Fatalf("Expected several updates, got %v", values)
+	}
+	for i, v := range values {
+		if v < 0 || v > 1 || (i > 0 && v < values[i-1]) {
+			t.Fatalf("Expected increasing values within [0, 1], got %v", values)
+		}
+	}
+	if last := values[len(values)-1]; last != 1 {
+		t.Errorf("Expected the fade to end at the clamped maximum 1, got %v", last)
+	}
+}
+
+func TestFadeToBeats(t *testing.T) {
+	client, f := newFakeResolume(t)
+	a := New(client, WithMaxRate(200))
+
+	// Half a beat at 600 BPM is 50ms
+	start := time.Now()
+	if err := a.FadeTo(context.Background(), 5, 0, Beats(0.5), EaseInOutSine); err != nil {
+		t.Fatalf("FadeTo() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Expected the fade to take 50ms, took %s", elapsed)
+	}
+	values := f.sent("/api/v1/parameter/by-id/5")
+	if len(values) == 0 || values[0] > 0.25 || values[len(values)-1] != 0 {
+		t.Errorf("Expected a fade from 0.25 to 0, got %v", values)
+	}
+}
+
+func TestFadeParameter(t *testing.T) {
+	client, f := newFakeResolume(t)
+	a := New(client)
+
+	master := &resolume.RangeParameter{ID: 7, Value: 1, Min: 0, Max: 1}
+	if err := a.FadeParameter(context.Background(), master, 0, Time(0), nil); err != nil {
+		t.Fatalf("FadeParameter() error = %v", err)
+	}
+	if values := f.sent("/api/v1/parameter/by-id/7"); len(values) != 1 || values[0] != 0 {
+		t.Errorf("Expected a single update to 0, got %v", values)
+	}
+}
+
+func TestFadeCancel(t *testing.T) {
+	client, _ := newFakeResolume(t)
+	a := New(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	if err := a.Fade(ctx, 5, 0, 1, Time(time.Hour), Linear); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Fade() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestFadeRejectsNonNumeric(t *testing.T) {
+	client, _ := newFakeResolume(t)
+	if err := New(client).Fade(context.Background(), 6, 0, 1, Time(0), Linear); err == nil {
+		t.Error("Fade() of a string parameter error = nil")
+	}
+}
+
+func TestSharedRate(t *testing.T) {
+	client, f := newFakeResolume(t)
+	a := New(client, WithMaxRate(100))
+
+	var wg sync.WaitGroup
+	for _, id := range []int64{8, 9} {
+		wg.Add(1)
+		go func(id int64) {
+			defer wg.Done()
+			master := &resolume.RangeParameter{ID: id, Min: 0, Max: 1}
+			a.FadeParameter(context.Background(), master, 1, Time(100*time.Millisecond), Linear)
+		}(id)
+	}
+	wg.Wait()
+
+	total := len(f.sent("/api/v1/parameter/by-id/8")) + len(f.sent("/api/v1/parameter/by-id/9"))
+	// 100ms at 100 updates per second leaves room for about 10 updates in total
+	if total > 14 {
+		t.Errorf("Expected the fades to share the rate, got %d updates", total)
+	}
+}
+
+func TestInvalidRate(t *testing.T) {
+	client, _ := newFakeResolume(t)
+	for _, rate := range []float64{0, -1, math.Inf(1)} {
+		if a := New(client, WithMaxRate(rate)); a.interval != time.Second/30 {
+			t.Errorf("WithMaxRate(%v) interval = %v, want the default", rate, a.interval)
+		}
+	}
+}
+
+func TestEasingEndpoints(t *testing.T) {
+	easings := map[string]Easing{
+		"Linear": Linear, "EaseInQuad": EaseInQuad, "EaseOutQuad": EaseOutQuad, "EaseInOutQuad": EaseInOutQuad,
+		"EaseInCubic": EaseInCubic, "EaseOutCubic": EaseOutCubic, "EaseInOutCubic": EaseInOutCubic,
+		"EaseInSine": EaseInSine, "EaseOutSine": EaseOutSine, "EaseInOutSine": EaseInOutSine,
+		"EaseInExpo": EaseInExpo, "EaseOutExpo": EaseOutExpo, "Step": Step,
+	}
+	for name, easing := range easings {
+		if got := easing(0); math.Abs(got) > 1e-9 {
+			t.Errorf("%s(0) = %v, want 0", name, got)
+		}
+		if got := easing(1); math.Abs(got-1) > 1e-9 {
+			t.Errorf("%s(1) = %v, want 1", name, got)
+		}
+	}
+}
+
+func TestWaitBeats(t *testing.T) {
+	client, _ := newFakeResolume(t)
+
+	start := time.Now()
+	if err := New(client).Wait(context.Background(), Beats(0.5)); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Expected half a beat at 600 BPM to take 50ms, took %s", elapsed)
+	}
+}
+
+func TestParseEasing(t *testing.T) {
+	if easing, err := ParseEasing("ease-in-quad"); err != nil || easing(0.5) != 0.25 {
+		t.Errorf("ParseEasing(ease-in-quad) = %v, %v", easing, err)
+	}
+	if _, err := ParseEasing("bounce"); err == nil {
+		t.Error("ParseEasing(bounce) error = nil")
+	}
+}
@@ -0,0 +1,88 @@
+package animate
+
+import (
+	"fmt"
+	"math"
+)
+
+// Easing maps the progress of a fade, from 0 to 1, to the fraction of the
+// change applied at that point
+type Easing func(t float64) float64
+
+// Standard easing curves
+var (
+	Linear Easing = func(t float64) float64 { return t }
+
+	EaseInQuad    Easing = func(t float64) float64 { return t * t }
+	EaseOutQuad   Easing = func(t float64) float64 { return 1 - (1-t)*(1-t) }
+	EaseInOutQuad Easing = func(t float64) float64 {
+		if t < 0.5 {
+			return 2 * t * t
+		}
+		return 1 - math.Pow(-2*t+2, 2)/2
+	}
+
+	EaseInCubic    Easing = func(t float64) float64 { return t * t * t }
+	EaseOutCubic   Easing = func(t float64) float64 { return 1 - math.Pow(1-t, 3) }
+	EaseInOutCubic Easing = func(t float64) float64 {
+		if t < 0.5 {
+			return 4 * t * t * t
+		}
+		return 1 - math.Pow(-2*t+2, 3)/2
+	}
+
+	EaseInSine    Easing = func(t float64) float64 { return 1 - math.Cos(t*math.Pi/2) }
+	EaseOutSine   Easing = func(t float64) float64 { return math.Sin(t * math.Pi / 2) }
+	EaseInOutSine Easing = func(t float64) float64 { return -(math.Cos(math.Pi*t) - 1) / 2 }
+
+	EaseInExpo Easing = func(t float64) float64 {
+		if t == 0 {
+			return 0
+		}
+		return math.Pow(2, 10*t-10)
+	}
+	EaseOutExpo Easing = func(t float64) float64 {
+		if t == 1 {
+			return 1
+		}
+		return 1 - math.Pow(2, -10*t)
+	}
+)
+
+// Step jumps to the target value at the end of the fade
+var Step Easing = func(t float64) float64 {
+	if t < 1 {
+		return 0
+	}
+	return 1
+}
+
+// easings are the easing curves by name, as accepted by ParseEasing
+var easings = map[string]Easing{
+	"linear":            Linear,
+	"ease-in-quad":      EaseInQuad,
+	"ease-out-quad":     EaseOutQuad,
+	"ease-in-out-quad":  EaseInOutQuad,
+	"ease-in-cubic":     EaseInCubic,
+	"ease-out-cubic":    EaseOutCubic,
+	"ease-in-out-cubic": EaseInOutCubic,
+	"ease-in-sine":      EaseInSine,
+	"ease-out-sine":     EaseOutSine,
+	"ease-in-out-sine":  EaseInOutSine,
+	"ease-in-expo":      EaseInExpo,
+	"ease-out-expo":     EaseOutExpo,
+	"step":              Step,
+}
+
+// ParseEasing returns the easing curve with the given name, such as "linear"
+// or "ease-in-out-sine". An empty name is Linear.
+func ParseEasing(name string) (Easing, error) {
+	if name == "" {
+		return Linear, nil
+	}
+	easing, ok := easings[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown easing: %s", name)
+	}
+	return easing, nil
+}
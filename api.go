@@ -1,612 +1,2442 @@
+// Code generated by resolume-gen from swagger.yaml. DO NOT EDIT.
+
 package resolume
 
-import "fmt"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
 
 // GetParameterByID retrieves a parameter given its unique id
+//
+// Given the unique id, get all properties for a single parameter.
 func (c *Client) GetParameterByID(parameterID int64) (interface{}, error) {
+	return c.GetParameterByIDContext(context.Background(), parameterID)
+}
+
+// GetParameterByIDContext is like GetParameterByID but with a context
+func (c *Client) GetParameterByIDContext(ctx context.Context, parameterID int64) (interface{}, error) {
 	endpoint := fmt.Sprintf("/parameter/by-id/%d", parameterID)
-	var param interface{}
-	if err := c.get(endpoint, &param); err != nil {
+	var v interface{}
+	if err := c.get(ctx, endpoint, &v); err != nil {
 		return nil, err
 	}
-	return param, nil
+	return v, nil
 }
 
 // SetParameterByID updates a parameter given its unique id
+//
+// Given the unique id, update a single parameter.
 func (c *Client) SetParameterByID(parameterID int64, parameter interface{}) error {
+	return c.SetParameterByIDContext(context.Background(), parameterID, parameter)
+}
+
+// SetParameterByIDContext is like SetParameterByID but with a context
+func (c *Client) SetParameterByIDContext(ctx context.Context, parameterID int64, parameter interface{}) error {
 	endpoint := fmt.Sprintf("/parameter/by-id/%d", parameterID)
-	return c.put(endpoint, parameter, nil)
+	return c.put(ctx, endpoint, parameter, nil)
 }
 
 // ResetParameterByID resets a parameter with the matching unique id
+//
+// Reset the parameter with the matching unique id.
 func (c *Client) ResetParameterByID(parameterID int64, resetAnimation bool) error {
+	return c.ResetParameterByIDContext(context.Background(), parameterID, resetAnimation)
+}
+
+// ResetParameterByIDContext is like ResetParameterByID but with a context
+func (c *Client) ResetParameterByIDContext(ctx context.Context, parameterID int64, resetAnimation bool) error {
 	endpoint := fmt.Sprintf("/parameter/by-id/%d/reset", parameterID)
 	body := ResetParameter{
 		ResetAnimation: resetAnimation,
 	}
-	return c.post(endpoint, body, nil)
+	return c.post(ctx, endpoint, body, nil)
 }
 
 // GetComposition retrieves the complete composition
+//
+// This contains the composition properties, all the decks, layers and clips.
 func (c *Client) GetComposition() (*Composition, error) {
+	return c.GetCompositionContext(context.Background())
+}
+
+// GetCompositionContext is like GetComposition but with a context
+func (c *Client) GetCompositionContext(ctx context.Context) (*Composition, error) {
 	endpoint := "/composition"
 	var composition Composition
-	if err := c.get(endpoint, &composition); err != nil {
+	if err := c.get(ctx, endpoint, &composition); err != nil {
 		return nil, err
 	}
 	return &composition, nil
 }
 
 // ReplaceComposition updates the complete composition
+//
+// This allows for updating all parameters in a composition, its decks, layers
+// and clips.
 func (c *Client) ReplaceComposition(composition *Composition) error {
+	return c.ReplaceCompositionContext(context.Background(), composition)
+}
+
+// ReplaceCompositionContext is like ReplaceComposition but with a context
+func (c *Client) ReplaceCompositionContext(ctx context.Context, composition *Composition) error {
 	endpoint := "/composition"
-	return c.put(endpoint, composition, nil)
+	return c.put(ctx, endpoint, composition, nil)
 }
 
-// CompositionAction executes undo or redo actions
+// CompositionAction undoes or redoes previously executed actions
+//
+// With this call an action can be either undone, or an action that was undone
+// can be executed again.
 func (c *Client) CompositionAction(action string) error {
+	return c.CompositionActionContext(context.Background(), action)
+}
+
+// CompositionActionContext is like CompositionAction but with a context
+func (c *Client) CompositionActionContext(ctx context.Context, action string) error {
 	if action != "undo" && action != "redo" {
 		return fmt.Errorf("invalid action: %s (must be 'undo' or 'redo')", action)
 	}
 	endpoint := "/composition/action"
-	return c.post(endpoint, action, nil)
+	return c.post(ctx, endpoint, plainText(action), nil)
 }
 
 // DisconnectAllClips disconnects all clips in the composition
+//
+// Any connected clip will be disconnected.
 func (c *Client) DisconnectAllClips() error {
+	return c.DisconnectAllClipsContext(context.Background())
+}
+
+// DisconnectAllClipsContext is like DisconnectAllClips but with a context
+func (c *Client) DisconnectAllClipsContext(ctx context.Context) error {
 	endpoint := "/composition/disconnect-all"
-	return c.post(endpoint, nil, nil)
+	return c.post(ctx, endpoint, nil, nil)
 }
 
 // SetEffectDisplayName changes the display name of an effect
+//
+// Change the display name of the effect with the matching unique id.
 func (c *Client) SetEffectDisplayName(effectID int64, displayName string) error {
+	return c.SetEffectDisplayNameContext(context.Background(), effectID, displayName)
+}
+
+// SetEffectDisplayNameContext is like SetEffectDisplayName but with a context
+func (c *Client) SetEffectDisplayNameContext(ctx context.Context, effectID int64, displayName string) error {
 	endpoint := fmt.Sprintf("/composition/effects/by-id/%d/set-display-name", effectID)
-	return c.post(endpoint, displayName, nil)
+	return c.post(ctx, endpoint, plainText(displayName), nil)
 }
 
 // MoveEffect moves an effect to the end of the composition
+//
+// Take an existing effect instance, and move it to the end of the composition.
 func (c *Client) MoveEffect(effectURI string) error {
+	return c.MoveEffectContext(context.Background(), effectURI)
+}
+
+// MoveEffectContext is like MoveEffect but with a context
+func (c *Client) MoveEffectContext(ctx context.Context, effectURI string) error {
 	endpoint := "/composition/effects/video/move"
-	return c.post(endpoint, effectURI, nil)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
 }
 
 // MoveEffectToOffset moves an effect to a specific offset in the composition
+//
+// Take an existing effect instance, and move it to the index inside the
+// composition.
 func (c *Client) MoveEffectToOffset(offset int64, effectURI string) error {
+	return c.MoveEffectToOffsetContext(context.Background(), offset, effectURI)
+}
+
+// MoveEffectToOffsetContext is like MoveEffectToOffset but with a context
+func (c *Client) MoveEffectToOffsetContext(ctx context.Context, offset int64, effectURI string) error {
 	endpoint := fmt.Sprintf("/composition/effects/video/move/%d", offset)
-	return c.post(endpoint, effectURI, nil)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
 }
 
 // AddEffect adds an effect to the entire composition
+//
+// Add a global effect, which affects everything within the composition.
 func (c *Client) AddEffect(effectURI string) error {
+	return c.AddEffectContext(context.Background(), effectURI)
+}
+
+// AddEffectContext is like AddEffect but with a context
+func (c *Client) AddEffectContext(ctx context.Context, effectURI string) error {
 	endpoint := "/composition/effects/video/add"
-	return c.post(endpoint, effectURI, nil)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
 }
 
 // AddEffectAtOffset adds an effect to the composition at a specific offset
+//
+// Add a global effect, which affects everything within the composition.
 func (c *Client) AddEffectAtOffset(offset int64, effectURI string) error {
+	return c.AddEffectAtOffsetContext(context.Background(), offset, effectURI)
+}
+
+// AddEffectAtOffsetContext is like AddEffectAtOffset but with a context
+func (c *Client) AddEffectAtOffsetContext(ctx context.Context, offset int64, effectURI string) error {
 	endpoint := fmt.Sprintf("/composition/effects/video/add/%d", offset)
-	return c.post(endpoint, effectURI, nil)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
 }
 
-// DeleteEffect removes an effect from the composition
+// DeleteEffect removes an effect from the entire composition
+//
+// Remove a global effect, which affects everything within the composition.
 func (c *Client) DeleteEffect(offset int64) error {
+	return c.DeleteEffectContext(context.Background(), offset)
+}
+
+// DeleteEffectContext is like DeleteEffect but with a context
+func (c *Client) DeleteEffectContext(ctx context.Context, offset int64) error {
 	endpoint := fmt.Sprintf("/composition/effects/video/%d", offset)
-	return c.delete(endpoint)
+	return c.delete(ctx, endpoint)
 }
 
 // ResetCompositionParameter resets a parameter in the composition to its default value
+//
+// This resets a parameter on the main composition to default. If a JSON body is
+// given and 'resetanimation' is set to true, animations are also reset.
 func (c *Client) ResetCompositionParameter(parameter string, resetAnimation bool) error {
+	return c.ResetCompositionParameterContext(context.Background(), parameter, resetAnimation)
+}
+
+// ResetCompositionParameterContext is like ResetCompositionParameter but with a context
+func (c *Client) ResetCompositionParameterContext(ctx context.Context, parameter string, resetAnimation bool) error {
 	endpoint := fmt.Sprintf("/composition/%s/reset", parameter)
 	body := ResetParameter{
 		ResetAnimation: resetAnimation,
 	}
-	return c.post(endpoint, body, nil)
+	return c.post(ctx, endpoint, body, nil)
 }
 
 // GetColumn retrieves column properties by index
+//
+// Returns a single column.
 func (c *Client) GetColumn(columnIndex int64) (*Column, error) {
+	return c.GetColumnContext(context.Background(), columnIndex)
+}
+
+// GetColumnContext is like GetColumn but with a context
+func (c *Client) GetColumnContext(ctx context.Context, columnIndex int64) (*Column, error) {
 	endpoint := fmt.Sprintf("/composition/columns/%d", columnIndex)
 	var column Column
-	if err := c.get(endpoint, &column); err != nil {
+	if err := c.get(ctx, endpoint, &column); err != nil {
 		return nil, err
 	}
 	return &column, nil
 }
 
-// ReplaceColumn updates a specific column by index
+// ReplaceColumn updates specific column by index
+//
+// Update a single column.
 func (c *Client) ReplaceColumn(columnIndex int64, column *Column) error {
+	return c.ReplaceColumnContext(context.Background(), columnIndex, column)
+}
+
+// ReplaceColumnContext is like ReplaceColumn but with a context
+func (c *Client) ReplaceColumnContext(ctx context.Context, columnIndex int64, column *Column) error {
 	endpoint := fmt.Sprintf("/composition/columns/%d", columnIndex)
-	return c.put(endpoint, column, nil)
+	return c.put(ctx, endpoint, column, nil)
 }
 
 // DeleteColumn removes a column by index
+//
+// Remove the column with the given index.
 func (c *Client) DeleteColumn(columnIndex int64) error {
+	return c.DeleteColumnContext(context.Background(), columnIndex)
+}
+
+// DeleteColumnContext is like DeleteColumn but with a context
+func (c *Client) DeleteColumnContext(ctx context.Context, columnIndex int64) error {
 	endpoint := fmt.Sprintf("/composition/columns/%d", columnIndex)
-	return c.delete(endpoint)
+	return c.delete(ctx, endpoint)
 }
 
 // DuplicateColumn duplicates the given column
+//
+// Take the given column and make a copy of it. All properties will be the same,
+// except for the unique identifiers. The new column is inserted into the
+// composition next to the existing one.
 func (c *Client) DuplicateColumn(columnIndex int64) error {
+	return c.DuplicateColumnContext(context.Background(), columnIndex)
+}
+
+// DuplicateColumnContext is like DuplicateColumn but with a context
+func (c *Client) DuplicateColumnContext(ctx context.Context, columnIndex int64) error {
 	endpoint := fmt.Sprintf("/composition/columns/%d/duplicate", columnIndex)
-	return c.post(endpoint, nil, nil)
+	return c.post(ctx, endpoint, nil, nil)
 }
 
 // AddColumn adds a new column to the composition
+//
+// This adds a new column either at the end, or somewhere else in the
+// composition.
 func (c *Client) AddColumn(beforeColumnURI string) error {
+	return c.AddColumnContext(context.Background(), beforeColumnURI)
+}
+
+// AddColumnContext is like AddColumn but with a context
+func (c *Client) AddColumnContext(ctx context.Context, beforeColumnURI string) error {
 	endpoint := "/composition/columns/add"
-	return c.post(endpoint, beforeColumnURI, nil)
+	return c.post(ctx, endpoint, uriText(beforeColumnURI), nil)
 }
 
 // ResetColumnParameter resets a parameter in a column to its default value
+//
+// This resets a parameter on the indexed column to default. If a JSON body is
+// given and 'resetanimation' is set to true, animations are also reset.
 func (c *Client) ResetColumnParameter(columnIndex int64, parameter string, resetAnimation bool) error {
+	return c.ResetColumnParameterContext(context.Background(), columnIndex, parameter, resetAnimation)
+}
+
+// ResetColumnParameterContext is like ResetColumnParameter but with a context
+func (c *Client) ResetColumnParameterContext(ctx context.Context, columnIndex int64, parameter string, resetAnimation bool) error {
 	endpoint := fmt.Sprintf("/composition/columns/%d/%s/reset", columnIndex, parameter)
 	body := ResetParameter{
 		ResetAnimation: resetAnimation,
 	}
-	return c.post(endpoint, body, nil)
+	return c.post(ctx, endpoint, body, nil)
 }
 
 // ConnectColumn connects the column by index
+//
+// Connect the column, possibly activating it.
 func (c *Client) ConnectColumn(columnIndex int64, connect *bool) error {
+	return c.ConnectColumnContext(context.Background(), columnIndex, connect)
+}
+
+// ConnectColumnContext is like ConnectColumn but with a context
+func (c *Client) ConnectColumnContext(ctx context.Context, columnIndex int64, connect *bool) error {
 	endpoint := fmt.Sprintf("/composition/columns/%d/connect", columnIndex)
-	return c.post(endpoint, connect, nil)
+	return c.post(ctx, endpoint, connect, nil)
 }
 
-// SelectColumn selects the column by index
+// SelectColumn selects the column by its position in the clip grid
+//
+// Select the column.
 func (c *Client) SelectColumn(columnIndex int64) error {
+	return c.SelectColumnContext(context.Background(), columnIndex)
+}
+
+// SelectColumnContext is like SelectColumn but with a context
+func (c *Client) SelectColumnContext(ctx context.Context, columnIndex int64) error {
 	endpoint := fmt.Sprintf("/composition/columns/%d/select", columnIndex)
-	return c.post(endpoint, nil, nil)
+	return c.post(ctx, endpoint, nil, nil)
+}
+
+// GetColumnByID retrieves column properties by id
+//
+// Returns a single column, either from the composition or a layer group.
+func (c *Client) GetColumnByID(columnID int64) (*Column, error) {
+	return c.GetColumnByIDContext(context.Background(), columnID)
+}
+
+// GetColumnByIDContext is like GetColumnByID but with a context
+func (c *Client) GetColumnByIDContext(ctx context.Context, columnID int64) (*Column, error) {
+	endpoint := fmt.Sprintf("/composition/columns/by-id/%d", columnID)
+	var column Column
+	if err := c.get(ctx, endpoint, &column); err != nil {
+		return nil, err
+	}
+	return &column, nil
+}
+
+// ReplaceColumnByID updates specific column by id
+//
+// Update a single column.
+func (c *Client) ReplaceColumnByID(columnID int64, column *Column) error {
+	return c.ReplaceColumnByIDContext(context.Background(), columnID, column)
+}
+
+// ReplaceColumnByIDContext is like ReplaceColumnByID but with a context
+func (c *Client) ReplaceColumnByIDContext(ctx context.Context, columnID int64, column *Column) error {
+	endpoint := fmt.Sprintf("/composition/columns/by-id/%d", columnID)
+	return c.put(ctx, endpoint, column, nil)
+}
+
+// DeleteColumnByID removes a column by id
+//
+// Remove the column with the given id.
+func (c *Client) DeleteColumnByID(columnID int64) error {
+	return c.DeleteColumnByIDContext(context.Background(), columnID)
+}
+
+// DeleteColumnByIDContext is like DeleteColumnByID but with a context
+func (c *Client) DeleteColumnByIDContext(ctx context.Context, columnID int64) error {
+	endpoint := fmt.Sprintf("/composition/columns/by-id/%d", columnID)
+	return c.delete(ctx, endpoint)
+}
+
+// DuplicateColumnByID duplicates the given column
+//
+// Take the given column and make a copy of it. All properties will be the same,
+// except for the unique identifiers. The new column is inserted into the
+// composition or layer group next to the existing one.
+func (c *Client) DuplicateColumnByID(columnID int64) error {
+	return c.DuplicateColumnByIDContext(context.Background(), columnID)
+}
+
+// DuplicateColumnByIDContext is like DuplicateColumnByID but with a context
+func (c *Client) DuplicateColumnByIDContext(ctx context.Context, columnID int64) error {
+	endpoint := fmt.Sprintf("/composition/columns/by-id/%d/duplicate", columnID)
+	return c.post(ctx, endpoint, nil, nil)
+}
+
+// ResetColumnParameterByID resets a parameter in a column to its default value
+//
+// This resets a parameter on the indexed column to default. If a JSON body is
+// given and 'resetanimation' is set to true, animations are also reset.
+func (c *Client) ResetColumnParameterByID(columnID int64, parameter string, resetAnimation bool) error {
+	return c.ResetColumnParameterByIDContext(context.Background(), columnID, parameter, resetAnimation)
+}
+
+// ResetColumnParameterByIDContext is like ResetColumnParameterByID but with a context
+func (c *Client) ResetColumnParameterByIDContext(ctx context.Context, columnID int64, parameter string, resetAnimation bool) error {
+	endpoint := fmt.Sprintf("/composition/columns/by-id/%d/%s/reset", columnID, parameter)
+	body := ResetParameter{
+		ResetAnimation: resetAnimation,
+	}
+	return c.post(ctx, endpoint, body, nil)
+}
+
+// ConnectColumnByID connects the column by id
+//
+// Connect the column, possibly activating it. This works for both regular
+// columns as well as columns inside a layer group.
+func (c *Client) ConnectColumnByID(columnID int64, connect *bool) error {
+	return c.ConnectColumnByIDContext(context.Background(), columnID, connect)
+}
+
+// ConnectColumnByIDContext is like ConnectColumnByID but with a context
+func (c *Client) ConnectColumnByIDContext(ctx context.Context, columnID int64, connect *bool) error {
+	endpoint := fmt.Sprintf("/composition/columns/by-id/%d/connect", columnID)
+	return c.post(ctx, endpoint, connect, nil)
+}
+
+// SelectColumnByID selects the column by id
+//
+// Select the column.
+func (c *Client) SelectColumnByID(columnID int64) error {
+	return c.SelectColumnByIDContext(context.Background(), columnID)
+}
+
+// SelectColumnByIDContext is like SelectColumnByID but with a context
+func (c *Client) SelectColumnByIDContext(ctx context.Context, columnID int64) error {
+	endpoint := fmt.Sprintf("/composition/columns/by-id/%d/select", columnID)
+	return c.post(ctx, endpoint, nil, nil)
 }
 
 // GetLayer retrieves layer properties and clip info by index
+//
+// Returns a single layer and all of its clips.
 func (c *Client) GetLayer(layerIndex int64) (*Layer, error) {
+	return c.GetLayerContext(context.Background(), layerIndex)
+}
+
+// GetLayerContext is like GetLayer but with a context
+func (c *Client) GetLayerContext(ctx context.Context, layerIndex int64) (*Layer, error) {
 	endpoint := fmt.Sprintf("/composition/layers/%d", layerIndex)
 	var layer Layer
-	if err := c.get(endpoint, &layer); err != nil {
+	if err := c.get(ctx, endpoint, &layer); err != nil {
 		return nil, err
 	}
 	return &layer, nil
 }
 
 // ReplaceLayer updates specified layer and/or clips by index
+//
+// Updates a single layer and all of its clips.
 func (c *Client) ReplaceLayer(layerIndex int64, layer *Layer) error {
+	return c.ReplaceLayerContext(context.Background(), layerIndex, layer)
+}
+
+// ReplaceLayerContext is like ReplaceLayer but with a context
+func (c *Client) ReplaceLayerContext(ctx context.Context, layerIndex int64, layer *Layer) error {
 	endpoint := fmt.Sprintf("/composition/layers/%d", layerIndex)
-	return c.put(endpoint, layer, nil)
+	return c.put(ctx, endpoint, layer, nil)
 }
 
 // DeleteLayer removes a layer by index
+//
+// Remove the layer with the given index.
 func (c *Client) DeleteLayer(layerIndex int64) error {
+	return c.DeleteLayerContext(context.Background(), layerIndex)
+}
+
+// DeleteLayerContext is like DeleteLayer but with a context
+func (c *Client) DeleteLayerContext(ctx context.Context, layerIndex int64) error {
 	endpoint := fmt.Sprintf("/composition/layers/%d", layerIndex)
-	return c.delete(endpoint)
+	return c.delete(ctx, endpoint)
 }
 
 // DuplicateLayer duplicates the given layer
+//
+// Take the given layer and make a copy of it. All properties will be the same,
+// except for the unique identifiers. The new layer is inserted into the
+// composition below the existing one.
 func (c *Client) DuplicateLayer(layerIndex int64) error {
+	return c.DuplicateLayerContext(context.Background(), layerIndex)
+}
+
+// DuplicateLayerContext is like DuplicateLayer but with a context
+func (c *Client) DuplicateLayerContext(ctx context.Context, layerIndex int64) error {
 	endpoint := fmt.Sprintf("/composition/layers/%d/duplicate", layerIndex)
-	return c.post(endpoint, nil, nil)
+	return c.post(ctx, endpoint, nil, nil)
 }
 
-// AddLayer adds a new layer to the composition
-func (c *Client) AddLayer(beforeLayerURI string) error {
-	endpoint := "/composition/layers/add"
-	return c.post(endpoint, beforeLayerURI, nil)
+// SetLayerEffectDisplayName changes the display name of an effect
+//
+// Change the display name of the effect with the given offset in the layer.
+func (c *Client) SetLayerEffectDisplayName(layerIndex, effectIndex int64, displayName string) error {
+	return c.SetLayerEffectDisplayNameContext(context.Background(), layerIndex, effectIndex, displayName)
 }
 
-// ResetLayerParameter resets a parameter in a layer to its default value
-func (c *Client) ResetLayerParameter(layerIndex int64, parameter string, resetAnimation bool) error {
-	endpoint := fmt.Sprintf("/composition/layers/%d/%s/reset", layerIndex, parameter)
-	body := ResetParameter{
-		ResetAnimation: resetAnimation,
-	}
-	return c.post(endpoint, body, nil)
+// SetLayerEffectDisplayNameContext is like SetLayerEffectDisplayName but with a context
+func (c *Client) SetLayerEffectDisplayNameContext(ctx context.Context, layerIndex, effectIndex int64, displayName string) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/effects/video/%d/set-display-name", layerIndex, effectIndex)
+	return c.post(ctx, endpoint, plainText(displayName), nil)
 }
 
-// SelectLayer selects the layer by index
-func (c *Client) SelectLayer(layerIndex int64) error {
-	endpoint := fmt.Sprintf("/composition/layers/%d/select", layerIndex)
-	return c.post(endpoint, nil, nil)
+// MoveEffectToLayer moves an effect to the end of the layer
+//
+// Take an existing effect instance, and move it to the end of the layer.
+func (c *Client) MoveEffectToLayer(layerIndex int64, effectURI string) error {
+	return c.MoveEffectToLayerContext(context.Background(), layerIndex, effectURI)
 }
 
-// ClearLayer disconnects any playing clips in the layer by index
-func (c *Client) ClearLayer(layerIndex int64) error {
-	endpoint := fmt.Sprintf("/composition/layers/%d/clear", layerIndex)
-	return c.post(endpoint, nil, nil)
+// MoveEffectToLayerContext is like MoveEffectToLayer but with a context
+func (c *Client) MoveEffectToLayerContext(ctx context.Context, layerIndex int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/effects/video/move", layerIndex)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
 }
 
-// ClearLayerClips clears all clips in the layer by index
-func (c *Client) ClearLayerClips(layerIndex int64) error {
-	endpoint := fmt.Sprintf("/composition/layers/%d/clearclips", layerIndex)
-	return c.post(endpoint, nil, nil)
+// MoveEffectToLayerOffset moves an effect to a specific offset in the layer
+//
+// Take an existing effect instance, and move it to the given offset.
+func (c *Client) MoveEffectToLayerOffset(layerIndex, offset int64, effectURI string) error {
+	return c.MoveEffectToLayerOffsetContext(context.Background(), layerIndex, offset, effectURI)
+}
+
+// MoveEffectToLayerOffsetContext is like MoveEffectToLayerOffset but with a context
+func (c *Client) MoveEffectToLayerOffsetContext(ctx context.Context, layerIndex, offset int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/effects/video/move/%d", layerIndex, offset)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
+}
+
+// AddEffectToLayer adds an effect to a layer by index
+//
+// Add an effect to the layer at the specified index.
+func (c *Client) AddEffectToLayer(layerIndex int64, effectURI string) error {
+	return c.AddEffectToLayerContext(context.Background(), layerIndex, effectURI)
+}
+
+// AddEffectToLayerContext is like AddEffectToLayer but with a context
+func (c *Client) AddEffectToLayerContext(ctx context.Context, layerIndex int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/effects/video/add", layerIndex)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
+}
+
+// AddEffectToLayerAtOffset adds an effect to a layer by index, at the given offset
+//
+// Add an effect to the layer at the specified index.
+func (c *Client) AddEffectToLayerAtOffset(layerIndex, offset int64, effectURI string) error {
+	return c.AddEffectToLayerAtOffsetContext(context.Background(), layerIndex, offset, effectURI)
+}
+
+// AddEffectToLayerAtOffsetContext is like AddEffectToLayerAtOffset but with a context
+func (c *Client) AddEffectToLayerAtOffsetContext(ctx context.Context, layerIndex, offset int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/effects/video/add/%d", layerIndex, offset)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
+}
+
+// DeleteLayerEffect removes an effect from a layer
+func (c *Client) DeleteLayerEffect(layerIndex, offset int64) error {
+	return c.DeleteLayerEffectContext(context.Background(), layerIndex, offset)
+}
+
+// DeleteLayerEffectContext is like DeleteLayerEffect but with a context
+func (c *Client) DeleteLayerEffectContext(ctx context.Context, layerIndex, offset int64) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/effects/video/%d", layerIndex, offset)
+	return c.delete(ctx, endpoint)
 }
 
 // GetSelectedLayer retrieves layer properties and clip info for the selected layers
+//
+// Returns a single layer and all of its clips.
 func (c *Client) GetSelectedLayer() (*Layer, error) {
+	return c.GetSelectedLayerContext(context.Background())
+}
+
+// GetSelectedLayerContext is like GetSelectedLayer but with a context
+func (c *Client) GetSelectedLayerContext(ctx context.Context) (*Layer, error) {
 	endpoint := "/composition/layers/selected"
 	var layer Layer
-	if err := c.get(endpoint, &layer); err != nil {
+	if err := c.get(ctx, endpoint, &layer); err != nil {
 		return nil, err
 	}
 	return &layer, nil
 }
 
 // ReplaceSelectedLayer updates selected layer and/or clips
+//
+// Updates a single layer and all of its clips.
 func (c *Client) ReplaceSelectedLayer(layer *Layer) error {
+	return c.ReplaceSelectedLayerContext(context.Background(), layer)
+}
+
+// ReplaceSelectedLayerContext is like ReplaceSelectedLayer but with a context
+func (c *Client) ReplaceSelectedLayerContext(ctx context.Context, layer *Layer) error {
 	endpoint := "/composition/layers/selected"
-	return c.put(endpoint, layer, nil)
+	return c.put(ctx, endpoint, layer, nil)
 }
 
 // DuplicateSelectedLayer duplicates the selected layer
+//
+// Take the selected layer and make a copy of it. All properties will be the
+// same, except for the unique identifiers. The new layer is inserted into the
+// composition below the existing one.
 func (c *Client) DuplicateSelectedLayer() error {
+	return c.DuplicateSelectedLayerContext(context.Background())
+}
+
+// DuplicateSelectedLayerContext is like DuplicateSelectedLayer but with a context
+func (c *Client) DuplicateSelectedLayerContext(ctx context.Context) error {
 	endpoint := "/composition/layers/selected/duplicate"
-	return c.post(endpoint, nil, nil)
+	return c.post(ctx, endpoint, nil, nil)
 }
 
 // AddEffectToSelectedLayer adds an effect to the selected layer
+//
+// Add an effect to the currently selected layer.
 func (c *Client) AddEffectToSelectedLayer(effectURI string) error {
+	return c.AddEffectToSelectedLayerContext(context.Background(), effectURI)
+}
+
+// AddEffectToSelectedLayerContext is like AddEffectToSelectedLayer but with a context
+func (c *Client) AddEffectToSelectedLayerContext(ctx context.Context, effectURI string) error {
 	endpoint := "/composition/layers/selected/effects/video/add"
-	return c.post(endpoint, effectURI, nil)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
 }
 
 // AddEffectToSelectedLayerAtOffset adds an effect at the given offset to the selected layer
+//
+// Add an effect at the given offset to the currently selected layer.
 func (c *Client) AddEffectToSelectedLayerAtOffset(offset int64, effectURI string) error {
+	return c.AddEffectToSelectedLayerAtOffsetContext(context.Background(), offset, effectURI)
+}
+
+// AddEffectToSelectedLayerAtOffsetContext is like AddEffectToSelectedLayerAtOffset but with a context
+func (c *Client) AddEffectToSelectedLayerAtOffsetContext(ctx context.Context, offset int64, effectURI string) error {
 	endpoint := fmt.Sprintf("/composition/layers/selected/effects/video/add/%d", offset)
-	return c.post(endpoint, effectURI, nil)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
 }
 
-// DeleteSelectedLayerEffect removes an effect from the selected layer
+// DeleteSelectedLayerEffect removes an effect from a layer
 func (c *Client) DeleteSelectedLayerEffect(offset int64) error {
+	return c.DeleteSelectedLayerEffectContext(context.Background(), offset)
+}
+
+// DeleteSelectedLayerEffectContext is like DeleteSelectedLayerEffect but with a context
+func (c *Client) DeleteSelectedLayerEffectContext(ctx context.Context, offset int64) error {
 	endpoint := fmt.Sprintf("/composition/layers/selected/effects/video/%d", offset)
-	return c.delete(endpoint)
+	return c.delete(ctx, endpoint)
+}
+
+// ResetLayerParameter resets a parameter in a layer to its default value
+//
+// This resets a parameter on the indexed layer to default. If a JSON body is
+// given and 'resetanimation' is set to true, animations are also reset.
+func (c *Client) ResetLayerParameter(layerIndex int64, parameter string, resetAnimation bool) error {
+	return c.ResetLayerParameterContext(context.Background(), layerIndex, parameter, resetAnimation)
+}
+
+// ResetLayerParameterContext is like ResetLayerParameter but with a context
+func (c *Client) ResetLayerParameterContext(ctx context.Context, layerIndex int64, parameter string, resetAnimation bool) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/%s/reset", layerIndex, parameter)
+	body := ResetParameter{
+		ResetAnimation: resetAnimation,
+	}
+	return c.post(ctx, endpoint, body, nil)
+}
+
+// AddLayer adds a new layer to the composition
+//
+// This adds a new layer either at the end, or somewhere else in the
+// composition.
+func (c *Client) AddLayer(beforeLayerURI string) error {
+	return c.AddLayerContext(context.Background(), beforeLayerURI)
+}
+
+// AddLayerContext is like AddLayer but with a context
+func (c *Client) AddLayerContext(ctx context.Context, beforeLayerURI string) error {
+	endpoint := "/composition/layers/add"
+	return c.post(ctx, endpoint, uriText(beforeLayerURI), nil)
 }
 
 // ResetSelectedLayerParameter resets a parameter in the selected layer to its default value
+//
+// This resets a parameter on the selected layer to default. If a JSON body is
+// given and 'resetanimation' is set to true, animations are also reset.
 func (c *Client) ResetSelectedLayerParameter(parameter string, resetAnimation bool) error {
+	return c.ResetSelectedLayerParameterContext(context.Background(), parameter, resetAnimation)
+}
+
+// ResetSelectedLayerParameterContext is like ResetSelectedLayerParameter but with a context
+func (c *Client) ResetSelectedLayerParameterContext(ctx context.Context, parameter string, resetAnimation bool) error {
 	endpoint := fmt.Sprintf("/composition/layers/selected/%s/reset", parameter)
 	body := ResetParameter{
 		ResetAnimation: resetAnimation,
 	}
-	return c.post(endpoint, body, nil)
+	return c.post(ctx, endpoint, body, nil)
+}
+
+// SelectLayer selects the layer by index
+//
+// Select the layer at the given index.
+func (c *Client) SelectLayer(layerIndex int64) error {
+	return c.SelectLayerContext(context.Background(), layerIndex)
+}
+
+// SelectLayerContext is like SelectLayer but with a context
+func (c *Client) SelectLayerContext(ctx context.Context, layerIndex int64) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/select", layerIndex)
+	return c.post(ctx, endpoint, nil, nil)
+}
+
+// ClearLayer disconnects any playing clips in the layer by index
+func (c *Client) ClearLayer(layerIndex int64) error {
+	return c.ClearLayerContext(context.Background(), layerIndex)
+}
+
+// ClearLayerContext is like ClearLayer but with a context
+func (c *Client) ClearLayerContext(ctx context.Context, layerIndex int64) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/clear", layerIndex)
+	return c.post(ctx, endpoint, nil, nil)
 }
 
 // ClearSelectedLayer disconnects any playing clips in the selected layer
 func (c *Client) ClearSelectedLayer() error {
+	return c.ClearSelectedLayerContext(context.Background())
+}
+
+// ClearSelectedLayerContext is like ClearSelectedLayer but with a context
+func (c *Client) ClearSelectedLayerContext(ctx context.Context) error {
 	endpoint := "/composition/layers/selected/clear"
-	return c.post(endpoint, nil, nil)
+	return c.post(ctx, endpoint, nil, nil)
+}
+
+// ClearLayerClips clears all clips in the layer by index
+//
+// Clears all clips in the layer by index, removing all tracks and settings from
+// the clips.
+func (c *Client) ClearLayerClips(layerIndex int64) error {
+	return c.ClearLayerClipsContext(context.Background(), layerIndex)
+}
+
+// ClearLayerClipsContext is like ClearLayerClips but with a context
+func (c *Client) ClearLayerClipsContext(ctx context.Context, layerIndex int64) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/clearclips", layerIndex)
+	return c.post(ctx, endpoint, nil, nil)
 }
 
 // ClearSelectedLayerClips clears all clips in the selected layer
+//
+// Clears all clips in the selected layer, removing all tracks and settings from
+// the clips.
 func (c *Client) ClearSelectedLayerClips() error {
+	return c.ClearSelectedLayerClipsContext(context.Background())
+}
+
+// ClearSelectedLayerClipsContext is like ClearSelectedLayerClips but with a context
+func (c *Client) ClearSelectedLayerClipsContext(ctx context.Context) error {
 	endpoint := "/composition/layers/selected/clearclips"
-	return c.post(endpoint, nil, nil)
+	return c.post(ctx, endpoint, nil, nil)
 }
 
-// GetLayerGroup retrieves layer group properties and layer info by index
-func (c *Client) GetLayerGroup(layerGroupIndex int64) (*LayerGroup, error) {
-	endpoint := fmt.Sprintf("/composition/layergroups/%d", layerGroupIndex)
-	var layerGroup LayerGroup
-	if err := c.get(endpoint, &layerGroup); err != nil {
+// GetLayerByID retrieves layer properties and clip info by id
+//
+// Returns a single layer and all of its clips.
+func (c *Client) GetLayerByID(layerID int64) (*Layer, error) {
+	return c.GetLayerByIDContext(context.Background(), layerID)
+}
+
+// GetLayerByIDContext is like GetLayerByID but with a context
+func (c *Client) GetLayerByIDContext(ctx context.Context, layerID int64) (*Layer, error) {
+	endpoint := fmt.Sprintf("/composition/layers/by-id/%d", layerID)
+	var layer Layer
+	if err := c.get(ctx, endpoint, &layer); err != nil {
 		return nil, err
 	}
-	return &layerGroup, nil
+	return &layer, nil
 }
 
-// ReplaceLayerGroup updates specified layer group and/or layers by index
-func (c *Client) ReplaceLayerGroup(layerGroupIndex int64, layerGroup *LayerGroup) error {
-	endpoint := fmt.Sprintf("/composition/layergroups/%d", layerGroupIndex)
-	return c.put(endpoint, layerGroup, nil)
+// ReplaceLayerByID updates specified layer and/or clips by id
+//
+// Updates a single layer and all of its clips.
+func (c *Client) ReplaceLayerByID(layerID int64, layer *Layer) error {
+	return c.ReplaceLayerByIDContext(context.Background(), layerID, layer)
 }
 
-// DeleteLayerGroup removes a layer group by index
-func (c *Client) DeleteLayerGroup(layerGroupIndex int64) error {
-	endpoint := fmt.Sprintf("/composition/layergroups/%d", layerGroupIndex)
-	return c.delete(endpoint)
+// ReplaceLayerByIDContext is like ReplaceLayerByID but with a context
+func (c *Client) ReplaceLayerByIDContext(ctx context.Context, layerID int64, layer *Layer) error {
+	endpoint := fmt.Sprintf("/composition/layers/by-id/%d", layerID)
+	return c.put(ctx, endpoint, layer, nil)
 }
 
-// DuplicateLayerGroup duplicates the given layer group
-func (c *Client) DuplicateLayerGroup(layerGroupIndex int64) error {
-	endpoint := fmt.Sprintf("/composition/layergroups/%d/duplicate", layerGroupIndex)
-	return c.post(endpoint, nil, nil)
+// DeleteLayerByID removes specified layer by id
+//
+// Remove the layer with the given id.
+func (c *Client) DeleteLayerByID(layerID int64) error {
+	return c.DeleteLayerByIDContext(context.Background(), layerID)
 }
 
-// MoveLayerToGroup adds an existing layer to an existing layer group
-func (c *Client) MoveLayerToGroup(layerGroupIndex int64, layerURI string) error {
-	endpoint := fmt.Sprintf("/composition/layergroups/%d/move-layer", layerGroupIndex)
-	return c.post(endpoint, layerURI, nil)
+// DeleteLayerByIDContext is like DeleteLayerByID but with a context
+func (c *Client) DeleteLayerByIDContext(ctx context.Context, layerID int64) error {
+	endpoint := fmt.Sprintf("/composition/layers/by-id/%d", layerID)
+	return c.delete(ctx, endpoint)
 }
 
-// AddLayerToGroup adds a new layer to an existing layer group
-func (c *Client) AddLayerToGroup(layerGroupIndex int64, beforeLayerURI string) error {
-	endpoint := fmt.Sprintf("/composition/layergroups/%d/add-layer", layerGroupIndex)
-	return c.post(endpoint, beforeLayerURI, nil)
+// DuplicateLayerByID duplicates the given layer
+//
+// Take the given layer and make a copy of it. All properties will be the same,
+// except for the unique identifiers. The new layer is inserted into the
+// composition below the existing one.
+func (c *Client) DuplicateLayerByID(layerID int64) error {
+	return c.DuplicateLayerByIDContext(context.Background(), layerID)
 }
 
-// AddLayerGroup adds a new layer group to the composition
-func (c *Client) AddLayerGroup(beforeLayerOrGroupURI string) error {
-	endpoint := "/composition/layergroups/add"
-	return c.post(endpoint, beforeLayerOrGroupURI, nil)
+// DuplicateLayerByIDContext is like DuplicateLayerByID but with a context
+func (c *Client) DuplicateLayerByIDContext(ctx context.Context, layerID int64) error {
+	endpoint := fmt.Sprintf("/composition/layers/by-id/%d/duplicate", layerID)
+	return c.post(ctx, endpoint, nil, nil)
 }
 
-// ResetLayerGroupParameter resets a parameter in a layer group to its default value
-func (c *Client) ResetLayerGroupParameter(layerGroupIndex int64, parameter string, resetAnimation bool) error {
-	endpoint := fmt.Sprintf("/composition/layergroups/%d/%s/reset", layerGroupIndex, parameter)
-	body := ResetParameter{
-		ResetAnimation: resetAnimation,
-	}
-	return c.post(endpoint, body, nil)
+// MoveEffectToLayerByID moves an effect to the end of a layer
+//
+// Take an existing effect instance, and move it to the end of the layer.
+func (c *Client) MoveEffectToLayerByID(layerID int64, effectURI string) error {
+	return c.MoveEffectToLayerByIDContext(context.Background(), layerID, effectURI)
 }
 
-// SelectLayerGroup selects the layer group by index
-func (c *Client) SelectLayerGroup(layerGroupIndex int64) error {
-	endpoint := fmt.Sprintf("/composition/layergroups/%d/select", layerGroupIndex)
-	return c.post(endpoint, nil, nil)
+// MoveEffectToLayerByIDContext is like MoveEffectToLayerByID but with a context
+func (c *Client) MoveEffectToLayerByIDContext(ctx context.Context, layerID int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/layers/by-id/%d/effects/video/move", layerID)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
 }
 
-// GetSelectedLayerGroup retrieves selected layer group properties and layer info
-func (c *Client) GetSelectedLayerGroup() (*LayerGroup, error) {
-	endpoint := "/composition/layergroups/selected"
-	var layerGroup LayerGroup
-	if err := c.get(endpoint, &layerGroup); err != nil {
-		return nil, err
-	}
-	return &layerGroup, nil
+// MoveEffectToLayerByIDOffset moves an effect to a specific offset inside the layer
+//
+// Take an existing effect instance, and move it to the given offset.
+func (c *Client) MoveEffectToLayerByIDOffset(layerID, offset int64, effectURI string) error {
+	return c.MoveEffectToLayerByIDOffsetContext(context.Background(), layerID, offset, effectURI)
 }
 
-// ReplaceSelectedLayerGroup updates selected layer group and/or layers
-func (c *Client) ReplaceSelectedLayerGroup(layerGroup *LayerGroup) error {
-	endpoint := "/composition/layergroups/selected"
-	return c.put(endpoint, layerGroup, nil)
+// MoveEffectToLayerByIDOffsetContext is like MoveEffectToLayerByIDOffset but with a context
+func (c *Client) MoveEffectToLayerByIDOffsetContext(ctx context.Context, layerID, offset int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/layers/by-id/%d/effects/video/move/%d", layerID, offset)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
 }
 
-// DeleteSelectedLayerGroup removes the selected layer group
-func (c *Client) DeleteSelectedLayerGroup() error {
-	endpoint := "/composition/layergroups/selected"
-	return c.delete(endpoint)
+// AddEffectToLayerByID adds an effect to a layer by unique id
+//
+// Add an effect to the layer with the given unique id.
+func (c *Client) AddEffectToLayerByID(layerID int64, effectURI string) error {
+	return c.AddEffectToLayerByIDContext(context.Background(), layerID, effectURI)
 }
 
-// DuplicateSelectedLayerGroup duplicates the selected layer group
-func (c *Client) DuplicateSelectedLayerGroup() error {
-	endpoint := "/composition/layergroups/selected/duplicate"
-	return c.post(endpoint, nil, nil)
+// AddEffectToLayerByIDContext is like AddEffectToLayerByID but with a context
+func (c *Client) AddEffectToLayerByIDContext(ctx context.Context, layerID int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/layers/by-id/%d/effects/video/add", layerID)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
 }
 
-// MoveLayerToSelectedGroup adds an existing layer to the selected layer group
-func (c *Client) MoveLayerToSelectedGroup(layerURI string) error {
-	endpoint := "/composition/layergroups/selected/move-layer"
-	return c.post(endpoint, layerURI, nil)
+// AddEffectToLayerByIDAtOffset adds an effect to the layer with the given id, at the given offset
+//
+// Add an effect to the layer with the given unique id.
+func (c *Client) AddEffectToLayerByIDAtOffset(layerID, offset int64, effectURI string) error {
+	return c.AddEffectToLayerByIDAtOffsetContext(context.Background(), layerID, offset, effectURI)
 }
 
-// AddLayerToSelectedGroup adds a new layer to the selected layer group
-func (c *Client) AddLayerToSelectedGroup(beforeLayerURI string) error {
-	endpoint := "/composition/layergroups/selected/add-layer"
-	return c.post(endpoint, beforeLayerURI, nil)
+// AddEffectToLayerByIDAtOffsetContext is like AddEffectToLayerByIDAtOffset but with a context
+func (c *Client) AddEffectToLayerByIDAtOffsetContext(ctx context.Context, layerID, offset int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/layers/by-id/%d/effects/video/add/%d", layerID, offset)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
 }
 
-// ResetSelectedLayerGroupParameter resets a parameter in the selected layer group to its default value
-func (c *Client) ResetSelectedLayerGroupParameter(parameter string, resetAnimation bool) error {
-	endpoint := fmt.Sprintf("/composition/layergroups/selected/%s/reset", parameter)
+// DeleteLayerEffectByID removes an effect from a layer
+func (c *Client) DeleteLayerEffectByID(layerID, offset int64) error {
+	return c.DeleteLayerEffectByIDContext(context.Background(), layerID, offset)
+}
+
+// DeleteLayerEffectByIDContext is like DeleteLayerEffectByID but with a context
+func (c *Client) DeleteLayerEffectByIDContext(ctx context.Context, layerID, offset int64) error {
+	endpoint := fmt.Sprintf("/composition/layers/by-id/%d/effects/video/%d", layerID, offset)
+	return c.delete(ctx, endpoint)
+}
+
+// ResetLayerParameterByID resets a parameter in a layer to its default value
+//
+// This resets a parameter on the indexed layer to default. If a JSON body is
+// given and 'resetanimation' is set to true, animations are also reset.
+func (c *Client) ResetLayerParameterByID(layerID int64, parameter string, resetAnimation bool) error {
+	return c.ResetLayerParameterByIDContext(context.Background(), layerID, parameter, resetAnimation)
+}
+
+// ResetLayerParameterByIDContext is like ResetLayerParameterByID but with a context
+func (c *Client) ResetLayerParameterByIDContext(ctx context.Context, layerID int64, parameter string, resetAnimation bool) error {
+	endpoint := fmt.Sprintf("/composition/layers/by-id/%d/%s/reset", layerID, parameter)
 	body := ResetParameter{
 		ResetAnimation: resetAnimation,
 	}
-	return c.post(endpoint, body, nil)
+	return c.post(ctx, endpoint, body, nil)
 }
 
-// GetDeck retrieves deck properties by index
-func (c *Client) GetDeck(deckIndex int64) (*Deck, error) {
-	endpoint := fmt.Sprintf("/composition/decks/%d", deckIndex)
-	var deck Deck
-	if err := c.get(endpoint, &deck); err != nil {
-		return nil, err
-	}
-	return &deck, nil
+// SelectLayerByID selects the layer by id
+//
+// Select the layer with the given id.
+func (c *Client) SelectLayerByID(layerID int64) error {
+	return c.SelectLayerByIDContext(context.Background(), layerID)
 }
 
-// ReplaceDeck updates a specific deck by index
-func (c *Client) ReplaceDeck(deckIndex int64, deck *Deck) error {
-	endpoint := fmt.Sprintf("/composition/decks/%d", deckIndex)
-	return c.put(endpoint, deck, nil)
+// SelectLayerByIDContext is like SelectLayerByID but with a context
+func (c *Client) SelectLayerByIDContext(ctx context.Context, layerID int64) error {
+	endpoint := fmt.Sprintf("/composition/layers/by-id/%d/select", layerID)
+	return c.post(ctx, endpoint, nil, nil)
 }
 
-// DeleteDeck removes a deck by index
-func (c *Client) DeleteDeck(deckIndex int64) error {
-	endpoint := fmt.Sprintf("/composition/decks/%d", deckIndex)
-	return c.delete(endpoint)
+// ClearLayerByID disconnects any playing clips in the layer by id
+func (c *Client) ClearLayerByID(layerID int64) error {
+	return c.ClearLayerByIDContext(context.Background(), layerID)
 }
 
-// DuplicateDeck duplicates the given deck
-func (c *Client) DuplicateDeck(deckIndex int64) error {
+// ClearLayerByIDContext is like ClearLayerByID but with a context
+func (c *Client) ClearLayerByIDContext(ctx context.Context, layerID int64) error {
+	endpoint := fmt.Sprintf("/composition/layers/by-id/%d/clear", layerID)
+	return c.post(ctx, endpoint, nil, nil)
+}
+
+// ClearLayerClipsByID clears all clips in the layer by id
+//
+// Clears all clips in the layer by id, removing all tracks and settings from
+// the clips.
+func (c *Client) ClearLayerClipsByID(layerID int64) error {
+	return c.ClearLayerClipsByIDContext(context.Background(), layerID)
+}
+
+// ClearLayerClipsByIDContext is like ClearLayerClipsByID but with a context
+func (c *Client) ClearLayerClipsByIDContext(ctx context.Context, layerID int64) error {
+	endpoint := fmt.Sprintf("/composition/layers/by-id/%d/clearclips", layerID)
+	return c.post(ctx, endpoint, nil, nil)
+}
+
+// GetLayerGroup retrieves layer group properties and layer info by index
+//
+// Returns a single layer group and all of its layers.
+func (c *Client) GetLayerGroup(layerGroupIndex int64) (*LayerGroup, error) {
+	return c.GetLayerGroupContext(context.Background(), layerGroupIndex)
+}
+
+// GetLayerGroupContext is like GetLayerGroup but with a context
+func (c *Client) GetLayerGroupContext(ctx context.Context, layerGroupIndex int64) (*LayerGroup, error) {
+	endpoint := fmt.Sprintf("/composition/layergroups/%d", layerGroupIndex)
+	var layerGroup LayerGroup
+	if err := c.get(ctx, endpoint, &layerGroup); err != nil {
+		return nil, err
+	}
+	return &layerGroup, nil
+}
+
+// ReplaceLayerGroup updates specified layer group and/or layers by index
+//
+// Updates a single layer group and all of its layers.
+func (c *Client) ReplaceLayerGroup(layerGroupIndex int64, layerGroup *LayerGroup) error {
+	return c.ReplaceLayerGroupContext(context.Background(), layerGroupIndex, layerGroup)
+}
+
+// ReplaceLayerGroupContext is like ReplaceLayerGroup but with a context
+func (c *Client) ReplaceLayerGroupContext(ctx context.Context, layerGroupIndex int64, layerGroup *LayerGroup) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/%d", layerGroupIndex)
+	return c.put(ctx, endpoint, layerGroup, nil)
+}
+
+// DeleteLayerGroup removes a layer group by index
+//
+// Remove the layer group with the given index.
+func (c *Client) DeleteLayerGroup(layerGroupIndex int64) error {
+	return c.DeleteLayerGroupContext(context.Background(), layerGroupIndex)
+}
+
+// DeleteLayerGroupContext is like DeleteLayerGroup but with a context
+func (c *Client) DeleteLayerGroupContext(ctx context.Context, layerGroupIndex int64) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/%d", layerGroupIndex)
+	return c.delete(ctx, endpoint)
+}
+
+// ClearLayerGroup disconnects any playing clips in the layer group by index
+func (c *Client) ClearLayerGroup(layerGroupIndex int64, clear *bool) error {
+	return c.ClearLayerGroupContext(context.Background(), layerGroupIndex, clear)
+}
+
+// ClearLayerGroupContext is like ClearLayerGroup but with a context
+func (c *Client) ClearLayerGroupContext(ctx context.Context, layerGroupIndex int64, clear *bool) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/%d/clear", layerGroupIndex)
+	return c.post(ctx, endpoint, clear, nil)
+}
+
+// ClearSelectedLayerGroup disconnects any playing clips in the selected layer group
+func (c *Client) ClearSelectedLayerGroup(clear *bool) error {
+	return c.ClearSelectedLayerGroupContext(context.Background(), clear)
+}
+
+// ClearSelectedLayerGroupContext is like ClearSelectedLayerGroup but with a context
+func (c *Client) ClearSelectedLayerGroupContext(ctx context.Context, clear *bool) error {
+	endpoint := "/composition/layergroups/selected/clear"
+	return c.post(ctx, endpoint, clear, nil)
+}
+
+// DuplicateLayerGroup duplicates the given layer group
+//
+// Take the given layer group and make a copy of it. All properties will be the
+// same, except for the unique identifiers. The new layer group is inserted into
+// the composition below the existing one.
+func (c *Client) DuplicateLayerGroup(layerGroupIndex int64) error {
+	return c.DuplicateLayerGroupContext(context.Background(), layerGroupIndex)
+}
+
+// DuplicateLayerGroupContext is like DuplicateLayerGroup but with a context
+func (c *Client) DuplicateLayerGroupContext(ctx context.Context, layerGroupIndex int64) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/%d/duplicate", layerGroupIndex)
+	return c.post(ctx, endpoint, nil, nil)
+}
+
+// MoveLayerToGroup adds an existing layer to an existing layer group
+//
+// Given an existing layer, identified by the path in the body, move it into the
+// layer group at the given position in the composition.
+func (c *Client) MoveLayerToGroup(layerGroupIndex int64, layerURI string) error {
+	return c.MoveLayerToGroupContext(context.Background(), layerGroupIndex, layerURI)
+}
+
+// MoveLayerToGroupContext is like MoveLayerToGroup but with a context
+func (c *Client) MoveLayerToGroupContext(ctx context.Context, layerGroupIndex int64, layerURI string) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/%d/move-layer", layerGroupIndex)
+	return c.post(ctx, endpoint, uriText(layerURI), nil)
+}
+
+// AddLayerToGroup adds a new layer to an existing layer group
+//
+// Create a new layer inside a layer group. If the path to an existing layer is
+// given, the new layer is inserted before.
+func (c *Client) AddLayerToGroup(layerGroupIndex int64, beforeLayerURI string) error {
+	return c.AddLayerToGroupContext(context.Background(), layerGroupIndex, beforeLayerURI)
+}
+
+// AddLayerToGroupContext is like AddLayerToGroup but with a context
+func (c *Client) AddLayerToGroupContext(ctx context.Context, layerGroupIndex int64, beforeLayerURI string) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/%d/add-layer", layerGroupIndex)
+	return c.post(ctx, endpoint, uriText(beforeLayerURI), nil)
+}
+
+// AddLayerGroup adds a new layer group to the composition
+//
+// This adds a new layer group either at the end, or somewhere else in the
+// composition.
+func (c *Client) AddLayerGroup(beforeLayerOrGroupURI string) error {
+	return c.AddLayerGroupContext(context.Background(), beforeLayerOrGroupURI)
+}
+
+// AddLayerGroupContext is like AddLayerGroup but with a context
+func (c *Client) AddLayerGroupContext(ctx context.Context, beforeLayerOrGroupURI string) error {
+	endpoint := "/composition/layergroups/add"
+	return c.post(ctx, endpoint, uriText(beforeLayerOrGroupURI), nil)
+}
+
+// SetLayerGroupEffectDisplayName changes the display name of an effect
+//
+// Change the display name of the effect with the given offset in the layer
+// group.
+func (c *Client) SetLayerGroupEffectDisplayName(layerGroupIndex, effectIndex int64, displayName string) error {
+	return c.SetLayerGroupEffectDisplayNameContext(context.Background(), layerGroupIndex, effectIndex, displayName)
+}
+
+// SetLayerGroupEffectDisplayNameContext is like SetLayerGroupEffectDisplayName but with a context
+func (c *Client) SetLayerGroupEffectDisplayNameContext(ctx context.Context, layerGroupIndex, effectIndex int64, displayName string) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/%d/effects/video/%d/set-display-name", layerGroupIndex, effectIndex)
+	return c.post(ctx, endpoint, plainText(displayName), nil)
+}
+
+// MoveEffectToLayerGroup moves an effect to the end of the layer group
+//
+// Take an existing effect instance, and move it to the end of the layer group.
+func (c *Client) MoveEffectToLayerGroup(layerGroupIndex int64, effectURI string) error {
+	return c.MoveEffectToLayerGroupContext(context.Background(), layerGroupIndex, effectURI)
+}
+
+// MoveEffectToLayerGroupContext is like MoveEffectToLayerGroup but with a context
+func (c *Client) MoveEffectToLayerGroupContext(ctx context.Context, layerGroupIndex int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/%d/effects/video/move", layerGroupIndex)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
+}
+
+// MoveEffectToLayerGroupOffset moves an effect to the given offset in the layer group
+//
+// Take an existing effect instance, and move it to the given offset.
+func (c *Client) MoveEffectToLayerGroupOffset(layerGroupIndex, offset int64, effectURI string) error {
+	return c.MoveEffectToLayerGroupOffsetContext(context.Background(), layerGroupIndex, offset, effectURI)
+}
+
+// MoveEffectToLayerGroupOffsetContext is like MoveEffectToLayerGroupOffset but with a context
+func (c *Client) MoveEffectToLayerGroupOffsetContext(ctx context.Context, layerGroupIndex, offset int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/%d/effects/video/move/%d", layerGroupIndex, offset)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
+}
+
+// AddEffectToLayerGroup adds an effect to a layer group by index
+//
+// Add an effect to the layer group at the specified index.
+func (c *Client) AddEffectToLayerGroup(layerGroupIndex int64, effectURI string) error {
+	return c.AddEffectToLayerGroupContext(context.Background(), layerGroupIndex, effectURI)
+}
+
+// AddEffectToLayerGroupContext is like AddEffectToLayerGroup but with a context
+func (c *Client) AddEffectToLayerGroupContext(ctx context.Context, layerGroupIndex int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/%d/effects/video/add", layerGroupIndex)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
+}
+
+// AddEffectToLayerGroupAtOffset adds an effect to a layer group by index, at the given offset
+//
+// Add an effect to the layer group at the specified index.
+func (c *Client) AddEffectToLayerGroupAtOffset(layerGroupIndex, offset int64, effectURI string) error {
+	return c.AddEffectToLayerGroupAtOffsetContext(context.Background(), layerGroupIndex, offset, effectURI)
+}
+
+// AddEffectToLayerGroupAtOffsetContext is like AddEffectToLayerGroupAtOffset but with a context
+func (c *Client) AddEffectToLayerGroupAtOffsetContext(ctx context.Context, layerGroupIndex, offset int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/%d/effects/video/add/%d", layerGroupIndex, offset)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
+}
+
+// DeleteLayerGroupEffect removes an effect from a layer group
+func (c *Client) DeleteLayerGroupEffect(layerGroupIndex, offset int64) error {
+	return c.DeleteLayerGroupEffectContext(context.Background(), layerGroupIndex, offset)
+}
+
+// DeleteLayerGroupEffectContext is like DeleteLayerGroupEffect but with a context
+func (c *Client) DeleteLayerGroupEffectContext(ctx context.Context, layerGroupIndex, offset int64) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/%d/effects/video/%d", layerGroupIndex, offset)
+	return c.delete(ctx, endpoint)
+}
+
+// ConnectLayerGroupColumn connects the column in the layergroup by index
+//
+// Connect the column, possibly activating it.
+func (c *Client) ConnectLayerGroupColumn(layerGroupIndex, columnIndex int64, connect *bool) error {
+	return c.ConnectLayerGroupColumnContext(context.Background(), layerGroupIndex, columnIndex, connect)
+}
+
+// ConnectLayerGroupColumnContext is like ConnectLayerGroupColumn but with a context
+func (c *Client) ConnectLayerGroupColumnContext(ctx context.Context, layerGroupIndex, columnIndex int64, connect *bool) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/%d/columns/%d/connect", layerGroupIndex, columnIndex)
+	return c.post(ctx, endpoint, connect, nil)
+}
+
+// SelectLayerGroupColumn selects the column in the layergroup by index
+//
+// Select the column.
+func (c *Client) SelectLayerGroupColumn(layerGroupIndex, columnIndex int64) error {
+	return c.SelectLayerGroupColumnContext(context.Background(), layerGroupIndex, columnIndex)
+}
+
+// SelectLayerGroupColumnContext is like SelectLayerGroupColumn but with a context
+func (c *Client) SelectLayerGroupColumnContext(ctx context.Context, layerGroupIndex, columnIndex int64) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/%d/columns/%d/select", layerGroupIndex, columnIndex)
+	return c.post(ctx, endpoint, nil, nil)
+}
+
+// GetSelectedLayerGroup retrieves selected layer group properties and layer info
+//
+// Returns a single layer group and all of its layers.
+func (c *Client) GetSelectedLayerGroup() (*LayerGroup, error) {
+	return c.GetSelectedLayerGroupContext(context.Background())
+}
+
+// GetSelectedLayerGroupContext is like GetSelectedLayerGroup but with a context
+func (c *Client) GetSelectedLayerGroupContext(ctx context.Context) (*LayerGroup, error) {
+	endpoint := "/composition/layergroups/selected"
+	var layerGroup LayerGroup
+	if err := c.get(ctx, endpoint, &layerGroup); err != nil {
+		return nil, err
+	}
+	return &layerGroup, nil
+}
+
+// ReplaceSelectedLayerGroup updates selected layer group and/or layers
+//
+// Updates a single layer group and all of its layers.
+func (c *Client) ReplaceSelectedLayerGroup(layerGroup *LayerGroup) error {
+	return c.ReplaceSelectedLayerGroupContext(context.Background(), layerGroup)
+}
+
+// ReplaceSelectedLayerGroupContext is like ReplaceSelectedLayerGroup but with a context
+func (c *Client) ReplaceSelectedLayerGroupContext(ctx context.Context, layerGroup *LayerGroup) error {
+	endpoint := "/composition/layergroups/selected"
+	return c.put(ctx, endpoint, layerGroup, nil)
+}
+
+// DeleteSelectedLayerGroup removes the selected layer group
+//
+// If a layer group is selected, it will be removed.
+func (c *Client) DeleteSelectedLayerGroup() error {
+	return c.DeleteSelectedLayerGroupContext(context.Background())
+}
+
+// DeleteSelectedLayerGroupContext is like DeleteSelectedLayerGroup but with a context
+func (c *Client) DeleteSelectedLayerGroupContext(ctx context.Context) error {
+	endpoint := "/composition/layergroups/selected"
+	return c.delete(ctx, endpoint)
+}
+
+// DuplicateSelectedLayerGroup duplicates the selected layer group
+//
+// Take the selected layer group and make a copy of it. All properties will be
+// the same, except for the unique identifiers. The new layer group is inserted
+// into the composition below the existing one.
+func (c *Client) DuplicateSelectedLayerGroup() error {
+	return c.DuplicateSelectedLayerGroupContext(context.Background())
+}
+
+// DuplicateSelectedLayerGroupContext is like DuplicateSelectedLayerGroup but with a context
+func (c *Client) DuplicateSelectedLayerGroupContext(ctx context.Context) error {
+	endpoint := "/composition/layergroups/selected/duplicate"
+	return c.post(ctx, endpoint, nil, nil)
+}
+
+// MoveLayerToSelectedGroup adds an existing layer to the selected layer group
+//
+// Given an existing layer, identified by the path in the body, move it into the
+// currently selected layer group.
+func (c *Client) MoveLayerToSelectedGroup(layerURI string) error {
+	return c.MoveLayerToSelectedGroupContext(context.Background(), layerURI)
+}
+
+// MoveLayerToSelectedGroupContext is like MoveLayerToSelectedGroup but with a context
+func (c *Client) MoveLayerToSelectedGroupContext(ctx context.Context, layerURI string) error {
+	endpoint := "/composition/layergroups/selected/move-layer"
+	return c.post(ctx, endpoint, uriText(layerURI), nil)
+}
+
+// AddLayerToSelectedGroup adds new layer to the selected layer group
+//
+// Create a new layer inside a layer group. If the path to an existing layer is
+// given, the new layer is inserted before.
+func (c *Client) AddLayerToSelectedGroup(beforeLayerURI string) error {
+	return c.AddLayerToSelectedGroupContext(context.Background(), beforeLayerURI)
+}
+
+// AddLayerToSelectedGroupContext is like AddLayerToSelectedGroup but with a context
+func (c *Client) AddLayerToSelectedGroupContext(ctx context.Context, beforeLayerURI string) error {
+	endpoint := "/composition/layergroups/selected/add-layer"
+	return c.post(ctx, endpoint, uriText(beforeLayerURI), nil)
+}
+
+// AddEffectToSelectedLayerGroup adds an effect to the selected layer group
+func (c *Client) AddEffectToSelectedLayerGroup(effectURI string) error {
+	return c.AddEffectToSelectedLayerGroupContext(context.Background(), effectURI)
+}
+
+// AddEffectToSelectedLayerGroupContext is like AddEffectToSelectedLayerGroup but with a context
+func (c *Client) AddEffectToSelectedLayerGroupContext(ctx context.Context, effectURI string) error {
+	endpoint := "/composition/layergroups/selected/effects/video/add"
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
+}
+
+// AddEffectToSelectedLayerGroupAtOffset adds an effect at the given offset to the selected layer group
+//
+// Add an effect to the selected layer group.
+func (c *Client) AddEffectToSelectedLayerGroupAtOffset(offset int64, effectURI string) error {
+	return c.AddEffectToSelectedLayerGroupAtOffsetContext(context.Background(), offset, effectURI)
+}
+
+// AddEffectToSelectedLayerGroupAtOffsetContext is like AddEffectToSelectedLayerGroupAtOffset but with a context
+func (c *Client) AddEffectToSelectedLayerGroupAtOffsetContext(ctx context.Context, offset int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/selected/effects/video/add/%d", offset)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
+}
+
+// DeleteSelectedLayerGroupEffect removes an effect from a layer group
+func (c *Client) DeleteSelectedLayerGroupEffect(offset int64) error {
+	return c.DeleteSelectedLayerGroupEffectContext(context.Background(), offset)
+}
+
+// DeleteSelectedLayerGroupEffectContext is like DeleteSelectedLayerGroupEffect but with a context
+func (c *Client) DeleteSelectedLayerGroupEffectContext(ctx context.Context, offset int64) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/selected/effects/video/%d", offset)
+	return c.delete(ctx, endpoint)
+}
+
+// ResetLayerGroupParameter resets a parameter in a layer group to its default value
+//
+// This resets a parameter on the indexed layer group to default. If a JSON body
+// is given and 'resetanimation' is set to true, animations are also reset.
+func (c *Client) ResetLayerGroupParameter(layerGroupIndex int64, parameter string, resetAnimation bool) error {
+	return c.ResetLayerGroupParameterContext(context.Background(), layerGroupIndex, parameter, resetAnimation)
+}
+
+// ResetLayerGroupParameterContext is like ResetLayerGroupParameter but with a context
+func (c *Client) ResetLayerGroupParameterContext(ctx context.Context, layerGroupIndex int64, parameter string, resetAnimation bool) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/%d/%s/reset", layerGroupIndex, parameter)
+	body := ResetParameter{
+		ResetAnimation: resetAnimation,
+	}
+	return c.post(ctx, endpoint, body, nil)
+}
+
+// ResetSelectedLayerGroupParameter resets a parameter in the selected layer group to its default value
+//
+// This resets a parameter on the selected layer group to default. If a JSON
+// body is given and 'resetanimation' is set to true, animations are also reset.
+func (c *Client) ResetSelectedLayerGroupParameter(parameter string, resetAnimation bool) error {
+	return c.ResetSelectedLayerGroupParameterContext(context.Background(), parameter, resetAnimation)
+}
+
+// ResetSelectedLayerGroupParameterContext is like ResetSelectedLayerGroupParameter but with a context
+func (c *Client) ResetSelectedLayerGroupParameterContext(ctx context.Context, parameter string, resetAnimation bool) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/selected/%s/reset", parameter)
+	body := ResetParameter{
+		ResetAnimation: resetAnimation,
+	}
+	return c.post(ctx, endpoint, body, nil)
+}
+
+// SelectLayerGroup selects the layer group by index
+//
+// Select the layer group at the given index.
+func (c *Client) SelectLayerGroup(layerGroupIndex int64) error {
+	return c.SelectLayerGroupContext(context.Background(), layerGroupIndex)
+}
+
+// SelectLayerGroupContext is like SelectLayerGroup but with a context
+func (c *Client) SelectLayerGroupContext(ctx context.Context, layerGroupIndex int64) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/%d/select", layerGroupIndex)
+	return c.post(ctx, endpoint, nil, nil)
+}
+
+// GetLayerGroupColumn retrieves the column inside the layer group
+//
+// Returns a single column from the requested layer group.
+func (c *Client) GetLayerGroupColumn(layerGroupIndex, columnIndex int64) (*Column, error) {
+	return c.GetLayerGroupColumnContext(context.Background(), layerGroupIndex, columnIndex)
+}
+
+// GetLayerGroupColumnContext is like GetLayerGroupColumn but with a context
+func (c *Client) GetLayerGroupColumnContext(ctx context.Context, layerGroupIndex, columnIndex int64) (*Column, error) {
+	endpoint := fmt.Sprintf("/composition/layergroups/%d/columns/%d", layerGroupIndex, columnIndex)
+	var column Column
+	if err := c.get(ctx, endpoint, &column); err != nil {
+		return nil, err
+	}
+	return &column, nil
+}
+
+// ReplaceLayerGroupColumn updates layer group column
+//
+// Update the column inside the specified layer group by its position inside the
+// composition.
+func (c *Client) ReplaceLayerGroupColumn(layerGroupIndex, columnIndex int64, column *Column) error {
+	return c.ReplaceLayerGroupColumnContext(context.Background(), layerGroupIndex, columnIndex, column)
+}
+
+// ReplaceLayerGroupColumnContext is like ReplaceLayerGroupColumn but with a context
+func (c *Client) ReplaceLayerGroupColumnContext(ctx context.Context, layerGroupIndex, columnIndex int64, column *Column) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/%d/columns/%d", layerGroupIndex, columnIndex)
+	return c.post(ctx, endpoint, column, nil)
+}
+
+// GetLayerGroupByID retrieves layer group properties and layer info by id
+//
+// Returns a single layer group and all of its layers.
+func (c *Client) GetLayerGroupByID(layerGroupID int64) (*LayerGroup, error) {
+	return c.GetLayerGroupByIDContext(context.Background(), layerGroupID)
+}
+
+// GetLayerGroupByIDContext is like GetLayerGroupByID but with a context
+func (c *Client) GetLayerGroupByIDContext(ctx context.Context, layerGroupID int64) (*LayerGroup, error) {
+	endpoint := fmt.Sprintf("/composition/layergroups/by-id/%d", layerGroupID)
+	var layerGroup LayerGroup
+	if err := c.get(ctx, endpoint, &layerGroup); err != nil {
+		return nil, err
+	}
+	return &layerGroup, nil
+}
+
+// ReplaceLayerGroupByID updates specified layer group and/or layers by id
+//
+// Updates a single layer group and all of its layers.
+func (c *Client) ReplaceLayerGroupByID(layerGroupID int64, layerGroup *LayerGroup) error {
+	return c.ReplaceLayerGroupByIDContext(context.Background(), layerGroupID, layerGroup)
+}
+
+// ReplaceLayerGroupByIDContext is like ReplaceLayerGroupByID but with a context
+func (c *Client) ReplaceLayerGroupByIDContext(ctx context.Context, layerGroupID int64, layerGroup *LayerGroup) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/by-id/%d", layerGroupID)
+	return c.put(ctx, endpoint, layerGroup, nil)
+}
+
+// DeleteLayerGroupByID removes specified layer group by id
+//
+// Remove the layer group with the given id.
+func (c *Client) DeleteLayerGroupByID(layerGroupID int64) error {
+	return c.DeleteLayerGroupByIDContext(context.Background(), layerGroupID)
+}
+
+// DeleteLayerGroupByIDContext is like DeleteLayerGroupByID but with a context
+func (c *Client) DeleteLayerGroupByIDContext(ctx context.Context, layerGroupID int64) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/by-id/%d", layerGroupID)
+	return c.delete(ctx, endpoint)
+}
+
+// ClearLayerGroupByID disconnects any playing clips in the layer group by id
+func (c *Client) ClearLayerGroupByID(layerGroupID int64, clear *bool) error {
+	return c.ClearLayerGroupByIDContext(context.Background(), layerGroupID, clear)
+}
+
+// ClearLayerGroupByIDContext is like ClearLayerGroupByID but with a context
+func (c *Client) ClearLayerGroupByIDContext(ctx context.Context, layerGroupID int64, clear *bool) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/by-id/%d/clear", layerGroupID)
+	return c.post(ctx, endpoint, clear, nil)
+}
+
+// DuplicateLayerGroupByID duplicates the given layer group
+//
+// Take the given layer group and make a copy of it. All properties will be the
+// same, except for the unique identifiers. The new layer group is inserted into
+// the composition below the existing one.
+func (c *Client) DuplicateLayerGroupByID(layerGroupID int64) error {
+	return c.DuplicateLayerGroupByIDContext(context.Background(), layerGroupID)
+}
+
+// DuplicateLayerGroupByIDContext is like DuplicateLayerGroupByID but with a context
+func (c *Client) DuplicateLayerGroupByIDContext(ctx context.Context, layerGroupID int64) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/by-id/%d/duplicate", layerGroupID)
+	return c.post(ctx, endpoint, nil, nil)
+}
+
+// MoveLayerToGroupByID adds an existing layer to an existing layer group
+//
+// Given an existing layer, identified by the path in the body, move it into the
+// layer group at the given position in the composition.
+func (c *Client) MoveLayerToGroupByID(layerGroupID int64, layerURI string) error {
+	return c.MoveLayerToGroupByIDContext(context.Background(), layerGroupID, layerURI)
+}
+
+// MoveLayerToGroupByIDContext is like MoveLayerToGroupByID but with a context
+func (c *Client) MoveLayerToGroupByIDContext(ctx context.Context, layerGroupID int64, layerURI string) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/by-id/%d/move-layer", layerGroupID)
+	return c.post(ctx, endpoint, uriText(layerURI), nil)
+}
+
+// AddLayerToGroupByID adds new layer to an existing layer group
+//
+// Create a new layer inside a layer group. If the path to an existing layer is
+// given, the new layer is inserted before.
+func (c *Client) AddLayerToGroupByID(layerGroupID int64, beforeLayerURI string) error {
+	return c.AddLayerToGroupByIDContext(context.Background(), layerGroupID, beforeLayerURI)
+}
+
+// AddLayerToGroupByIDContext is like AddLayerToGroupByID but with a context
+func (c *Client) AddLayerToGroupByIDContext(ctx context.Context, layerGroupID int64, beforeLayerURI string) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/by-id/%d/add-layer", layerGroupID)
+	return c.post(ctx, endpoint, uriText(beforeLayerURI), nil)
+}
+
+// MoveEffectToLayerGroupByID moves an effect to the end of the layer group
+//
+// Take an existing effect instance, and move it to the end of the layer group.
+func (c *Client) MoveEffectToLayerGroupByID(layerGroupID int64, effectURI string) error {
+	return c.MoveEffectToLayerGroupByIDContext(context.Background(), layerGroupID, effectURI)
+}
+
+// MoveEffectToLayerGroupByIDContext is like MoveEffectToLayerGroupByID but with a context
+func (c *Client) MoveEffectToLayerGroupByIDContext(ctx context.Context, layerGroupID int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/by-id/%d/effects/video/move", layerGroupID)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
+}
+
+// MoveEffectToLayerGroupByIDOffset moves an effect to the given offset in the layer group
+//
+// Take an existing effect instance, and move it to the given offset.
+func (c *Client) MoveEffectToLayerGroupByIDOffset(layerGroupID, offset int64, effectURI string) error {
+	return c.MoveEffectToLayerGroupByIDOffsetContext(context.Background(), layerGroupID, offset, effectURI)
+}
+
+// MoveEffectToLayerGroupByIDOffsetContext is like MoveEffectToLayerGroupByIDOffset but with a context
+func (c *Client) MoveEffectToLayerGroupByIDOffsetContext(ctx context.Context, layerGroupID, offset int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/by-id/%d/effects/video/move/%d", layerGroupID, offset)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
+}
+
+// AddEffectToLayerGroupByID adds an effect to a layer group by unique id
+//
+// Add an effect to the layer group with the given unique id.
+func (c *Client) AddEffectToLayerGroupByID(layerGroupID int64, effectURI string) error {
+	return c.AddEffectToLayerGroupByIDContext(context.Background(), layerGroupID, effectURI)
+}
+
+// AddEffectToLayerGroupByIDContext is like AddEffectToLayerGroupByID but with a context
+func (c *Client) AddEffectToLayerGroupByIDContext(ctx context.Context, layerGroupID int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/by-id/%d/effects/video/add", layerGroupID)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
+}
+
+// AddEffectToLayerGroupByIDAtOffset adds an effect to a layer group by unique id, at the given offset
+//
+// Add an effect to the layer group with the given unique id.
+func (c *Client) AddEffectToLayerGroupByIDAtOffset(layerGroupID, offset int64, effectURI string) error {
+	return c.AddEffectToLayerGroupByIDAtOffsetContext(context.Background(), layerGroupID, offset, effectURI)
+}
+
+// AddEffectToLayerGroupByIDAtOffsetContext is like AddEffectToLayerGroupByIDAtOffset but with a context
+func (c *Client) AddEffectToLayerGroupByIDAtOffsetContext(ctx context.Context, layerGroupID, offset int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/by-id/%d/effects/video/add/%d", layerGroupID, offset)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
+}
+
+// DeleteLayerGroupEffectByID removes an effect from a layer group
+func (c *Client) DeleteLayerGroupEffectByID(layerGroupID, offset int64) error {
+	return c.DeleteLayerGroupEffectByIDContext(context.Background(), layerGroupID, offset)
+}
+
+// DeleteLayerGroupEffectByIDContext is like DeleteLayerGroupEffectByID but with a context
+func (c *Client) DeleteLayerGroupEffectByIDContext(ctx context.Context, layerGroupID, offset int64) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/by-id/%d/effects/video/%d", layerGroupID, offset)
+	return c.delete(ctx, endpoint)
+}
+
+// ResetLayerGroupParameterByID resets a parameter in a layer group to its default value
+//
+// This resets a parameter on the indexed layer group to default. If a JSON body
+// is given and 'resetanimation' is set to true, animations are also reset.
+func (c *Client) ResetLayerGroupParameterByID(layerGroupID int64, parameter string, resetAnimation bool) error {
+	return c.ResetLayerGroupParameterByIDContext(context.Background(), layerGroupID, parameter, resetAnimation)
+}
+
+// ResetLayerGroupParameterByIDContext is like ResetLayerGroupParameterByID but with a context
+func (c *Client) ResetLayerGroupParameterByIDContext(ctx context.Context, layerGroupID int64, parameter string, resetAnimation bool) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/by-id/%d/%s/reset", layerGroupID, parameter)
+	body := ResetParameter{
+		ResetAnimation: resetAnimation,
+	}
+	return c.post(ctx, endpoint, body, nil)
+}
+
+// SelectLayerGroupByID selects the layer group by id
+//
+// Select the layer group with the given id.
+func (c *Client) SelectLayerGroupByID(layerGroupID int64) error {
+	return c.SelectLayerGroupByIDContext(context.Background(), layerGroupID)
+}
+
+// SelectLayerGroupByIDContext is like SelectLayerGroupByID but with a context
+func (c *Client) SelectLayerGroupByIDContext(ctx context.Context, layerGroupID int64) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/by-id/%d/select", layerGroupID)
+	return c.post(ctx, endpoint, nil, nil)
+}
+
+// GetDeck retrieves deck properties by index
+//
+// Returns a single deck.
+func (c *Client) GetDeck(deckIndex int64) (*Deck, error) {
+	return c.GetDeckContext(context.Background(), deckIndex)
+}
+
+// GetDeckContext is like GetDeck but with a context
+func (c *Client) GetDeckContext(ctx context.Context, deckIndex int64) (*Deck, error) {
+	endpoint := fmt.Sprintf("/composition/decks/%d", deckIndex)
+	var deck Deck
+	if err := c.get(ctx, endpoint, &deck); err != nil {
+		return nil, err
+	}
+	return &deck, nil
+}
+
+// ReplaceDeck updates specific deck by index
+//
+// Update a single deck.
+func (c *Client) ReplaceDeck(deckIndex int64, deck *Deck) error {
+	return c.ReplaceDeckContext(context.Background(), deckIndex, deck)
+}
+
+// ReplaceDeckContext is like ReplaceDeck but with a context
+func (c *Client) ReplaceDeckContext(ctx context.Context, deckIndex int64, deck *Deck) error {
+	endpoint := fmt.Sprintf("/composition/decks/%d", deckIndex)
+	return c.put(ctx, endpoint, deck, nil)
+}
+
+// DeleteDeck removes a deck by index
+//
+// Remove the deck with the given index.
+func (c *Client) DeleteDeck(deckIndex int64) error {
+	return c.DeleteDeckContext(context.Background(), deckIndex)
+}
+
+// DeleteDeckContext is like DeleteDeck but with a context
+func (c *Client) DeleteDeckContext(ctx context.Context, deckIndex int64) error {
+	endpoint := fmt.Sprintf("/composition/decks/%d", deckIndex)
+	return c.delete(ctx, endpoint)
+}
+
+// DuplicateDeck duplicates the given deck
+//
+// Take the given deck and make a copy of it. All properties will be the same,
+// except for the unique identifiers. The new deck is inserted into the
+// composition next to the existing one.
+func (c *Client) DuplicateDeck(deckIndex int64) error {
+	return c.DuplicateDeckContext(context.Background(), deckIndex)
+}
+
+// DuplicateDeckContext is like DuplicateDeck but with a context
+func (c *Client) DuplicateDeckContext(ctx context.Context, deckIndex int64) error {
 	endpoint := fmt.Sprintf("/composition/decks/%d/duplicate", deckIndex)
-	return c.post(endpoint, nil, nil)
+	return c.post(ctx, endpoint, nil, nil)
 }
 
 // AddDeck adds a new deck to the composition
+//
+// This adds a new deck either at the end, or somewhere else in the composition.
 func (c *Client) AddDeck(beforeDeckURI string) error {
+	return c.AddDeckContext(context.Background(), beforeDeckURI)
+}
+
+// AddDeckContext is like AddDeck but with a context
+func (c *Client) AddDeckContext(ctx context.Context, beforeDeckURI string) error {
 	endpoint := "/composition/decks/add"
-	return c.post(endpoint, beforeDeckURI, nil)
+	return c.post(ctx, endpoint, uriText(beforeDeckURI), nil)
 }
 
 // ResetDeckParameter resets a parameter in a deck to its default value
+//
+// This resets a parameter on the indexed deck to default. If a JSON body is
+// given and 'resetanimation' is set to true, animations are also reset.
 func (c *Client) ResetDeckParameter(deckIndex int64, parameter string, resetAnimation bool) error {
+	return c.ResetDeckParameterContext(context.Background(), deckIndex, parameter, resetAnimation)
+}
+
+// ResetDeckParameterContext is like ResetDeckParameter but with a context
+func (c *Client) ResetDeckParameterContext(ctx context.Context, deckIndex int64, parameter string, resetAnimation bool) error {
 	endpoint := fmt.Sprintf("/composition/decks/%d/%s/reset", deckIndex, parameter)
 	body := ResetParameter{
 		ResetAnimation: resetAnimation,
 	}
-	return c.post(endpoint, body, nil)
+	return c.post(ctx, endpoint, body, nil)
 }
 
 // SelectDeck selects the deck by index
+//
+// Select the deck at the given index.
 func (c *Client) SelectDeck(deckIndex int64) error {
+	return c.SelectDeckContext(context.Background(), deckIndex)
+}
+
+// SelectDeckContext is like SelectDeck but with a context
+func (c *Client) SelectDeckContext(ctx context.Context, deckIndex int64) error {
 	endpoint := fmt.Sprintf("/composition/decks/%d/select", deckIndex)
-	return c.post(endpoint, nil, nil)
+	return c.post(ctx, endpoint, nil, nil)
 }
 
 // GetDeckByID retrieves deck properties by id
+//
+// Returns a single deck.
 func (c *Client) GetDeckByID(deckID int64) (*Deck, error) {
+	return c.GetDeckByIDContext(context.Background(), deckID)
+}
+
+// GetDeckByIDContext is like GetDeckByID but with a context
+func (c *Client) GetDeckByIDContext(ctx context.Context, deckID int64) (*Deck, error) {
 	endpoint := fmt.Sprintf("/composition/decks/by-id/%d", deckID)
 	var deck Deck
-	if err := c.get(endpoint, &deck); err != nil {
+	if err := c.get(ctx, endpoint, &deck); err != nil {
 		return nil, err
 	}
 	return &deck, nil
 }
 
 // ReplaceDeckByID updates specific deck by id
+//
+// Update a single deck.
 func (c *Client) ReplaceDeckByID(deckID int64, deck *Deck) error {
+	return c.ReplaceDeckByIDContext(context.Background(), deckID, deck)
+}
+
+// ReplaceDeckByIDContext is like ReplaceDeckByID but with a context
+func (c *Client) ReplaceDeckByIDContext(ctx context.Context, deckID int64, deck *Deck) error {
 	endpoint := fmt.Sprintf("/composition/decks/by-id/%d", deckID)
-	return c.put(endpoint, deck, nil)
+	return c.put(ctx, endpoint, deck, nil)
 }
 
 // DeleteDeckByID removes specified deck by id
+//
+// Remove the deck with the given id.
 func (c *Client) DeleteDeckByID(deckID int64) error {
+	return c.DeleteDeckByIDContext(context.Background(), deckID)
+}
+
+// DeleteDeckByIDContext is like DeleteDeckByID but with a context
+func (c *Client) DeleteDeckByIDContext(ctx context.Context, deckID int64) error {
 	endpoint := fmt.Sprintf("/composition/decks/by-id/%d", deckID)
-	return c.delete(endpoint)
+	return c.delete(ctx, endpoint)
 }
 
 // DuplicateDeckByID duplicates the given deck
+//
+// Take the given deck and make a copy of it. All properties will be the same,
+// except for the unique identifiers. The new deck is inserted into the
+// composition next to the existing one.
 func (c *Client) DuplicateDeckByID(deckID int64) error {
+	return c.DuplicateDeckByIDContext(context.Background(), deckID)
+}
+
+// DuplicateDeckByIDContext is like DuplicateDeckByID but with a context
+func (c *Client) DuplicateDeckByIDContext(ctx context.Context, deckID int64) error {
 	endpoint := fmt.Sprintf("/composition/decks/by-id/%d/duplicate", deckID)
-	return c.post(endpoint, nil, nil)
+	return c.post(ctx, endpoint, nil, nil)
 }
 
 // CloseDeckByID closes the given deck
+//
+// Take the given deck and close it.
 func (c *Client) CloseDeckByID(deckID int64) error {
+	return c.CloseDeckByIDContext(context.Background(), deckID)
+}
+
+// CloseDeckByIDContext is like CloseDeckByID but with a context
+func (c *Client) CloseDeckByIDContext(ctx context.Context, deckID int64) error {
 	endpoint := fmt.Sprintf("/composition/decks/by-id/%d/close", deckID)
-	return c.post(endpoint, nil, nil)
+	return c.post(ctx, endpoint, nil, nil)
 }
 
 // OpenDeckByID re-opens the given deck
+//
+// Take the given deck and open it again.
 func (c *Client) OpenDeckByID(deckID int64) error {
+	return c.OpenDeckByIDContext(context.Background(), deckID)
+}
+
+// OpenDeckByIDContext is like OpenDeckByID but with a context
+func (c *Client) OpenDeckByIDContext(ctx context.Context, deckID int64) error {
 	endpoint := fmt.Sprintf("/composition/decks/by-id/%d/open", deckID)
-	return c.post(endpoint, nil, nil)
+	return c.post(ctx, endpoint, nil, nil)
 }
 
 // ResetDeckParameterByID resets a parameter in a deck to its default value
+//
+// This resets a parameter on the indexed deck to default. If a JSON body is
+// given and 'resetanimation' is set to true, animations are also reset.
 func (c *Client) ResetDeckParameterByID(deckID int64, parameter string, resetAnimation bool) error {
+	return c.ResetDeckParameterByIDContext(context.Background(), deckID, parameter, resetAnimation)
+}
+
+// ResetDeckParameterByIDContext is like ResetDeckParameterByID but with a context
+func (c *Client) ResetDeckParameterByIDContext(ctx context.Context, deckID int64, parameter string, resetAnimation bool) error {
 	endpoint := fmt.Sprintf("/composition/decks/by-id/%d/%s/reset", deckID, parameter)
 	body := ResetParameter{
 		ResetAnimation: resetAnimation,
 	}
-	return c.post(endpoint, body, nil)
+	return c.post(ctx, endpoint, body, nil)
 }
 
 // SelectDeckByID selects the deck by id
+//
+// Select the deck with the given id.
 func (c *Client) SelectDeckByID(deckID int64) error {
+	return c.SelectDeckByIDContext(context.Background(), deckID)
+}
+
+// SelectDeckByIDContext is like SelectDeckByID but with a context
+func (c *Client) SelectDeckByIDContext(ctx context.Context, deckID int64) error {
 	endpoint := fmt.Sprintf("/composition/decks/by-id/%d/select", deckID)
-	return c.post(endpoint, nil, nil)
+	return c.post(ctx, endpoint, nil, nil)
 }
 
 // GetClipByPosition retrieves a clip by its position in the clip grid
+//
+// Retrieve all clip information and associated effects.
 func (c *Client) GetClipByPosition(layerIndex, clipIndex int64) (*Clip, error) {
+	return c.GetClipByPositionContext(context.Background(), layerIndex, clipIndex)
+}
+
+// GetClipByPositionContext is like GetClipByPosition but with a context
+func (c *Client) GetClipByPositionContext(ctx context.Context, layerIndex, clipIndex int64) (*Clip, error) {
 	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d", layerIndex, clipIndex)
 	var clip Clip
-	if err := c.get(endpoint, &clip); err != nil {
+	if err := c.get(ctx, endpoint, &clip); err != nil {
 		return nil, err
 	}
 	return &clip, nil
 }
 
 // ReplaceClipByPosition updates clip and/or its effects by position in the clip grid
+//
+// Update a single clip and its effects.
 func (c *Client) ReplaceClipByPosition(layerIndex, clipIndex int64, clip *Clip) error {
+	return c.ReplaceClipByPositionContext(context.Background(), layerIndex, clipIndex, clip)
+}
+
+// ReplaceClipByPositionContext is like ReplaceClipByPosition but with a context
+func (c *Client) ReplaceClipByPositionContext(ctx context.Context, layerIndex, clipIndex int64, clip *Clip) error {
 	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d", layerIndex, clipIndex)
-	return c.put(endpoint, clip, nil)
+	return c.put(ctx, endpoint, clip, nil)
+}
+
+// SetClipEffectDisplayName changes the display name of an effect
+//
+// Change the display name of the effect with the given offset in the clip.
+func (c *Client) SetClipEffectDisplayName(layerIndex, clipIndex, effectIndex int64, displayName string) error {
+	return c.SetClipEffectDisplayNameContext(context.Background(), layerIndex, clipIndex, effectIndex, displayName)
+}
+
+// SetClipEffectDisplayNameContext is like SetClipEffectDisplayName but with a context
+func (c *Client) SetClipEffectDisplayNameContext(ctx context.Context, layerIndex, clipIndex, effectIndex int64, displayName string) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d/effects/video/%d/set-display-name", layerIndex, clipIndex, effectIndex)
+	return c.post(ctx, endpoint, plainText(displayName), nil)
+}
+
+// MoveEffectToClip moves an effect to the end of the clip
+//
+// Take an existing effect instance, and move it to the end of the clip.
+func (c *Client) MoveEffectToClip(layerIndex, clipIndex int64, effectURI string) error {
+	return c.MoveEffectToClipContext(context.Background(), layerIndex, clipIndex, effectURI)
+}
+
+// MoveEffectToClipContext is like MoveEffectToClip but with a context
+func (c *Client) MoveEffectToClipContext(ctx context.Context, layerIndex, clipIndex int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d/effects/video/move", layerIndex, clipIndex)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
+}
+
+// MoveEffectToClipOffset moves an effect to the given index in the clip
+//
+// Take an existing effect instance, and move it to the given offset.
+func (c *Client) MoveEffectToClipOffset(layerIndex, clipIndex, offset int64, effectURI string) error {
+	return c.MoveEffectToClipOffsetContext(context.Background(), layerIndex, clipIndex, offset, effectURI)
+}
+
+// MoveEffectToClipOffsetContext is like MoveEffectToClipOffset but with a context
+func (c *Client) MoveEffectToClipOffsetContext(ctx context.Context, layerIndex, clipIndex, offset int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d/effects/video/move/%d", layerIndex, clipIndex, offset)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
+}
+
+// AddEffectToClip adds an effect to a clip by its position in the clip grid
+//
+// Add an effect to a clip at the specified position.
+func (c *Client) AddEffectToClip(layerIndex, clipIndex int64, effectURI string) error {
+	return c.AddEffectToClipContext(context.Background(), layerIndex, clipIndex, effectURI)
+}
+
+// AddEffectToClipContext is like AddEffectToClip but with a context
+func (c *Client) AddEffectToClipContext(ctx context.Context, layerIndex, clipIndex int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d/effects/video/add", layerIndex, clipIndex)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
+}
+
+// AddEffectToClipAtOffset adds an effect to a clip by its position in the clip grid, at the given offset
+//
+// Add an effect to the clip at the specified position.
+func (c *Client) AddEffectToClipAtOffset(layerIndex, clipIndex, offset int64, effectURI string) error {
+	return c.AddEffectToClipAtOffsetContext(context.Background(), layerIndex, clipIndex, offset, effectURI)
+}
+
+// AddEffectToClipAtOffsetContext is like AddEffectToClipAtOffset but with a context
+func (c *Client) AddEffectToClipAtOffsetContext(ctx context.Context, layerIndex, clipIndex, offset int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d/effects/video/add/%d", layerIndex, clipIndex, offset)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
+}
+
+// DeleteClipEffect removes an effect from a clip
+func (c *Client) DeleteClipEffect(layerIndex, clipIndex, offset int64) error {
+	return c.DeleteClipEffectContext(context.Background(), layerIndex, clipIndex, offset)
+}
+
+// DeleteClipEffectContext is like DeleteClipEffect but with a context
+func (c *Client) DeleteClipEffectContext(ctx context.Context, layerIndex, clipIndex, offset int64) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d/effects/video/%d", layerIndex, clipIndex, offset)
+	return c.delete(ctx, endpoint)
 }
 
 // GetSelectedClip retrieves the selected clip
+//
+// Retrieve all clip information and associated effects.
 func (c *Client) GetSelectedClip() (*Clip, error) {
+	return c.GetSelectedClipContext(context.Background())
+}
+
+// GetSelectedClipContext is like GetSelectedClip but with a context
+func (c *Client) GetSelectedClipContext(ctx context.Context) (*Clip, error) {
 	endpoint := "/composition/clips/selected"
 	var clip Clip
-	if err := c.get(endpoint, &clip); err != nil {
+	if err := c.get(ctx, endpoint, &clip); err != nil {
 		return nil, err
 	}
 	return &clip, nil
 }
 
 // ReplaceSelectedClip updates selected clip and/or its effects
+//
+// Update a single clip and its effects.
 func (c *Client) ReplaceSelectedClip(clip *Clip) error {
+	return c.ReplaceSelectedClipContext(context.Background(), clip)
+}
+
+// ReplaceSelectedClipContext is like ReplaceSelectedClip but with a context
+func (c *Client) ReplaceSelectedClipContext(ctx context.Context, clip *Clip) error {
 	endpoint := "/composition/clips/selected"
-	return c.put(endpoint, clip, nil)
+	return c.put(ctx, endpoint, clip, nil)
 }
 
 // AddEffectToSelectedClip adds an effect to the selected clip
 func (c *Client) AddEffectToSelectedClip(effectURI string) error {
+	return c.AddEffectToSelectedClipContext(context.Background(), effectURI)
+}
+
+// AddEffectToSelectedClipContext is like AddEffectToSelectedClip but with a context
+func (c *Client) AddEffectToSelectedClipContext(ctx context.Context, effectURI string) error {
 	endpoint := "/composition/clips/selected/effects/video/add"
-	return c.post(endpoint, effectURI, nil)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
 }
 
 // AddEffectToSelectedClipAtOffset adds an effect at the given offset to the selected clip
+//
+// Add an effect to the selected clip.
 func (c *Client) AddEffectToSelectedClipAtOffset(offset int64, effectURI string) error {
+	return c.AddEffectToSelectedClipAtOffsetContext(context.Background(), offset, effectURI)
+}
+
+// AddEffectToSelectedClipAtOffsetContext is like AddEffectToSelectedClipAtOffset but with a context
+func (c *Client) AddEffectToSelectedClipAtOffsetContext(ctx context.Context, offset int64, effectURI string) error {
 	endpoint := fmt.Sprintf("/composition/clips/selected/effects/video/add/%d", offset)
-	return c.post(endpoint, effectURI, nil)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
 }
 
-// DeleteSelectedClipEffect removes an effect from the selected clip
+// DeleteSelectedClipEffect removes an effect from a clip
 func (c *Client) DeleteSelectedClipEffect(offset int64) error {
+	return c.DeleteSelectedClipEffectContext(context.Background(), offset)
+}
+
+// DeleteSelectedClipEffectContext is like DeleteSelectedClipEffect but with a context
+func (c *Client) DeleteSelectedClipEffectContext(ctx context.Context, offset int64) error {
 	endpoint := fmt.Sprintf("/composition/clips/selected/effects/video/%d", offset)
-	return c.delete(endpoint)
+	return c.delete(ctx, endpoint)
+}
+
+// ResetClipParameter resets a parameter in a clip to its default value
+//
+// This resets a parameter on the indexed clip to default. If a JSON body is
+// given and 'resetanimation' is set to true, animations are also reset.
+func (c *Client) ResetClipParameter(layerIndex, clipIndex int64, parameter string, resetAnimation bool) error {
+	return c.ResetClipParameterContext(context.Background(), layerIndex, clipIndex, parameter, resetAnimation)
+}
+
+// ResetClipParameterContext is like ResetClipParameter but with a context
+func (c *Client) ResetClipParameterContext(ctx context.Context, layerIndex, clipIndex int64, parameter string, resetAnimation bool) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d/%s/reset", layerIndex, clipIndex, parameter)
+	body := ResetParameter{
+		ResetAnimation: resetAnimation,
+	}
+	return c.post(ctx, endpoint, body, nil)
 }
 
 // ResetSelectedClipParameter resets a parameter in the selected clip to its default value
+//
+// This resets a parameter on the selected clip to default. If a JSON body is
+// given and 'resetanimation' is set to true, animations are also reset.
 func (c *Client) ResetSelectedClipParameter(parameter string, resetAnimation bool) error {
+	return c.ResetSelectedClipParameterContext(context.Background(), parameter, resetAnimation)
+}
+
+// ResetSelectedClipParameterContext is like ResetSelectedClipParameter but with a context
+func (c *Client) ResetSelectedClipParameterContext(ctx context.Context, parameter string, resetAnimation bool) error {
 	endpoint := fmt.Sprintf("/composition/clips/selected/%s/reset", parameter)
 	body := ResetParameter{
 		ResetAnimation: resetAnimation,
 	}
-	return c.post(endpoint, body, nil)
+	return c.post(ctx, endpoint, body, nil)
+}
+
+// SelectClip selects the clip by its position in the clip grid
+//
+// Select the clip.
+func (c *Client) SelectClip(layerIndex, clipIndex int64) error {
+	return c.SelectClipContext(context.Background(), layerIndex, clipIndex)
+}
+
+// SelectClipContext is like SelectClip but with a context
+func (c *Client) SelectClipContext(ctx context.Context, layerIndex, clipIndex int64) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d/select", layerIndex, clipIndex)
+	return c.post(ctx, endpoint, nil, nil)
+}
+
+// ConnectClip connects the clip by its position in the clip grid
+//
+// Connect the clip, possibly activating it.
+func (c *Client) ConnectClip(layerIndex, clipIndex int64, connect *bool) error {
+	return c.ConnectClipContext(context.Background(), layerIndex, clipIndex, connect)
+}
+
+// ConnectClipContext is like ConnectClip but with a context
+func (c *Client) ConnectClipContext(ctx context.Context, layerIndex, clipIndex int64, connect *bool) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d/connect", layerIndex, clipIndex)
+	return c.post(ctx, endpoint, connect, nil)
 }
 
 // ConnectSelectedClip connects the selected clip
+//
+// Connect the clip, possibly activating it.
 func (c *Client) ConnectSelectedClip(connect *bool) error {
+	return c.ConnectSelectedClipContext(context.Background(), connect)
+}
+
+// ConnectSelectedClipContext is like ConnectSelectedClip but with a context
+func (c *Client) ConnectSelectedClipContext(ctx context.Context, connect *bool) error {
 	endpoint := "/composition/clips/selected/connect"
-	return c.post(endpoint, connect, nil)
+	return c.post(ctx, endpoint, connect, nil)
+}
+
+// OpenClip loads a file or opens a source into a clip by its position in the clip grid
+//
+// Loads a file or opens a source into a clip, will retain settings as much as
+// possible.
+func (c *Client) OpenClip(layerIndex, clipIndex int64, uri string) error {
+	return c.OpenClipContext(context.Background(), layerIndex, clipIndex, uri)
+}
+
+// OpenClipContext is like OpenClip but with a context
+func (c *Client) OpenClipContext(ctx context.Context, layerIndex, clipIndex int64, uri string) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d/open", layerIndex, clipIndex)
+	return c.post(ctx, endpoint, uriText(uri), nil)
+}
+
+// OpenFileClip loads file into clip by its position in the clip grid
+//
+// Loads file into clip, will retain settings as much as possible.
+//
+// Deprecated: the endpoint is deprecated in the Resolume API.
+func (c *Client) OpenFileClip(layerIndex, clipIndex int64, fileURI string) error {
+	return c.OpenFileClipContext(context.Background(), layerIndex, clipIndex, fileURI)
+}
+
+// OpenFileClipContext is like OpenFileClip but with a context
+func (c *Client) OpenFileClipContext(ctx context.Context, layerIndex, clipIndex int64, fileURI string) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d/openfile", layerIndex, clipIndex)
+	return c.post(ctx, endpoint, uriText(fileURI), nil)
 }
 
 // OpenSelectedClip loads a file or opens a source into the selected clip
+//
+// Loads a file or opens a source into a clip, will retain settings as much as
+// possible.
 func (c *Client) OpenSelectedClip(uri string) error {
+	return c.OpenSelectedClipContext(context.Background(), uri)
+}
+
+// OpenSelectedClipContext is like OpenSelectedClip but with a context
+func (c *Client) OpenSelectedClipContext(ctx context.Context, uri string) error {
 	endpoint := "/composition/clips/selected/open"
-	return c.post(endpoint, uri, nil)
+	return c.post(ctx, endpoint, uriText(uri), nil)
+}
+
+// OpenFileSelectedClip loads file into the selected clip
+//
+// Loads file into clip, will retain settings as much as possible.
+//
+// Deprecated: the endpoint is deprecated in the Resolume API.
+func (c *Client) OpenFileSelectedClip(fileURI string) error {
+	return c.OpenFileSelectedClipContext(context.Background(), fileURI)
+}
+
+// OpenFileSelectedClipContext is like OpenFileSelectedClip but with a context
+func (c *Client) OpenFileSelectedClipContext(ctx context.Context, fileURI string) error {
+	endpoint := "/composition/clips/selected/openfile"
+	return c.post(ctx, endpoint, uriText(fileURI), nil)
+}
+
+// ClearClip clears the clip by its position in the clip grid
+//
+// Clears the clip, removing all tracks and settings.
+func (c *Client) ClearClip(layerIndex, clipIndex int64) error {
+	return c.ClearClipContext(context.Background(), layerIndex, clipIndex)
+}
+
+// ClearClipContext is like ClearClip but with a context
+func (c *Client) ClearClipContext(ctx context.Context, layerIndex, clipIndex int64) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d/clear", layerIndex, clipIndex)
+	return c.post(ctx, endpoint, nil, nil)
 }
 
 // ClearSelectedClip clears the selected clip
+//
+// Clears the clip, removing all tracks and settings.
 func (c *Client) ClearSelectedClip() error {
+	return c.ClearSelectedClipContext(context.Background())
+}
+
+// ClearSelectedClipContext is like ClearSelectedClip but with a context
+func (c *Client) ClearSelectedClipContext(ctx context.Context) error {
 	endpoint := "/composition/clips/selected/clear"
-	return c.post(endpoint, nil, nil)
+	return c.post(ctx, endpoint, nil, nil)
 }
 
 // GetClipByID retrieves a clip by id
+//
+// Retrieve all clip information and associated effects.
 func (c *Client) GetClipByID(clipID int64) (*Clip, error) {
+	return c.GetClipByIDContext(context.Background(), clipID)
+}
+
+// GetClipByIDContext is like GetClipByID but with a context
+func (c *Client) GetClipByIDContext(ctx context.Context, clipID int64) (*Clip, error) {
 	endpoint := fmt.Sprintf("/composition/clips/by-id/%d", clipID)
 	var clip Clip
-	if err := c.get(endpoint, &clip); err != nil {
+	if err := c.get(ctx, endpoint, &clip); err != nil {
 		return nil, err
 	}
 	return &clip, nil
 }
 
 // ReplaceClipByID updates clip and/or its effects by id
+//
+// Update a single clip and its effects.
 func (c *Client) ReplaceClipByID(clipID int64, clip *Clip) error {
+	return c.ReplaceClipByIDContext(context.Background(), clipID, clip)
+}
+
+// ReplaceClipByIDContext is like ReplaceClipByID but with a context
+func (c *Client) ReplaceClipByIDContext(ctx context.Context, clipID int64, clip *Clip) error {
 	endpoint := fmt.Sprintf("/composition/clips/by-id/%d", clipID)
-	return c.put(endpoint, clip, nil)
+	return c.put(ctx, endpoint, clip, nil)
+}
+
+// MoveEffectToClipByID moves an effect to the end of the clip
+//
+// Take an existing effect instance, and move it to the end of the clip.
+func (c *Client) MoveEffectToClipByID(clipID int64, effectURI string) error {
+	return c.MoveEffectToClipByIDContext(context.Background(), clipID, effectURI)
+}
+
+// MoveEffectToClipByIDContext is like MoveEffectToClipByID but with a context
+func (c *Client) MoveEffectToClipByIDContext(ctx context.Context, clipID int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/clips/by-id/%d/effects/video/move", clipID)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
+}
+
+// MoveEffectToClipByIDOffset moves an effect to the given offset in the clip
+//
+// Take an existing effect instance, and move it to the given offset.
+func (c *Client) MoveEffectToClipByIDOffset(clipID, offset int64, effectURI string) error {
+	return c.MoveEffectToClipByIDOffsetContext(context.Background(), clipID, offset, effectURI)
+}
+
+// MoveEffectToClipByIDOffsetContext is like MoveEffectToClipByIDOffset but with a context
+func (c *Client) MoveEffectToClipByIDOffsetContext(ctx context.Context, clipID, offset int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/clips/by-id/%d/effects/video/move/%d", clipID, offset)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
+}
+
+// AddEffectToClipByID adds an effect to a clip by its unique identifier
+//
+// Add an effect to a clip by its unique id.
+func (c *Client) AddEffectToClipByID(clipID int64, effectURI string) error {
+	return c.AddEffectToClipByIDContext(context.Background(), clipID, effectURI)
+}
+
+// AddEffectToClipByIDContext is like AddEffectToClipByID but with a context
+func (c *Client) AddEffectToClipByIDContext(ctx context.Context, clipID int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/clips/%d/effects/video/add", clipID)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
+}
+
+// AddEffectToClipByIDAtOffset adds an effect to a clip by its unique identifier, at the given offset
+//
+// Add an effect to the clip with the unique identifier.
+func (c *Client) AddEffectToClipByIDAtOffset(clipID, offset int64, effectURI string) error {
+	return c.AddEffectToClipByIDAtOffsetContext(context.Background(), clipID, offset, effectURI)
+}
+
+// AddEffectToClipByIDAtOffsetContext is like AddEffectToClipByIDAtOffset but with a context
+func (c *Client) AddEffectToClipByIDAtOffsetContext(ctx context.Context, clipID, offset int64, effectURI string) error {
+	endpoint := fmt.Sprintf("/composition/clips/%d/effects/video/add/%d", clipID, offset)
+	return c.post(ctx, endpoint, uriText(effectURI), nil)
+}
+
+// DeleteClipEffectByID removes an effect from a clip
+func (c *Client) DeleteClipEffectByID(clipID, offset int64) error {
+	return c.DeleteClipEffectByIDContext(context.Background(), clipID, offset)
+}
+
+// DeleteClipEffectByIDContext is like DeleteClipEffectByID but with a context
+func (c *Client) DeleteClipEffectByIDContext(ctx context.Context, clipID, offset int64) error {
+	endpoint := fmt.Sprintf("/composition/clips/%d/effects/video/%d", clipID, offset)
+	return c.delete(ctx, endpoint)
+}
+
+// ResetClipParameterByID resets a parameter in a clip to its default value
+//
+// This resets a parameter on the indexed clip to default. If a JSON body is
+// given and 'resetanimation' is set to true, animations are also reset.
+func (c *Client) ResetClipParameterByID(clipID int64, parameter string, resetAnimation bool) error {
+	return c.ResetClipParameterByIDContext(context.Background(), clipID, parameter, resetAnimation)
+}
+
+// ResetClipParameterByIDContext is like ResetClipParameterByID but with a context
+func (c *Client) ResetClipParameterByIDContext(ctx context.Context, clipID int64, parameter string, resetAnimation bool) error {
+	endpoint := fmt.Sprintf("/composition/clips/%d/%s/reset", clipID, parameter)
+	body := ResetParameter{
+		ResetAnimation: resetAnimation,
+	}
+	return c.post(ctx, endpoint, body, nil)
 }
 
 // SelectClipByID selects the clip by id
+//
+// Select the clip.
 func (c *Client) SelectClipByID(clipID int64) error {
+	return c.SelectClipByIDContext(context.Background(), clipID)
+}
+
+// SelectClipByIDContext is like SelectClipByID but with a context
+func (c *Client) SelectClipByIDContext(ctx context.Context, clipID int64) error {
 	endpoint := fmt.Sprintf("/composition/clips/by-id/%d/select", clipID)
-	return c.post(endpoint, nil, nil)
+	return c.post(ctx, endpoint, nil, nil)
 }
 
 // ConnectClipByID connects the clip by id
+//
+// Connect the clip, possibly activating it.
 func (c *Client) ConnectClipByID(clipID int64, connect *bool) error {
+	return c.ConnectClipByIDContext(context.Background(), clipID, connect)
+}
+
+// ConnectClipByIDContext is like ConnectClipByID but with a context
+func (c *Client) ConnectClipByIDContext(ctx context.Context, clipID int64, connect *bool) error {
 	endpoint := fmt.Sprintf("/composition/clips/by-id/%d/connect", clipID)
-	return c.post(endpoint, connect, nil)
+	return c.post(ctx, endpoint, connect, nil)
 }
 
-// OpenClipByID loads a file or opens a source into the clip with the given id
+// OpenClipByID loads a file or opens a source into the clip with the given unique id
+//
+// Loads a file or opens a source into a clip, will retain settings as much as
+// possible.
 func (c *Client) OpenClipByID(clipID int64, uri string) error {
+	return c.OpenClipByIDContext(context.Background(), clipID, uri)
+}
+
+// OpenClipByIDContext is like OpenClipByID but with a context
+func (c *Client) OpenClipByIDContext(ctx context.Context, clipID int64, uri string) error {
 	endpoint := fmt.Sprintf("/composition/clips/by-id/%d/open", clipID)
-	return c.post(endpoint, uri, nil)
+	return c.post(ctx, endpoint, uriText(uri), nil)
+}
+
+// OpenFileClipByID loads file into clip with the given unique identifier
+//
+// Loads file into clip, will retain settings as much as possible.
+//
+// Deprecated: the endpoint is deprecated in the Resolume API.
+func (c *Client) OpenFileClipByID(clipID int64, fileURI string) error {
+	return c.OpenFileClipByIDContext(context.Background(), clipID, fileURI)
 }
 
-// ClearClipByID clears the clip with the given id
+// OpenFileClipByIDContext is like OpenFileClipByID but with a context
+func (c *Client) OpenFileClipByIDContext(ctx context.Context, clipID int64, fileURI string) error {
+	endpoint := fmt.Sprintf("/composition/clips/by-id/%d/openfile", clipID)
+	return c.post(ctx, endpoint, uriText(fileURI), nil)
+}
+
+// ClearClipByID clears the clip with the given unique id
+//
+// Clears the clip, removing all tracks and settings.
 func (c *Client) ClearClipByID(clipID int64) error {
+	return c.ClearClipByIDContext(context.Background(), clipID)
+}
+
+// ClearClipByIDContext is like ClearClipByID but with a context
+func (c *Client) ClearClipByIDContext(ctx context.Context, clipID int64) error {
 	endpoint := fmt.Sprintf("/composition/clips/by-id/%d/clear", clipID)
-	return c.post(endpoint, nil, nil)
+	return c.post(ctx, endpoint, nil, nil)
+}
+
+// GetSelectedClipThumbnail retrieves the latest thumbnail belonging to the selected clip
+//
+// Finds the selected clip and retrieves the latest thumbnail.
+func (c *Client) GetSelectedClipThumbnail() (io.ReadCloser, error) {
+	return c.GetSelectedClipThumbnailContext(context.Background())
+}
+
+// GetSelectedClipThumbnailContext is like GetSelectedClipThumbnail but with a context
+func (c *Client) GetSelectedClipThumbnailContext(ctx context.Context) (io.ReadCloser, error) {
+	endpoint := "/composition/clips/selected/thumbnail"
+	resp, err := c.getRaw(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// SetSelectedClipThumbnail sets a custom thumbnail for the selected clip
+//
+// Finds the selected clip in the grid and sets the thumbnail.
+func (c *Client) SetSelectedClipThumbnail(thumbnail io.Reader) error {
+	return c.SetSelectedClipThumbnailContext(context.Background(), thumbnail)
+}
+
+// SetSelectedClipThumbnailContext is like SetSelectedClipThumbnail but with a context
+func (c *Client) SetSelectedClipThumbnailContext(ctx context.Context, thumbnail io.Reader) error {
+	endpoint := "/composition/clips/selected/thumbnail"
+	body := multipartFile{field: "file", filename: "thumbnail", content: thumbnail}
+	return c.post(ctx, endpoint, body, nil)
+}
+
+// ResetSelectedClipThumbnail reverts thumbnail to default for the selected clip
+//
+// Undo any custom thumbnail selection and revert to the auto-generated
+// thumbnail.
+func (c *Client) ResetSelectedClipThumbnail() error {
+	return c.ResetSelectedClipThumbnailContext(context.Background())
+}
+
+// ResetSelectedClipThumbnailContext is like ResetSelectedClipThumbnail but with a context
+func (c *Client) ResetSelectedClipThumbnailContext(ctx context.Context) error {
+	endpoint := "/composition/clips/selected/thumbnail"
+	return c.delete(ctx, endpoint)
+}
+
+// GetClipThumbnailByTimestamp retrieves the thumbnail of a clip by its position in the clip grid, which must have been last updated at lastUpdated
+//
+// Finds the clip at the given position in the grid and retrieves the thumbnail
+// if the thumbnail was last updated at the given timestamp.
+func (c *Client) GetClipThumbnailByTimestamp(layerIndex, clipIndex, lastUpdated int64) (io.ReadCloser, error) {
+	return c.GetClipThumbnailByTimestampContext(context.Background(), layerIndex, clipIndex, lastUpdated)
+}
+
+// GetClipThumbnailByTimestampContext is like GetClipThumbnailByTimestamp but with a context
+func (c *Client) GetClipThumbnailByTimestampContext(ctx context.Context, layerIndex, clipIndex, lastUpdated int64) (io.ReadCloser, error) {
+	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d/thumbnail/%d", layerIndex, clipIndex, lastUpdated)
+	resp, err := c.getRaw(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// GetSelectedClipThumbnailByTimestamp retrieves the thumbnail of the selected clip, which must have been last updated at lastUpdated
+//
+// Finds the selected clip and retrieves the thumbnail if the thumbnail was last
+// updated at the given timestamp.
+func (c *Client) GetSelectedClipThumbnailByTimestamp(lastUpdated int64) (io.ReadCloser, error) {
+	return c.GetSelectedClipThumbnailByTimestampContext(context.Background(), lastUpdated)
+}
+
+// GetSelectedClipThumbnailByTimestampContext is like GetSelectedClipThumbnailByTimestamp but with a context
+func (c *Client) GetSelectedClipThumbnailByTimestampContext(ctx context.Context, lastUpdated int64) (io.ReadCloser, error) {
+	endpoint := fmt.Sprintf("/composition/clips/selected/thumbnail/%d", lastUpdated)
+	resp, err := c.getRaw(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// GetClipThumbnailByID retrieves the latest thumbnail belonging to the clip by id
+//
+// Finds the clip with the given unique id and retrieves the latest thumbnail.
+func (c *Client) GetClipThumbnailByID(clipID int64) (io.ReadCloser, error) {
+	return c.GetClipThumbnailByIDContext(context.Background(), clipID)
+}
+
+// GetClipThumbnailByIDContext is like GetClipThumbnailByID but with a context
+func (c *Client) GetClipThumbnailByIDContext(ctx context.Context, clipID int64) (io.ReadCloser, error) {
+	endpoint := fmt.Sprintf("/composition/clips/by-id/%d/thumbnail", clipID)
+	resp, err := c.getRaw(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// SetClipThumbnailByID sets a custom thumbnail for the clip by id
+//
+// Finds the clip with the specified id in the grid and sets the thumbnail.
+func (c *Client) SetClipThumbnailByID(clipID int64, thumbnail io.Reader) error {
+	return c.SetClipThumbnailByIDContext(context.Background(), clipID, thumbnail)
+}
+
+// SetClipThumbnailByIDContext is like SetClipThumbnailByID but with a context
+func (c *Client) SetClipThumbnailByIDContext(ctx context.Context, clipID int64, thumbnail io.Reader) error {
+	endpoint := fmt.Sprintf("/composition/clips/by-id/%d/thumbnail", clipID)
+	body := multipartFile{field: "file", filename: "thumbnail", content: thumbnail}
+	return c.post(ctx, endpoint, body, nil)
+}
+
+// ResetClipThumbnailByID reverts thumbnail to default for the clip by id
+//
+// Undo any custom thumbnail selection and revert to the auto-generated
+// thumbnail.
+func (c *Client) ResetClipThumbnailByID(clipID int64) error {
+	return c.ResetClipThumbnailByIDContext(context.Background(), clipID)
+}
+
+// ResetClipThumbnailByIDContext is like ResetClipThumbnailByID but with a context
+func (c *Client) ResetClipThumbnailByIDContext(ctx context.Context, clipID int64) error {
+	endpoint := fmt.Sprintf("/composition/clips/by-id/%d/thumbnail", clipID)
+	return c.delete(ctx, endpoint)
+}
+
+// GetClipThumbnailByIDAndTimestamp retrieves the thumbnail of the clip by id, which must have been last updated at lastUpdated
+//
+// Finds the clip with the given unique id and retrieves the thumbnail if the
+// thumbnail was last updated at the given timestamp.
+func (c *Client) GetClipThumbnailByIDAndTimestamp(clipID, lastUpdated int64) (io.ReadCloser, error) {
+	return c.GetClipThumbnailByIDAndTimestampContext(context.Background(), clipID, lastUpdated)
+}
+
+// GetClipThumbnailByIDAndTimestampContext is like GetClipThumbnailByIDAndTimestamp but with a context
+func (c *Client) GetClipThumbnailByIDAndTimestampContext(ctx context.Context, clipID, lastUpdated int64) (io.ReadCloser, error) {
+	endpoint := fmt.Sprintf("/composition/clips/by-id/%d/thumbnail/%d", clipID, lastUpdated)
+	resp, err := c.getRaw(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// GetEffectByID retrieves effect properties given their unique identifier
+//
+// Finds the effect in any clip belonging to the composition.
+func (c *Client) GetEffectByID(effectID int64) (AnyEffect, error) {
+	return c.GetEffectByIDContext(context.Background(), effectID)
+}
+
+// GetEffectByIDContext is like GetEffectByID but with a context
+func (c *Client) GetEffectByIDContext(ctx context.Context, effectID int64) (AnyEffect, error) {
+	endpoint := fmt.Sprintf("/composition/effects/by-id/%d", effectID)
+	var raw json.RawMessage
+	if err := c.get(ctx, endpoint, &raw); err != nil {
+		return nil, err
+	}
+	return decodeAnyEffect(raw)
+}
+
+// ReplaceEffectByID updates effect by id
+//
+// Update a single effect.
+func (c *Client) ReplaceEffectByID(effectID int64, effect interface{}) error {
+	return c.ReplaceEffectByIDContext(context.Background(), effectID, effect)
+}
+
+// ReplaceEffectByIDContext is like ReplaceEffectByID but with a context
+func (c *Client) ReplaceEffectByIDContext(ctx context.Context, effectID int64, effect interface{}) error {
+	endpoint := fmt.Sprintf("/composition/effects/by-id/%d", effectID)
+	return c.put(ctx, endpoint, effect, nil)
 }
@@ -0,0 +1,276 @@
+package resolume
+
+import (
+	"bufio"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/FlowingSPDG/resolume-go/uri"
+)
+
+// recordedRequest holds what the test server received
+type recordedRequest struct {
+	method      string
+	path        string
+	contentType string
+	body        string
+}
+
+// newRecordingServer starts a server recording each request and answering 204
+func newRecordingServer(t *testing.T) (*Client, *[]recordedRequest, func()) {
+	t.Helper()
+	var requests []recordedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, recordedRequest{
+			method:      r.Method,
+			path:        r.URL.Path,
+			contentType: r.Header.Get("Content-Type"),
+			body:        string(body),
+		})
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	client, err := NewClientFromURL(server.URL+"/api/v1", WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewClientFromURL() error = %v", err)
+	}
+	return client, &requests, server.Close
+}
+
+func TestPlainTextBodies(t *testing.T) {
+	client, requests, closeServer := newRecordingServer(t)
+	defer closeServer()
+
+	tests := []struct {
+		name string
+		call func() error
+		path string
+		body string
+	}{
+		{"AddEffect", func() error { return client.AddEffect("effect:///video/Blur") }, "/api/v1/composition/effects/video/add", "effect:///video/Blur"},
+		{"MoveEffectToOffset", func() error { return client.MoveEffectToOffset(2, "/composition/video/effects/1") }, "/api/v1/composition/effects/video/move/2", "/composition/video/effects/1"},
+		{"OpenSelectedClip", func() error { return client.OpenSelectedClip("file:///Users/Resolume/file%201.mov") }, "/api/v1/composition/clips/selected/open", "file:///Users/Resolume/file%201.mov"},
+		{"OpenClipByID", func() error { return client.OpenClipByID(12, "source:///video/Gradient") }, "/api/v1/composition/clips/by-id/12/open", "source:///video/Gradient"},
+		{"AddLayer", func() error { return client.AddLayer("/composition/layers/3") }, "/api/v1/composition/layers/add", "/composition/layers/3"},
+		{"AddColumn", func() error { return client.AddColumn("/composition/columns/2") }, "/api/v1/composition/columns/add", "/composition/columns/2"},
+		{"SetEffectDisplayName", func() error { return client.SetEffectDisplayName(5, "My Blur") }, "/api/v1/composition/effects/by-id/5/set-display-name", "My Blur"},
+		{"CompositionAction", func() error { return client.CompositionAction("undo") }, "/api/v1/composition/action", "undo"},
+		{"SetClipThumbnailFromFile", func() error { return client.SetClipThumbnailFromFile(1, 2, "file:///C:/thumbnail.png") }, "/api/v1/composition/layers/1/clips/2/thumbnail", "file:///C:/thumbnail.png"},
+		{"SetSelectedClipThumbnailFromFile", func() error { return client.SetSelectedClipThumbnailFromFile("file:///thumbnail.gif") }, "/api/v1/composition/clips/selected/thumbnail", "file:///thumbnail.gif"},
+		{"SetClipThumbnailByIDFromFile", func() error { return client.SetClipThumbnailByIDFromFile(9, "file:///thumbnail.jpg") }, "/api/v1/composition/clips/by-id/9/thumbnail", "file:///thumbnail.jpg"},
+	}
+
+	for _, tt := range tests {
+		*requests = nil
+		if err := tt.call(); err != nil {
+			t.Errorf("%s() error = %v", tt.name, err)
+			continue
+		}
+		if len(*requests) != 1 {
+			t.Errorf("%s() sent %d requests, want 1", tt.name, len(*requests))
+			continue
+		}
+		req := (*requests)[0]
+		if req.path != tt.path {
+			t.Errorf("%s() path = %s, want %s", tt.name, req.path, tt.path)
+		}
+		if req.contentType != "text/plain" {
+			t.Errorf("%s() Content-Type = %s, want text/plain", tt.name, req.contentType)
+		}
+		if req.body != tt.body {
+			t.Errorf("%s() body = %q, want %q", tt.name, req.body, tt.body)
+		}
+	}
+}
+
+func TestEmptyPlainTextBody(t *testing.T) {
+	client, requests, closeServer := newRecordingServer(t)
+	defer closeServer()
+
+	if err := client.AddLayer(""); err != nil {
+		t.Fatalf("AddLayer() error = %v", err)
+	}
+	req := (*requests)[0]
+	if req.body != "" || req.contentType != "" {
+		t.Errorf("Expected no body, got %q with Content-Type %q", req.body, req.contentType)
+	}
+}
+
+func TestJSONBody(t *testing.T) {
+	client, requests, closeServer := newRecordingServer(t)
+	defer closeServer()
+
+	if err := client.ResetParameterByID(7, true); err != nil {
+		t.Fatalf("ResetParameterByID() error = %v", err)
+	}
+	req := (*requests)[0]
+	if req.contentType != "application/json" {
+		t.Errorf("Content-Type = %s, want application/json", req.contentType)
+	}
+	if req.body != `{"resetanimation":true}` {
+		t.Errorf("body = %s, want {\"resetanimation\":true}", req.body)
+	}
+}
+
+func TestMultipartBody(t *testing.T) {
+	client, requests, closeServer := newRecordingServer(t)
+	defer closeServer()
+
+	if err := client.SetClipThumbnail(1, 2, strings.NewReader("PNGDATA")); err != nil {
+		t.Fatalf("SetClipThumbnail() error = %v", err)
+	}
+	req := (*requests)[0]
+	mediaType, params, err := mime.ParseMediaType(req.contentType)
+	if err != nil || mediaType != "multipart/form-data" {
+		t.Fatalf("Content-Type = %s, want multipart/form-data", req.contentType)
+	}
+
+	reader := multipart.NewReader(strings.NewReader(req.body), params["boundary"])
+	part, err := reader.NextPart()
+	if err != nil {
+		t.Fatalf("NextPart() error = %v", err)
+	}
+	if part.FormName() != "file" {
+		t.Errorf("form name = %s, want file", part.FormName())
+	}
+	content, _ := io.ReadAll(part)
+	if string(content) != "PNGDATA" {
+		t.Errorf("file content = %q, want PNGDATA", content)
+	}
+}
+
+func TestURIOverloads(t *testing.T) {
+	client, requests, closeServer := newRecordingServer(t)
+	defer closeServer()
+
+	blow := Effect{IDString: "A139", Name: "Blow"}
+	if err := client.AddEffectURI(blow.URI("Solid")); err != nil {
+		t.Fatalf("AddEffectURI() error = %v", err)
+	}
+	if err := client.OpenClipByIDURI(12, uri.FileURI(`C:\Users\Resolume\file 1.mov`)); err != nil {
+		t.Fatalf("OpenClipByIDURI() error = %v", err)
+	}
+	if err := client.MoveLayerToGroupURI(2, uri.LayerByIDURI(1658311520090)); err != nil {
+		t.Fatalf("MoveLayerToGroupURI() error = %v", err)
+	}
+
+	want := []string{
+		"effect:///video/Blow/Solid",
+		"file:///C:/Users/Resolume/file%201.mov",
+		"/composition/layers/by-id/1658311520090",
+	}
+	for i, body := range want {
+		if (*requests)[i].body != body {
+			t.Errorf("request %d body = %q, want %q", i, (*requests)[i].body, body)
+		}
+	}
+}
+
+func TestEveryOperationHasMethod(t *testing.T) {
+	f, err := os.Open("swagger.yaml")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer f.Close()
+
+	clientType := reflect.TypeOf(&Client{})
+	count := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "operationId:") {
+			continue
+		}
+		count++
+		operationID := strings.TrimSpace(strings.TrimPrefix(line, "operationId:"))
+		name, ok := operations[operationID]
+		if !ok {
+			t.Errorf("operation %s has no client method, run go generate", operationID)
+			continue
+		}
+		for _, method := range []string{name, name + "Context"} {
+			if _, ok := clientType.MethodByName(method); !ok {
+				t.Errorf("operation %s: Client has no method %s", operationID, method)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if count != len(operations) {
+		t.Errorf("swagger.yaml has %d operations, operations table has %d", count, len(operations))
+	}
+}
+
+func TestGeneratedEndpoints(t *testing.T) {
+	client, requests, closeServer := newRecordingServer(t)
+	defer closeServer()
+
+	connect := true
+	tests := []struct {
+		name   string
+		call   func() error
+		method string
+		path   string
+		body   string
+	}{
+		{"DeleteColumnByID", func() error { return client.DeleteColumnByID(7) }, http.MethodDelete, "/api/v1/composition/columns/by-id/7", ""},
+		{"SetLayerEffectDisplayName", func() error { return client.SetLayerEffectDisplayName(1, 2, "Glow") }, http.MethodPost, "/api/v1/composition/layers/1/effects/video/2/set-display-name", "Glow"},
+		{"ConnectLayerGroupColumn", func() error { return client.ConnectLayerGroupColumn(2, 3, &connect) }, http.MethodPost, "/api/v1/composition/layergroups/2/columns/3/connect", "true"},
+		{"AddEffectToClipByIDAtOffset", func() error { return client.AddEffectToClipByIDAtOffset(9, 1, "effect:///video/Blur") }, http.MethodPost, "/api/v1/composition/clips/9/effects/video/add/1", "effect:///video/Blur"},
+		{"OpenFileClip", func() error { return client.OpenFileClip(1, 2, "file:///C:/a.mov") }, http.MethodPost, "/api/v1/composition/layers/1/clips/2/openfile", "file:///C:/a.mov"},
+		{"ResetClipThumbnailByID", func() error { return client.ResetClipThumbnailByID(9) }, http.MethodDelete, "/api/v1/composition/clips/by-id/9/thumbnail", ""},
+	}
+
+	for _, tt := range tests {
+		*requests = nil
+		if err := tt.call(); err != nil {
+			t.Errorf("%s() error = %v", tt.name, err)
+			continue
+		}
+		req := (*requests)[0]
+		if req.method != tt.method || req.path != tt.path {
+			t.Errorf("%s() sent %s %s, want %s %s", tt.name, req.method, req.path, tt.method, tt.path)
+		}
+		if strings.TrimSpace(req.body) != tt.body {
+			t.Errorf("%s() body = %q, want %q", tt.name, req.body, tt.body)
+		}
+	}
+}
+
+func TestGetEffectByIDDecodesUnion(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{"id": 1, "name": "Blur", "display_name": "Blur", "params": {}}`, "*resolume.VideoEffect"},
+		{`{"id": 2, "name": "EQ", "params": {}}`, "*resolume.AudioEffect"},
+	}
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(tt.body))
+		}))
+		client, err := NewClientFromURL(server.URL+"/api/v1", WithHTTPClient(server.Client()))
+		if err != nil {
+			t.Fatalf("NewClientFromURL() error = %v", err)
+		}
+		effect, err := client.GetEffectByID(1)
+		server.Close()
+		if err != nil {
+			t.Errorf("GetEffectByID() error = %v", err)
+			continue
+		}
+		if got := reflect.TypeOf(effect).String(); got != tt.want {
+			t.Errorf("GetEffectByID() = %s, want %s", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package resolume
+
+import (
+	"context"
+
+	"github.com/FlowingSPDG/resolume-go/uri"
+)
+
+// URI returns the URI of the effect with an optional preset name
+func (e Effect) URI(preset string) uri.Effect {
+	return uri.EffectURI(e.Name, preset)
+}
+
+// URI returns the URI of the source with an optional preset id
+func (s Source) URI(presetID int64) uri.Source {
+	return uri.SourceURI(s.Name, presetID)
+}
+
+// AddEffectURI adds an effect to the entire composition
+func (c *Client) AddEffectURI(effect uri.Effect) error {
+	return c.AddEffectURIContext(context.Background(), effect)
+}
+
+// AddEffectURIContext is like AddEffectURI but with a context
+func (c *Client) AddEffectURIContext(ctx context.Context, effect uri.Effect) error {
+	return c.AddEffectContext(ctx, effect.String())
+}
+
+// MoveEffectURI moves an effect instance to the end of the composition
+func (c *Client) MoveEffectURI(effect uri.EffectInstance) error {
+	return c.MoveEffectURIContext(context.Background(), effect)
+}
+
+// MoveEffectURIContext is like MoveEffectURI but with a context
+func (c *Client) MoveEffectURIContext(ctx context.Context, effect uri.EffectInstance) error {
+	return c.MoveEffectContext(ctx, effect.String())
+}
+
+// AddColumnURI adds a new column before the given column, or at the end for the zero value
+func (c *Client) AddColumnURI(before uri.Column) error {
+	return c.AddColumnURIContext(context.Background(), before)
+}
+
+// AddColumnURIContext is like AddColumnURI but with a context
+func (c *Client) AddColumnURIContext(ctx context.Context, before uri.Column) error {
+	return c.AddColumnContext(ctx, before.String())
+}
+
+// AddLayerURI adds a new layer before the given layer, or at the end for the zero value
+func (c *Client) AddLayerURI(before uri.Layer) error {
+	return c.AddLayerURIContext(context.Background(), before)
+}
+
+// AddLayerURIContext is like AddLayerURI but with a context
+func (c *Client) AddLayerURIContext(ctx context.Context, before uri.Layer) error {
+	return c.AddLayerContext(ctx, before.String())
+}
+
+// AddDeckURI adds a new deck before the given deck, or at the end for the zero value
+func (c *Client) AddDeckURI(before uri.Deck) error {
+	return c.AddDeckURIContext(context.Background(), before)
+}
+
+// AddDeckURIContext is like AddDeckURI but with a context
+func (c *Client) AddDeckURIContext(ctx context.Context, before uri.Deck) error {
+	return c.AddDeckContext(ctx, before.String())
+}
+
+// AddLayerGroupURI adds a new layer group before the given uri.Layer or uri.LayerGroup,
+// or at the end when before is nil
+func (c *Client) AddLayerGroupURI(before uri.URI) error {
+	return c.AddLayerGroupURIContext(context.Background(), before)
+}
+
+// AddLayerGroupURIContext is like AddLayerGroupURI but with a context
+func (c *Client) AddLayerGroupURIContext(ctx context.Context, before uri.URI) error {
+	if before == nil {
+		return c.AddLayerGroupContext(ctx, "")
+	}
+	return c.AddLayerGroupContext(ctx, before.String())
+}
+
+// MoveLayerToGroupURI adds an existing layer to an existing layer group
+func (c *Client) MoveLayerToGroupURI(layerGroupIndex int64, layer uri.Layer) error {
+	return c.MoveLayerToGroupURIContext(context.Background(), layerGroupIndex, layer)
+}
+
+// MoveLayerToGroupURIContext is like MoveLayerToGroupURI but with a context
+func (c *Client) MoveLayerToGroupURIContext(ctx context.Context, layerGroupIndex int64, layer uri.Layer) error {
+	return c.MoveLayerToGroupContext(ctx, layerGroupIndex, layer.String())
+}
+
+// OpenSelectedClipURI loads a uri.File or opens a uri.Source into the selected clip
+func (c *Client) OpenSelectedClipURI(media uri.Media) error {
+	return c.OpenSelectedClipURIContext(context.Background(), media)
+}
+
+// OpenSelectedClipURIContext is like OpenSelectedClipURI but with a context
+func (c *Client) OpenSelectedClipURIContext(ctx context.Context, media uri.Media) error {
+	return c.OpenSelectedClipContext(ctx, media.String())
+}
+
+// OpenClipByIDURI loads a uri.File or opens a uri.Source into the clip with the given id
+func (c *Client) OpenClipByIDURI(clipID int64, media uri.Media) error {
+	return c.OpenClipByIDURIContext(context.Background(), clipID, media)
+}
+
+// OpenClipByIDURIContext is like OpenClipByIDURI but with a context
+func (c *Client) OpenClipByIDURIContext(ctx context.Context, clipID int64, media uri.Media) error {
+	return c.OpenClipByIDContext(ctx, clipID, media.String())
+}
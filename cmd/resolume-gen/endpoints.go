@@ -0,0 +1,273 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// bodyKind is how a request body is passed to the API
+type bodyKind int
+
+const (
+	bodyText bodyKind = iota
+	bodyURI
+	bodyJSON
+	bodyReset
+	bodyMultipart
+)
+
+// resultKind is how a response body is returned
+type resultKind int
+
+const (
+	resultNone resultKind = iota
+	resultJSON
+	resultUnion
+	resultImage
+)
+
+// arg is a Go function argument
+type arg struct {
+	Name string
+	Type string
+}
+
+// endpoint is an operation resolved into a Client method
+type endpoint struct {
+	OperationID string
+	Method      string
+	Path        string
+	Deprecated  bool
+	Name        string
+	Doc         string
+	Description string
+	Params      []arg
+
+	Body     *arg
+	BodyKind bodyKind
+	Enum     []string
+
+	ResultKind   resultKind
+	ResultType   string
+	ResultDecode string
+}
+
+// Args returns the path parameters followed by the body argument
+func (e *endpoint) Args() []arg {
+	args := append([]arg(nil), e.Params...)
+	if e.Body != nil {
+		args = append(args, *e.Body)
+	}
+	return args
+}
+
+// resolveEndpoints resolves every operation in the spec, in document order
+func resolveEndpoints(s *spec) ([]*endpoint, error) {
+	var endpoints []*endpoint
+	var missing []string
+	for _, item := range s.Paths {
+		for _, op := range item.operations() {
+			m, ok := methods[op.OperationID]
+			if !ok {
+				missing = append(missing, op.OperationID)
+				continue
+			}
+			e, err := resolveEndpoint(item, op, m)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op.OperationID, err)
+			}
+			endpoints = append(endpoints, e)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("no method name for operations: %s", strings.Join(missing, ", "))
+	}
+	return endpoints, nil
+}
+
+func resolveEndpoint(item pathItem, op methodOperation, m method) (*endpoint, error) {
+	e := &endpoint{
+		OperationID: op.OperationID,
+		Method:      op.Method,
+		Path:        item.Path,
+		Deprecated:  op.Deprecated,
+		Name:        m.Name,
+		Doc:         m.Doc,
+		Description: op.Description,
+	}
+	if e.Doc == "" {
+		e.Doc = docFromSummary(op.Summary)
+	}
+
+	params := map[string]parameter{}
+	for _, p := range append(append([]parameter(nil), item.Parameters...), op.Parameters...) {
+		params[p.Name] = p
+	}
+	for _, name := range pathParams(item.Path) {
+		p, ok := params[name]
+		if !ok {
+			return nil, fmt.Errorf("path parameter %s is not declared", name)
+		}
+		e.Params = append(e.Params, arg{Name: goName(name), Type: goType(p.Schema)})
+	}
+
+	if err := e.resolveBody(op.RequestBody, m); err != nil {
+		return nil, err
+	}
+	if err := e.resolveResult(op.Responses, m); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// resolveBody picks the request body argument. Multipart is preferred over
+// text/plain for thumbnails, which accept both.
+func (e *endpoint) resolveBody(body *requestBody, m method) error {
+	if body == nil {
+		return nil
+	}
+	if _, ok := content(body.Content, "multipart/form-data"); ok {
+		e.Body = &arg{Name: m.Body, Type: "io.Reader"}
+		e.BodyKind = bodyMultipart
+	} else if mt, ok := content(body.Content, "text/plain"); ok {
+		e.Body = &arg{Name: m.Body, Type: "string"}
+		e.BodyKind = bodyText
+		if strings.HasSuffix(strings.ToLower(m.Body), "uri") {
+			// e.g. effectURI or beforeLayerURI
+			e.BodyKind = bodyURI
+		}
+		e.Enum = mt.Schema.Enum
+	} else if mt, ok := content(body.Content, "application/json"); ok {
+		switch s := mt.Schema; {
+		case s.refName() == "ResetParameter" || hasResetAnimation(s):
+			e.Body = &arg{Name: "resetAnimation", Type: "bool"}
+			e.BodyKind = bodyReset
+			return nil
+		case s.Ref != "":
+			e.Body = &arg{Name: lowerFirst(s.refName()), Type: "*" + s.refName()}
+		case s.Type == "boolean":
+			e.Body = &arg{Name: m.Body, Type: "*bool"}
+		default:
+			e.Body = &arg{Name: m.Body, Type: "interface{}"}
+		}
+		e.BodyKind = bodyJSON
+	} else {
+		return fmt.Errorf("unsupported request body")
+	}
+	if e.Body.Name == "" {
+		return fmt.Errorf("no argument name for the request body")
+	}
+	return nil
+}
+
+// resolveResult picks the result type from the 200 response. A oneOf is
+// decoded into its union unless the method is untyped.
+func (e *endpoint) resolveResult(responses map[string]response, m method) error {
+	resp, ok := responses["200"]
+	if !ok {
+		return nil
+	}
+	if _, ok := content(resp.Content, "image/png"); ok {
+		e.ResultKind = resultImage
+		e.ResultType = "io.ReadCloser"
+		return nil
+	}
+	mt, ok := content(resp.Content, "application/json")
+	if !ok {
+		return nil
+	}
+	switch {
+	case mt.Schema.Ref != "":
+		e.ResultKind = resultJSON
+		e.ResultType = mt.Schema.refName()
+	case len(mt.Schema.OneOf) > 0 && !m.Untyped:
+		u, err := findUnion(mt.Schema.OneOf)
+		if err != nil {
+			return err
+		}
+		e.ResultKind = resultUnion
+		e.ResultType = u.Name
+		e.ResultDecode = u.decodeFunc()
+	default:
+		e.ResultKind = resultJSON
+		e.ResultType = "interface{}"
+	}
+	return nil
+}
+
+// pathParams returns the names of the parameters in a path template, in order
+func pathParams(path string) []string {
+	var names []string
+	for {
+		start := strings.Index(path, "{")
+		if start < 0 {
+			return names
+		}
+		end := strings.Index(path[start:], "}")
+		if end < 0 {
+			return names
+		}
+		names = append(names, path[start+1:start+end])
+		path = path[start+end+1:]
+	}
+}
+
+// goName converts a kebab-case parameter name to a Go identifier, e.g. "layergroup-id" to "layerGroupID"
+func goName(name string) string {
+	var b strings.Builder
+	for i, word := range strings.Split(name, "-") {
+		switch word {
+		case "id":
+			word = "ID"
+		case "layergroup":
+			word = "layerGroup"
+		}
+		if i > 0 {
+			word = strings.ToUpper(word[:1]) + word[1:]
+		}
+		b.WriteString(word)
+	}
+	return b.String()
+}
+
+// goType returns the Go type of a parameter schema
+func goType(s schema) string {
+	switch s.Type {
+	case "integer":
+		return "int64"
+	case "boolean":
+		return "bool"
+	default:
+		return "string"
+	}
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// docFromSummary turns an imperative summary into the rest of a doc comment,
+// e.g. "Retrieve the complete composition" into "retrieves the complete composition"
+func docFromSummary(summary string) string {
+	summary = strings.TrimSuffix(strings.TrimSpace(summary), ".")
+	verb, rest, _ := strings.Cut(summary, " ")
+	verb = strings.ToLower(verb)
+	if !strings.HasSuffix(verb, "s") {
+		verb += "s"
+	}
+	if rest == "" {
+		return verb
+	}
+	return verb + " " + rest
+}
+
+// hasResetAnimation reports whether s is an inline object with a resetanimation flag
+func hasResetAnimation(s schema) bool {
+	prop, ok := s.Properties.get("resetanimation")
+	return ok && prop.Type == "boolean"
+}
@@ -0,0 +1,95 @@
+// Command resolume-gen generates the schema types and the Client endpoint
+// methods from swagger.yaml.
+//
+// It is run with go generate from the repository root:
+//
+//	go generate ./...
+//
+// With -check it writes nothing and fails if the checked-in files differ from
+// what would be generated.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	specPath := flag.String("spec", "swagger.yaml", "path to the OpenAPI document")
+	typesPath := flag.String("types", "types.go", "output file for the schema types")
+	apiPath := flag.String("api", "api.go", "output file for the endpoint methods")
+	operationsPath := flag.String("operations", "operations_test.go", "output file for the operation table")
+	check := flag.Bool("check", false, "fail if the output files are stale instead of writing them")
+	flag.Parse()
+
+	files, err := generate(*specPath, *typesPath, *apiPath, *operationsPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *check {
+		if stale := staleFiles(files); len(stale) > 0 {
+			log.Fatalf("%s out of date with %s, run go generate", strings.Join(stale, ", "), *specPath)
+		}
+		return
+	}
+	for _, f := range files {
+		if err := os.WriteFile(f.path, f.content, 0o644); err != nil {
+			log.Fatalf("failed to write %s: %v", f.path, err)
+		}
+	}
+}
+
+// output is a generated file
+type output struct {
+	path    string
+	content []byte
+}
+
+// generate renders all output files from the spec
+func generate(specPath, typesPath, apiPath, operationsPath string) ([]output, error) {
+	s, err := loadSpec(specPath)
+	if err != nil {
+		return nil, err
+	}
+	decls, err := resolveTypes(s)
+	if err != nil {
+		return nil, err
+	}
+	endpoints, err := resolveEndpoints(s)
+	if err != nil {
+		return nil, err
+	}
+
+	types, err := renderTypes(decls)
+	if err != nil {
+		return nil, err
+	}
+	api, err := renderAPI(endpoints)
+	if err != nil {
+		return nil, err
+	}
+	operations, err := renderOperations(endpoints)
+	if err != nil {
+		return nil, err
+	}
+	return []output{
+		{typesPath, types},
+		{apiPath, api},
+		{operationsPath, operations},
+	}, nil
+}
+
+// staleFiles returns the paths of outputs that differ from the files on disk
+func staleFiles(files []output) []string {
+	var stale []string
+	for _, f := range files {
+		current, err := os.ReadFile(f.path)
+		if err != nil || !bytes.Equal(current, f.content) {
+			stale = append(stale, f.path)
+		}
+	}
+	return stale
+}
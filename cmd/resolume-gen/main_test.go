@@ -0,0 +1,55 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestGeneratedFilesAreCurrent(t *testing.T) {
+	root := filepath.Join("..", "..")
+	files, err := generate(
+		filepath.Join(root, "swagger.yaml"),
+		filepath.Join(root, "types.go"),
+		filepath.Join(root, "api.go"),
+		filepath.Join(root, "operations_test.go"),
+	)
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	if stale := staleFiles(files); len(stale) > 0 {
+		t.Errorf("out of date with swagger.yaml, run go generate: %v", stale)
+	}
+}
+
+func TestDocFromDescription(t *testing.T) {
+	tests := []struct {
+		description string
+		want        string
+	}{
+		{"A column within a deck", "represents a column within a deck"},
+		{"BPM Sync controls", "represents BPM Sync controls"},
+		{"Frame rate expressed as ratio. Read only.", "represents frame rate expressed as ratio"},
+	}
+	for _, tt := range tests {
+		if got := docFromDescription(tt.description); got != tt.want {
+			t.Errorf("docFromDescription(%q) = %q, want %q", tt.description, got, tt.want)
+		}
+	}
+}
+
+func TestOmitEmpty(t *testing.T) {
+	idx := schemaIndex{"ParameterCollection": {AdditionalProperties: &schema{}}}
+	for typ, want := range map[string]bool{
+		"int64":               false,
+		"string":              false,
+		"ParameterView":       false,
+		"*RangeParameter":     true,
+		"[]Effect":            true,
+		"ParameterCollection": true,
+		"Transport":           true,
+	} {
+		if got := idx.omitEmpty(typ); got != want {
+			t.Errorf("omitEmpty(%s) = %v, want %v", typ, got, want)
+		}
+	}
+}
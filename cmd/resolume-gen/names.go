@@ -0,0 +1,209 @@
+package main
+
+// method describes how an operation is exposed on the Client
+type method struct {
+	// Name is the Go method name
+	Name string
+	// Body names the argument carrying a text/plain or boolean request body
+	Body string
+	// Doc replaces the doc comment derived from the summary
+	Doc string
+	// Handwritten marks operations implemented outside the generated file
+	Handwritten bool
+	// Untyped returns a oneOf response as decoded JSON instead of its union
+	Untyped bool
+}
+
+// methods maps every operationId in swagger.yaml to its Client method.
+// An operation missing from this table fails the generator, so new endpoints
+// in a spec update get a deliberate name.
+var methods = map[string]method{
+	"get_product":           {Name: "GetProduct", Handwritten: true},
+	"get_effects":           {Name: "GetEffects", Handwritten: true},
+	"get_sources":           {Name: "GetSources", Handwritten: true},
+	"get_parameter_by_id":   {Name: "GetParameterByID", Untyped: true},
+	"set_parameter_by_id":   {Name: "SetParameterByID", Body: "parameter"},
+	"reset_parameter_by_id": {Name: "ResetParameterByID", Doc: "resets a parameter with the matching unique id"},
+
+	"list_composition":               {Name: "GetComposition"},
+	"replace_composition":            {Name: "ReplaceComposition"},
+	"composition_action":             {Name: "CompositionAction", Body: "action", Doc: "undoes or redoes previously executed actions"},
+	"composition_disconnect_all":     {Name: "DisconnectAllClips"},
+	"effect_set_display_name_by_id":  {Name: "SetEffectDisplayName", Body: "displayName"},
+	"move_effect_composition":        {Name: "MoveEffect", Body: "effectURI"},
+	"move_effect_composition_offset": {Name: "MoveEffectToOffset", Body: "effectURI", Doc: "moves an effect to a specific offset in the composition"},
+	"add_effect_composition":         {Name: "AddEffect", Body: "effectURI"},
+	"add_composition_effect_offset":  {Name: "AddEffectAtOffset", Body: "effectURI", Doc: "adds an effect to the composition at a specific offset"},
+	"delete_composition_effect":      {Name: "DeleteEffect"},
+	"reset_composition_param":        {Name: "ResetCompositionParameter"},
+	"get_effect_by_id":               {Name: "GetEffectByID"},
+	"replace_effect_by_id":           {Name: "ReplaceEffectByID", Body: "effect"},
+
+	"get_column":                         {Name: "GetColumn"},
+	"replace_column":                     {Name: "ReplaceColumn"},
+	"delete_column":                      {Name: "DeleteColumn"},
+	"composition_duplicate_column":       {Name: "DuplicateColumn"},
+	"composition_add_column":             {Name: "AddColumn", Body: "beforeColumnURI"},
+	"reset_column_param":                 {Name: "ResetColumnParameter"},
+	"column_connect":                     {Name: "ConnectColumn", Body: "connect"},
+	"column_select":                      {Name: "SelectColumn"},
+	"get_column_by_id":                   {Name: "GetColumnByID"},
+	"replace_column_by_id":               {Name: "ReplaceColumnByID"},
+	"remove_column_by_id":                {Name: "DeleteColumnByID", Doc: "removes a column by id"},
+	"composition_duplicate_column_by_id": {Name: "DuplicateColumnByID"},
+	"reset_column_param_by_id":           {Name: "ResetColumnParameterByID"},
+	"column_connect_by_id":               {Name: "ConnectColumnByID", Body: "connect"},
+	"column_select_by_id":                {Name: "SelectColumnByID"},
+
+	"get_layer":                            {Name: "GetLayer"},
+	"replace_layer":                        {Name: "ReplaceLayer"},
+	"delete_layer":                         {Name: "DeleteLayer"},
+	"composition_duplicate_layer":          {Name: "DuplicateLayer"},
+	"video_effect_set_display_name_layer":  {Name: "SetLayerEffectDisplayName", Body: "displayName"},
+	"move_effect_layer":                    {Name: "MoveEffectToLayer", Body: "effectURI"},
+	"move_effect_layer_offset":             {Name: "MoveEffectToLayerOffset", Body: "effectURI", Doc: "moves an effect to a specific offset in the layer"},
+	"add_layer_effect":                     {Name: "AddEffectToLayer", Body: "effectURI"},
+	"layer_add_effect_offset":              {Name: "AddEffectToLayerAtOffset", Body: "effectURI"},
+	"delete_layer_effect":                  {Name: "DeleteLayerEffect"},
+	"list_selected_layer":                  {Name: "GetSelectedLayer"},
+	"replace_selected_layer":               {Name: "ReplaceSelectedLayer"},
+	"composition_duplicate_selected_layer": {Name: "DuplicateSelectedLayer"},
+	"add_effect_selected_layer":            {Name: "AddEffectToSelectedLayer", Body: "effectURI"},
+	"selected_layer_add_effect_offset":     {Name: "AddEffectToSelectedLayerAtOffset", Body: "effectURI"},
+	"delete_selected_layer_effect":         {Name: "DeleteSelectedLayerEffect"},
+	"reset_layer_param":                    {Name: "ResetLayerParameter"},
+	"composition_add_layer":                {Name: "AddLayer", Body: "beforeLayerURI"},
+	"reset_selected_layer_param":           {Name: "ResetSelectedLayerParameter"},
+	"layer_select":                         {Name: "SelectLayer"},
+	"layer_clear":                          {Name: "ClearLayer"},
+	"selected_layer_clear":                 {Name: "ClearSelectedLayer"},
+	"layer_clear_clips":                    {Name: "ClearLayerClips"},
+	"selected_layer_clear_clips":           {Name: "ClearSelectedLayerClips"},
+	"get_layer_by_id":                      {Name: "GetLayerByID"},
+	"replace_layer_by_id":                  {Name: "ReplaceLayerByID"},
+	"delete_layer_by_id":                   {Name: "DeleteLayerByID"},
+	"composition_duplicate_layer_by_id":    {Name: "DuplicateLayerByID"},
+	"move_effect_layer_by_id":              {Name: "MoveEffectToLayerByID", Body: "effectURI"},
+	"move_effect_layer_offset_by_id":       {Name: "MoveEffectToLayerByIDOffset", Body: "effectURI"},
+	"add_effect_layer_by_id":               {Name: "AddEffectToLayerByID", Body: "effectURI"},
+	"layer_add_effect_by_id_offset":        {Name: "AddEffectToLayerByIDAtOffset", Body: "effectURI"},
+	"delete_layer_effect_by_id":            {Name: "DeleteLayerEffectByID"},
+	"reset_layer_param_by_id":              {Name: "ResetLayerParameterByID"},
+	"layer_select_by_id":                   {Name: "SelectLayerByID"},
+	"layer_clear_by_id":                    {Name: "ClearLayerByID"},
+	"layer_clear_clips_by_id":              {Name: "ClearLayerClipsByID"},
+
+	"get_layergroup":                             {Name: "GetLayerGroup"},
+	"replace_layergroup":                         {Name: "ReplaceLayerGroup"},
+	"delete_layer_group":                         {Name: "DeleteLayerGroup"},
+	"layer_group_clear":                          {Name: "ClearLayerGroup", Body: "clear"},
+	"selected_layer_group_clear":                 {Name: "ClearSelectedLayerGroup", Body: "clear"},
+	"composition_duplicate_layer_group":          {Name: "DuplicateLayerGroup"},
+	"composition_move_layer_to_group":            {Name: "MoveLayerToGroup", Body: "layerURI"},
+	"composition_add_layer_to_group":             {Name: "AddLayerToGroup", Body: "beforeLayerURI"},
+	"composition_add_layergroup":                 {Name: "AddLayerGroup", Body: "beforeLayerOrGroupURI"},
+	"video_effect_set_display_name_layer_group":  {Name: "SetLayerGroupEffectDisplayName", Body: "displayName"},
+	"move_effect_layer_group":                    {Name: "MoveEffectToLayerGroup", Body: "effectURI"},
+	"move_effect_layer_group_offset":             {Name: "MoveEffectToLayerGroupOffset", Body: "effectURI"},
+	"add_effect_layergroup":                      {Name: "AddEffectToLayerGroup", Body: "effectURI"},
+	"layergroup_add_effect_offset":               {Name: "AddEffectToLayerGroupAtOffset", Body: "effectURI", Doc: "adds an effect to a layer group by index, at the given offset"},
+	"delete_layer_group_effect":                  {Name: "DeleteLayerGroupEffect"},
+	"layer_group_column_connect":                 {Name: "ConnectLayerGroupColumn", Body: "connect"},
+	"layer_group_column_select":                  {Name: "SelectLayerGroupColumn"},
+	"list_selected_layergroup":                   {Name: "GetSelectedLayerGroup"},
+	"replace_selected_layergroup":                {Name: "ReplaceSelectedLayerGroup"},
+	"delete_selected_layer_group":                {Name: "DeleteSelectedLayerGroup"},
+	"composition_duplicate_selected_layer_group": {Name: "DuplicateSelectedLayerGroup"},
+	"composition_move_layer_to_selected_group":   {Name: "MoveLayerToSelectedGroup", Body: "layerURI"},
+	"composition_add_layer_to_selected_group":    {Name: "AddLayerToSelectedGroup", Body: "beforeLayerURI"},
+	"add_effect_selected_layergroup":             {Name: "AddEffectToSelectedLayerGroup", Body: "effectURI"},
+	"selected_layergroup_add_effect_offset":      {Name: "AddEffectToSelectedLayerGroupAtOffset", Body: "effectURI", Doc: "adds an effect at the given offset to the selected layer group"},
+	"delete_selected_layer_group_effect":         {Name: "DeleteSelectedLayerGroupEffect"},
+	"reset_layer_group_param":                    {Name: "ResetLayerGroupParameter"},
+	"reset_selected_layer_group_param":           {Name: "ResetSelectedLayerGroupParameter"},
+	"layergroup_select":                          {Name: "SelectLayerGroup"},
+	"get_layergroup_column":                      {Name: "GetLayerGroupColumn"},
+	"replace_layergroup_column":                  {Name: "ReplaceLayerGroupColumn"},
+	"get_layergroup_by_id":                       {Name: "GetLayerGroupByID"},
+	"replace_layergroup_by_id":                   {Name: "ReplaceLayerGroupByID", Doc: "updates specified layer group and/or layers by id"},
+	"delete_layergroup_by_id":                    {Name: "DeleteLayerGroupByID"},
+	"layer_group_clear_by_id":                    {Name: "ClearLayerGroupByID", Body: "clear"},
+	"composition_duplicate_layer_group_by_id":    {Name: "DuplicateLayerGroupByID"},
+	"composition_move_layer_to_group_by_id":      {Name: "MoveLayerToGroupByID", Body: "layerURI"},
+	"composition_add_layer_to_group_by_id":       {Name: "AddLayerToGroupByID", Body: "beforeLayerURI"},
+	"move_effect_layer_group_by_id":              {Name: "MoveEffectToLayerGroupByID", Body: "effectURI"},
+	"move_effect_layer_group_offset_by_id":       {Name: "MoveEffectToLayerGroupByIDOffset", Body: "effectURI"},
+	"add_effect_layergroup_by_id":                {Name: "AddEffectToLayerGroupByID", Body: "effectURI"},
+	"layergroup_add_effect_by_id_offset":         {Name: "AddEffectToLayerGroupByIDAtOffset", Body: "effectURI", Doc: "adds an effect to a layer group by unique id, at the given offset"},
+	"delete_layer_group_effect_by_id":            {Name: "DeleteLayerGroupEffectByID"},
+	"reset_layer_group_param_by_id":              {Name: "ResetLayerGroupParameterByID"},
+	"layergroup_select_by_id":                    {Name: "SelectLayerGroupByID"},
+
+	"get_deck":                         {Name: "GetDeck"},
+	"replace_deck":                     {Name: "ReplaceDeck"},
+	"delete_deck":                      {Name: "DeleteDeck"},
+	"composition_duplicate_deck":       {Name: "DuplicateDeck"},
+	"composition_add_deck":             {Name: "AddDeck", Body: "beforeDeckURI"},
+	"reset_deck_param":                 {Name: "ResetDeckParameter"},
+	"deck_select":                      {Name: "SelectDeck"},
+	"get_deck_by_id":                   {Name: "GetDeckByID"},
+	"replace_deck_by_id":               {Name: "ReplaceDeckByID"},
+	"delete_deck_by_id":                {Name: "DeleteDeckByID"},
+	"composition_duplicate_deck_by_id": {Name: "DuplicateDeckByID"},
+	"composition_close_deck_by_id":     {Name: "CloseDeckByID"},
+	"composition_open_deck_by_id":      {Name: "OpenDeckByID"},
+	"reset_deck_param_by_id":           {Name: "ResetDeckParameterByID"},
+	"deck_select_by_id":                {Name: "SelectDeckByID"},
+
+	"get_clip_by_position":               {Name: "GetClipByPosition"},
+	"replace_clip_by_position":           {Name: "ReplaceClipByPosition"},
+	"video_effect_set_display_name_clip": {Name: "SetClipEffectDisplayName", Body: "displayName"},
+	"move_effect_clip":                   {Name: "MoveEffectToClip", Body: "effectURI"},
+	"move_effect_clip_offset":            {Name: "MoveEffectToClipOffset", Body: "effectURI"},
+	"add_effect_clip":                    {Name: "AddEffectToClip", Body: "effectURI"},
+	"clip_add_effect_offset":             {Name: "AddEffectToClipAtOffset", Body: "effectURI", Doc: "adds an effect to a clip by its position in the clip grid, at the given offset"},
+	"delete_clip_effect":                 {Name: "DeleteClipEffect"},
+	"list_selected_clip":                 {Name: "GetSelectedClip"},
+	"replace_selected_clip":              {Name: "ReplaceSelectedClip"},
+	"add_effect_selected_clip":           {Name: "AddEffectToSelectedClip", Body: "effectURI"},
+	"selected_clip_add_effect_offset":    {Name: "AddEffectToSelectedClipAtOffset", Body: "effectURI", Doc: "adds an effect at the given offset to the selected clip"},
+	"delete_selected_clip_effect":        {Name: "DeleteSelectedClipEffect"},
+	"reset_clip_param":                   {Name: "ResetClipParameter"},
+	"reset_selected_clip_param":          {Name: "ResetSelectedClipParameter"},
+	"clip_select":                        {Name: "SelectClip"},
+	"clip_connect":                       {Name: "ConnectClip", Body: "connect"},
+	"selected_clip_connect":              {Name: "ConnectSelectedClip", Body: "connect"},
+	"clip_open":                          {Name: "OpenClip", Body: "uri"},
+	"clip_openfile":                      {Name: "OpenFileClip", Body: "fileURI"},
+	"selected_clip_open":                 {Name: "OpenSelectedClip", Body: "uri"},
+	"selected_clip_openfile":             {Name: "OpenFileSelectedClip", Body: "fileURI"},
+	"clip_clear":                         {Name: "ClearClip"},
+	"selected_clip_clear":                {Name: "ClearSelectedClip"},
+	"get_clip_by_id":                     {Name: "GetClipByID"},
+	"replace_clip_by_id":                 {Name: "ReplaceClipByID"},
+	"move_effect_clip_by_id":             {Name: "MoveEffectToClipByID", Body: "effectURI"},
+	"move_effect_clip_offset_by_id":      {Name: "MoveEffectToClipByIDOffset", Body: "effectURI"},
+	"add_effect_clip_by_id":              {Name: "AddEffectToClipByID", Body: "effectURI"},
+	"clip_add_effect_offset_by_id":       {Name: "AddEffectToClipByIDAtOffset", Body: "effectURI", Doc: "adds an effect to a clip by its unique identifier, at the given offset"},
+	"delete_clip_effect_by_id":           {Name: "DeleteClipEffectByID"},
+	"reset_clip_param_by_id":             {Name: "ResetClipParameterByID"},
+	"clip_select_by_id":                  {Name: "SelectClipByID"},
+	"clip_connect_by_id":                 {Name: "ConnectClipByID", Body: "connect"},
+	"clip_open_by_id":                    {Name: "OpenClipByID", Body: "uri", Doc: "loads a file or opens a source into the clip with the given unique id"},
+	"clip_openfile_by_id":                {Name: "OpenFileClipByID", Body: "fileURI"},
+	"clip_clear_by_id":                   {Name: "ClearClipByID"},
+
+	"list_clip_thumbnail_by_position":              {Name: "GetClipThumbnail", Handwritten: true},
+	"set_clip_thumbnail_by_position":               {Name: "SetClipThumbnail", Body: "thumbnail", Handwritten: true},
+	"revert_clip_thumbnail_by_position":            {Name: "ResetClipThumbnail", Handwritten: true},
+	"list_selected_clip_thumbnail":                 {Name: "GetSelectedClipThumbnail"},
+	"set_selected_clip_thumbnail":                  {Name: "SetSelectedClipThumbnail", Body: "thumbnail"},
+	"revert_selected_clip_thumbnail":               {Name: "ResetSelectedClipThumbnail"},
+	"get_clip_thumbnail_by_position_and_timestamp": {Name: "GetClipThumbnailByTimestamp", Doc: "retrieves the thumbnail of a clip by its position in the clip grid, which must have been last updated at lastUpdated"},
+	"get_last_clip_thumbnail_by_timestamp":         {Name: "GetSelectedClipThumbnailByTimestamp", Doc: "retrieves the thumbnail of the selected clip, which must have been last updated at lastUpdated"},
+	"list_clip_thumbnail_by_id":                    {Name: "GetClipThumbnailByID", Doc: "retrieves the latest thumbnail belonging to the clip by id"},
+	"set_clip_thumbnail_by_id":                     {Name: "SetClipThumbnailByID", Body: "thumbnail"},
+	"revert_clip_thumbnail_by_id":                  {Name: "ResetClipThumbnailByID"},
+	"get_clip_thumbnail_by_id_and_timestamp":       {Name: "GetClipThumbnailByIDAndTimestamp", Doc: "retrieves the thumbnail of the clip by id, which must have been last updated at lastUpdated"},
+	"list_dummy_thumbnail":                         {Name: "GetDummyThumbnail", Handwritten: true},
+}
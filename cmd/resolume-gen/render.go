@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+const header = "// Code generated by resolume-gen from swagger.yaml. DO NOT EDIT.\n\n"
+
+// renderAPI renders the Client methods of all generated endpoints
+func renderAPI(endpoints []*endpoint) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(header)
+	b.WriteString("package resolume\n\nimport (\n\t\"context\"\n")
+	for _, e := range endpoints {
+		if e.ResultKind == resultUnion && !methods[e.OperationID].Handwritten {
+			b.WriteString("\t\"encoding/json\"\n")
+			break
+		}
+	}
+	b.WriteString("\t\"fmt\"\n\t\"io\"\n)\n")
+	for _, e := range endpoints {
+		if methods[e.OperationID].Handwritten {
+			continue
+		}
+		renderEndpoint(&b, e)
+	}
+	return formatSource(b.Bytes())
+}
+
+// renderOperations renders the table of operationIds to method names used by the coverage test
+func renderOperations(endpoints []*endpoint) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(header)
+	b.WriteString("package resolume\n\n")
+	b.WriteString("// operations maps each operationId in swagger.yaml to its Client method\n")
+	b.WriteString("var operations = map[string]string{\n")
+	sorted := append([]*endpoint(nil), endpoints...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].OperationID < sorted[j].OperationID })
+	for _, e := range sorted {
+		fmt.Fprintf(&b, "%q: %q,\n", e.OperationID, e.Name)
+	}
+	b.WriteString("}\n")
+	return formatSource(b.Bytes())
+}
+
+func formatSource(src []byte) ([]byte, error) {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return formatted, nil
+}
+
+func renderEndpoint(b *bytes.Buffer, e *endpoint) {
+	args := e.Args()
+	names := make([]string, len(args))
+	for i, a := range args {
+		names[i] = a.Name
+	}
+	params := signature(args)
+	results := "error"
+	if e.ResultKind != resultNone {
+		results = fmt.Sprintf("(%s, error)", resultType(e))
+	}
+
+	fmt.Fprintf(b, "\n// %s %s\n", e.Name, e.Doc)
+	if description := docParagraph(e.Description, e.Doc); description != "" {
+		b.WriteString("//\n" + description)
+	}
+	if e.Deprecated {
+		b.WriteString("//\n// Deprecated: the endpoint is deprecated in the Resolume API.\n")
+	}
+	fmt.Fprintf(b, "func (c *Client) %s(%s) %s {\n", e.Name, params, results)
+	fmt.Fprintf(b, "\treturn c.%sContext(%s)\n}\n", e.Name, strings.Join(append([]string{"context.Background()"}, names...), ", "))
+
+	ctxParams := "ctx context.Context"
+	if params != "" {
+		ctxParams += ", " + params
+	}
+	fmt.Fprintf(b, "\n// %sContext is like %s but with a context\n", e.Name, e.Name)
+	fmt.Fprintf(b, "func (c *Client) %sContext(%s) %s {\n", e.Name, ctxParams, results)
+	if len(e.Enum) > 0 {
+		renderEnumCheck(b, e.Body.Name, e.Enum)
+	}
+	fmt.Fprintf(b, "\tendpoint := %s\n", endpointExpr(e))
+	renderCall(b, e)
+	b.WriteString("}\n")
+}
+
+// signature joins arguments, grouping adjacent arguments of the same type
+func signature(args []arg) string {
+	var parts []string
+	for i, a := range args {
+		if i+1 < len(args) && args[i+1].Type == a.Type {
+			parts = append(parts, a.Name)
+		} else {
+			parts = append(parts, a.Name+" "+a.Type)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// docParagraph wraps a description into comment lines, or returns "" when it
+// only repeats the summary
+func docParagraph(description, doc string) string {
+	text := strings.Join(strings.Fields(description), " ")
+	text = strings.TrimSuffix(text, ".")
+	if text == "" || strings.EqualFold(docFromSummary(text), doc) {
+		return ""
+	}
+
+	var b strings.Builder
+	line := "//"
+	for _, word := range strings.Fields(text + ".") {
+		if len(line)+1+len(word) > 80 && line != "//" {
+			b.WriteString(line + "\n")
+			line = "//"
+		}
+		line += " " + word
+	}
+	b.WriteString(line + "\n")
+	return b.String()
+}
+
+func resultType(e *endpoint) string {
+	if e.ResultKind == resultJSON && e.ResultType != "interface{}" {
+		return "*" + e.ResultType
+	}
+	return e.ResultType
+}
+
+// endpointExpr returns the expression building the endpoint path
+func endpointExpr(e *endpoint) string {
+	if len(e.Params) == 0 {
+		return fmt.Sprintf("%q", e.Path)
+	}
+	pattern := e.Path
+	var names []string
+	for i, name := range pathParams(e.Path) {
+		verb := "%s"
+		if e.Params[i].Type == "int64" {
+			verb = "%d"
+		}
+		pattern = strings.Replace(pattern, "{"+name+"}", verb, 1)
+		names = append(names, e.Params[i].Name)
+	}
+	return fmt.Sprintf("fmt.Sprintf(%q, %s)", pattern, strings.Join(names, ", "))
+}
+
+func renderEnumCheck(b *bytes.Buffer, name string, enum []string) {
+	var conds, quoted []string
+	for _, v := range enum {
+		conds = append(conds, fmt.Sprintf("%s != %q", name, v))
+		quoted = append(quoted, "'"+v+"'")
+	}
+	fmt.Fprintf(b, "\tif %s {\n", strings.Join(conds, " && "))
+	fmt.Fprintf(b, "\t\treturn fmt.Errorf(\"invalid %s: %%s (must be %s)\", %s)\n\t}\n", name, strings.Join(quoted, " or "), name)
+}
+
+// renderCall renders the request and the return statements
+func renderCall(b *bytes.Buffer, e *endpoint) {
+	switch e.Method {
+	case "GET":
+		renderGet(b, e)
+		return
+	case "DELETE":
+		b.WriteString("\treturn c.delete(ctx, endpoint)\n")
+		return
+	}
+
+	body := "nil"
+	if e.Body != nil {
+		switch e.BodyKind {
+		case bodyText:
+			body = fmt.Sprintf("plainText(%s)", e.Body.Name)
+		case bodyURI:
+			body = fmt.Sprintf("uriText(%s)", e.Body.Name)
+		case bodyJSON:
+			body = e.Body.Name
+		case bodyReset:
+			b.WriteString("\tbody := ResetParameter{\n\t\tResetAnimation: resetAnimation,\n\t}\n")
+			body = "body"
+		case bodyMultipart:
+			fmt.Fprintf(b, "\tbody := multipartFile{field: \"file\", filename: \"thumbnail\", content: %s}\n", e.Body.Name)
+			body = "body"
+		}
+	}
+	fmt.Fprintf(b, "\treturn c.%s(ctx, endpoint, %s, nil)\n", strings.ToLower(e.Method), body)
+}
+
+func renderGet(b *bytes.Buffer, e *endpoint) {
+	switch e.ResultKind {
+	case resultImage:
+		b.WriteString("\tresp, err := c.getRaw(ctx, endpoint)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\treturn resp.Body, nil\n")
+	case resultUnion:
+		fmt.Fprintf(b, "\tvar raw json.RawMessage\n\tif err := c.get(ctx, endpoint, &raw); err != nil {\n\t\treturn nil, err\n\t}\n\treturn %s(raw)\n", e.ResultDecode)
+	case resultJSON:
+		if e.ResultType == "interface{}" {
+			b.WriteString("\tvar v interface{}\n\tif err := c.get(ctx, endpoint, &v); err != nil {\n\t\treturn nil, err\n\t}\n\treturn v, nil\n")
+			return
+		}
+		name := lowerFirst(e.ResultType)
+		fmt.Fprintf(b, "\tvar %s %s\n\tif err := c.get(ctx, endpoint, &%s); err != nil {\n\t\treturn nil, err\n\t}\n\treturn &%s, nil\n", name, e.ResultType, name, name)
+	default:
+		b.WriteString("\treturn c.get(ctx, endpoint, nil)\n")
+	}
+}
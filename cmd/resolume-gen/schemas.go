@@ -0,0 +1,356 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// fieldNames maps JSON keys written as one lowercase word to Go field names.
+// Keys with underscores are converted word by word.
+var fieldNames = map[string]string{
+	"autopilot":           "AutoPilot",
+	"autosize":            "AutoSize",
+	"beatloop":            "BeatLoop",
+	"beatsnap":            "BeatSnap",
+	"bpm":                 "BPM",
+	"clipbeatsnap":        "ClipBeatSnap",
+	"cliptarget":          "ClipTarget",
+	"cliptriggerstyle":    "ClipTriggerStyle",
+	"colorid":             "ColorID",
+	"crossfader":          "CrossFader",
+	"crossfadergroup":     "CrossFaderGroup",
+	"faderstart":          "FaderStart",
+	"fileinfo":            "FileInfo",
+	"framerate":           "FrameRate",
+	"idstring":            "IDString",
+	"ignorecolumntrigger": "IgnoreColumnTrigger",
+	"layergroups":         "LayerGroups",
+	"maskmode":            "MaskMode",
+	"playdirection":       "PlayDirection",
+	"playmode":            "PlayMode",
+	"playmodeaway":        "PlayModeAway",
+	"resetanimation":      "ResetAnimation",
+	"scrollx":             "ScrollX",
+	"sidea":               "SideA",
+	"sideb":               "SideB",
+	"sourceparams":        "SourceParams",
+	"syncmode":            "SyncMode",
+	"transporttype":       "TransportType",
+	"triggerstyle":        "TriggerStyle",
+	"valuetype":           "ValueType",
+}
+
+// typeDocs replaces the doc comments of schemas whose description does not
+// read well after the type name
+var typeDocs = map[string]string{
+	"AudioEffect":       "represents a single audio effect in a chain",
+	"VideoEffect":       "represents a single video effect in a chain",
+	"AudioTrackClip":    "represents an audio track specifically for clips",
+	"AutoPilot":         "represents options to control automatic clip transitions",
+	"VideoTrackLayer":   "represents a video track specifically for layers",
+	"VideoTrackClip":    "represents a video track specifically for clips",
+	"TransportBPMSync":  "represents BPM sync transport controls",
+	"TransportTimeline": "represents timeline transport controls",
+	"Deck":              "represents a deck containing a full set of layers and clips",
+	"Layer":             "represents a container for clips that has its own dashboard and can be selected",
+	"LayerTransition":   "describes the transition between clips within a layer",
+}
+
+// namedObjects are the inline objects declared as named types, as they were
+// before types.go was generated. Other inline objects are anonymous structs.
+var namedObjects = map[string]bool{
+	"TransportBPMSyncControls":  true,
+	"TransportTimelineControls": true,
+}
+
+// keptTags are the JSON tags of fields written by hand before types.go was
+// generated, which differ from what omitEmpty gives. They are kept so these
+// fields encode as they always have.
+var keptTags = map[string]string{
+	"AudioTrackClip.description":  "description,omitempty",
+	"VideoTrackClip.description":  "description,omitempty",
+	"ParameterView.suffix":        "suffix,omitempty",
+	"ParameterView.step":          "step,omitempty",
+	"ParameterView.multiplier":    "multiplier,omitempty",
+	"ParameterView.display_units": "display_units,omitempty",
+	"ParameterView.control_type":  "control_type,omitempty",
+	"ChoiceParameter.options":     "options",
+	"Effects.video":               "video",
+	"Sources.video":               "video",
+}
+
+// typeDecl is a Go type generated from a schema
+type typeDecl struct {
+	Name   string
+	Doc    string
+	Map    string // element type when the schema is a map
+	Embeds []string
+	Fields []field
+}
+
+type field struct {
+	Name string
+	Type string
+	Tag  string
+}
+
+// schemaIndex holds the component schemas by name
+type schemaIndex map[string]schema
+
+// resolveTypes converts the component schemas into Go types, in document order.
+// Inline objects become anonymous structs, or namedObjects following their
+// parent.
+func resolveTypes(s *spec) ([]*typeDecl, error) {
+	index := schemaIndex{}
+	for _, sch := range s.Schemas {
+		index[sch.Name] = sch.schema
+	}
+
+	var decls []*typeDecl
+	for _, sch := range s.Schemas {
+		d, nested, err := index.resolveObject(sch.Name, docFromDescription(sch.Description), sch.schema)
+		if err != nil {
+			return nil, fmt.Errorf("schema %s: %w", sch.Name, err)
+		}
+		decls = append(decls, d)
+		decls = append(decls, nested...)
+	}
+	for _, d := range decls {
+		if doc, ok := typeDocs[d.Name]; ok {
+			d.Doc = doc
+		}
+	}
+	return decls, nil
+}
+
+// resolveObject converts an object schema into a type declaration
+func (idx schemaIndex) resolveObject(name, doc string, s schema) (*typeDecl, []*typeDecl, error) {
+	d := &typeDecl{Name: name, Doc: doc}
+	if s.AdditionalProperties != nil {
+		elem, _, err := idx.resolveField(name, "", *s.AdditionalProperties)
+		if err != nil {
+			return nil, nil, err
+		}
+		d.Map = elem
+		return d, nil, nil
+	}
+
+	props := s.Properties
+	for _, part := range s.AllOf {
+		if part.Ref != "" {
+			d.Embeds = append(d.Embeds, part.refName())
+		} else {
+			props = append(props, part.Properties...)
+		}
+	}
+
+	var nested []*typeDecl
+	for _, p := range props {
+		typ, inline, err := idx.resolveField(name, p.Name, p.schema)
+		if err != nil {
+			return nil, nil, fmt.Errorf("property %s: %w", p.Name, err)
+		}
+		nested = append(nested, inline...)
+		tag := p.Name
+		if idx.omitEmpty(typ) {
+			tag += ",omitempty"
+		}
+		if kept, ok := keptTags[name+"."+p.Name]; ok {
+			tag = kept
+		}
+		d.Fields = append(d.Fields, field{Name: fieldName(p.Name), Type: typ, Tag: tag})
+	}
+	return d, nested, nil
+}
+
+// resolveField returns the Go type of a property, and the types declared for inline objects
+func (idx schemaIndex) resolveField(parent, key string, s schema) (string, []*typeDecl, error) {
+	s = flattenAllOf(s)
+	switch {
+	case s.Ref != "":
+		name := s.refName()
+		target, ok := idx[name]
+		if !ok {
+			return "", nil, fmt.Errorf("unknown schema %s", name)
+		}
+		switch {
+		case target.AdditionalProperties != nil:
+			return name, nil, nil
+		case target.ReadOnly && !isNullable(target):
+			return name, nil, nil
+		default:
+			return "*" + name, nil, nil
+		}
+	case len(s.OneOf) > 0:
+		u, err := findUnion(s.OneOf)
+		if err != nil {
+			return "", nil, err
+		}
+		return u.Name, nil, nil
+	case s.Type == "array":
+		if s.Items == nil {
+			return "", nil, fmt.Errorf("array without items")
+		}
+		items := *s.Items
+		if items.Ref != "" {
+			return "[]" + items.refName(), nil, nil
+		}
+		elem, nested, err := idx.resolveField(parent, key, items)
+		// The elements of an array are values
+		return "[]" + strings.TrimPrefix(elem, "*"), nested, err
+	case s.Type == "object":
+		d, nested, err := idx.resolveObject(parent+fieldName(key), "", s)
+		if err != nil {
+			return "", nil, err
+		}
+		typ := d.structType()
+		if namedObjects[d.Name] {
+			d.Doc = fmt.Sprintf("represents the %s of a %s", fieldName(key), parent)
+			if s.Description != "" {
+				d.Doc = docFromDescription(s.Description)
+			}
+			typ, nested = d.Name, append([]*typeDecl{d}, nested...)
+		}
+		if s.Nullable == nil || *s.Nullable {
+			typ = "*" + typ
+		}
+		return typ, nested, nil
+	default:
+		return scalarType(s), nil, nil
+	}
+}
+
+// flattenAllOf reduces an allOf of a single $ref and annotations to that $ref
+func flattenAllOf(s schema) schema {
+	if len(s.AllOf) == 0 {
+		return s
+	}
+	var ref string
+	for _, part := range s.AllOf {
+		if part.Ref != "" {
+			if ref != "" {
+				return s
+			}
+			ref = part.Ref
+		}
+		if len(part.Properties) > 0 {
+			return s
+		}
+		s.ReadOnly = s.ReadOnly || part.ReadOnly
+		if part.Nullable != nil {
+			s.Nullable = part.Nullable
+		}
+	}
+	s.Ref = ref
+	s.AllOf = nil
+	return s
+}
+
+func isNullable(s schema) bool {
+	return s.Nullable != nil && *s.Nullable
+}
+
+// omitEmpty reports whether a field of a Go type is left out when empty: only
+// pointers, slices, maps and unions, which are nil when empty
+func (idx schemaIndex) omitEmpty(typ string) bool {
+	if strings.HasPrefix(typ, "*") || strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[") {
+		return true
+	}
+	if target, ok := idx[typ]; ok && target.AdditionalProperties != nil {
+		return true
+	}
+	for _, u := range unions {
+		if u.Name == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// scalarType returns the Go type of a scalar schema
+func scalarType(s schema) string {
+	switch s.Type {
+	case "integer":
+		if s.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case "number":
+		if s.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	default:
+		return "string"
+	}
+}
+
+// fieldName converts a JSON key to a Go field name
+func fieldName(key string) string {
+	if name, ok := fieldNames[key]; ok {
+		return name
+	}
+	var b strings.Builder
+	for _, word := range strings.Split(key, "_") {
+		if word == "id" {
+			b.WriteString("ID")
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// docFromDescription turns the first sentence of a schema description into
+// the rest of a doc comment, e.g. "A column within a deck" into "represents a column within a deck".
+// A leading acronym such as "BPM" keeps its case.
+func docFromDescription(description string) string {
+	sentence := strings.Join(strings.Fields(description), " ")
+	if i := strings.Index(sentence, ". "); i >= 0 {
+		sentence = sentence[:i]
+	}
+	sentence = strings.TrimSuffix(sentence, ".")
+	first, _, _ := strings.Cut(sentence, " ")
+	if len(first) == 1 || strings.ToUpper(first) != first {
+		sentence = strings.ToLower(sentence[:1]) + sentence[1:]
+	}
+	return "represents " + sentence
+}
+
+// structType renders a declaration as a struct type, formatted by renderTypes
+func (d *typeDecl) structType() string {
+	var b strings.Builder
+	b.WriteString("struct {\n")
+	for _, e := range d.Embeds {
+		fmt.Fprintf(&b, "%s\n", e)
+	}
+	for _, f := range d.Fields {
+		fmt.Fprintf(&b, "%s %s `json:\"%s\"`\n", f.Name, f.Type, f.Tag)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// renderTypes renders the type declarations and the generated unions
+func renderTypes(decls []*typeDecl) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(header)
+	b.WriteString("package resolume\n\nimport (\n\t\"encoding/json\"\n\t\"fmt\"\n)\n")
+	for _, d := range decls {
+		fmt.Fprintf(&b, "\n// %s %s\n", d.Name, d.Doc)
+		if d.Map != "" {
+			fmt.Fprintf(&b, "type %s map[string]%s\n", d.Name, d.Map)
+			continue
+		}
+		fmt.Fprintf(&b, "type %s %s\n", d.Name, d.structType())
+	}
+	for _, u := range unions {
+		if !u.Handwritten {
+			u.render(&b)
+		}
+	}
+	return formatSource(b.Bytes())
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// spec is the subset of an OpenAPI 3 document used by the generator
+type spec struct {
+	Paths   []pathItem
+	Schemas []namedSchema
+}
+
+// namedSchema is a schema from components/schemas
+type namedSchema struct {
+	Name string
+	schema
+}
+
+// pathItem holds the operations of a single path
+type pathItem struct {
+	Path       string
+	Parameters []parameter `yaml:"parameters"`
+	Get        *operation  `yaml:"get"`
+	Put        *operation  `yaml:"put"`
+	Post       *operation  `yaml:"post"`
+	Delete     *operation  `yaml:"delete"`
+}
+
+// operations returns the operations of the path keyed by HTTP method, in a fixed order
+func (p pathItem) operations() []methodOperation {
+	var ops []methodOperation
+	for _, m := range []struct {
+		method string
+		op     *operation
+	}{{"GET", p.Get}, {"PUT", p.Put}, {"POST", p.Post}, {"DELETE", p.Delete}} {
+		if m.op != nil {
+			ops = append(ops, methodOperation{Method: m.method, operation: m.op})
+		}
+	}
+	return ops
+}
+
+type methodOperation struct {
+	Method string
+	*operation
+}
+
+type operation struct {
+	Summary     string              `yaml:"summary"`
+	Description string              `yaml:"description"`
+	OperationID string              `yaml:"operationId"`
+	Deprecated  bool                `yaml:"deprecated"`
+	Parameters  []parameter         `yaml:"parameters"`
+	RequestBody *requestBody        `yaml:"requestBody"`
+	Responses   map[string]response `yaml:"responses"`
+}
+
+type parameter struct {
+	Name        string `yaml:"name"`
+	In          string `yaml:"in"`
+	Description string `yaml:"description"`
+	Schema      schema `yaml:"schema"`
+}
+
+type requestBody struct {
+	Description string               `yaml:"description"`
+	Content     map[string]mediaType `yaml:"content"`
+}
+
+type response struct {
+	Description string               `yaml:"description"`
+	Content     map[string]mediaType `yaml:"content"`
+}
+
+type mediaType struct {
+	Schema schema `yaml:"schema"`
+}
+
+type schema struct {
+	Ref                  string     `yaml:"$ref"`
+	Description          string     `yaml:"description"`
+	Type                 string     `yaml:"type"`
+	Format               string     `yaml:"format"`
+	Enum                 []string   `yaml:"enum"`
+	ReadOnly             bool       `yaml:"readOnly"`
+	Nullable             *bool      `yaml:"nullable"`
+	OneOf                []schema   `yaml:"oneOf"`
+	AllOf                []schema   `yaml:"allOf"`
+	Items                *schema    `yaml:"items"`
+	AdditionalProperties *schema    `yaml:"additionalProperties"`
+	Properties           properties `yaml:"properties"`
+}
+
+// property is a named property of an object schema
+type property struct {
+	Name string
+	schema
+}
+
+// properties keeps the properties of an object schema in document order
+type properties []property
+
+// UnmarshalYAML decodes a properties mapping in document order
+func (p *properties) UnmarshalYAML(node *yaml.Node) error {
+	var err error
+	*p, err = decodeOrdered[property](node, func(name string, s schema) property {
+		return property{Name: name, schema: s}
+	})
+	return err
+}
+
+// get returns the named property
+func (p properties) get(name string) (schema, bool) {
+	for _, prop := range p {
+		if prop.Name == name {
+			return prop.schema, true
+		}
+	}
+	return schema{}, false
+}
+
+// decodeOrdered decodes a mapping of schemas, keeping the keys in document order
+func decodeOrdered[T any](node *yaml.Node, build func(string, schema) T) ([]T, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: expected a mapping", node.Line)
+	}
+	var out []T
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		var s schema
+		if err := node.Content[i+1].Decode(&s); err != nil {
+			return nil, err
+		}
+		out = append(out, build(node.Content[i].Value, s))
+	}
+	return out, nil
+}
+
+// refName returns the component name of a $ref, e.g. "Composition"
+func (s schema) refName() string {
+	return strings.TrimPrefix(s.Ref, "#/components/schemas/")
+}
+
+// loadSpec reads and decodes an OpenAPI document, keeping the paths in document order
+func loadSpec(path string) (*spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc struct {
+		Paths      yaml.Node `yaml:"paths"`
+		Components struct {
+			Schemas yaml.Node `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	s := &spec{}
+	for i := 0; i+1 < len(doc.Paths.Content); i += 2 {
+		item := pathItem{Path: doc.Paths.Content[i].Value}
+		if err := doc.Paths.Content[i+1].Decode(&item); err != nil {
+			return nil, fmt.Errorf("failed to decode path %s: %w", item.Path, err)
+		}
+		s.Paths = append(s.Paths, item)
+	}
+	s.Schemas, err = decodeOrdered[namedSchema](&doc.Components.Schemas, func(name string, sch schema) namedSchema {
+		return namedSchema{Name: name, schema: sch}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode schemas: %w", err)
+	}
+	return s, nil
+}
+
+// content returns the media type of a content map, tolerating a stray leading slash
+func content(c map[string]mediaType, contentType string) (mediaType, bool) {
+	for k, v := range c {
+		if strings.TrimPrefix(k, "/") == contentType {
+			return v, true
+		}
+	}
+	return mediaType{}, false
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// union describes the Go interface generated for a oneOf schema
+type union struct {
+	// Name is the Go interface name
+	Name string
+	// Members are the schemas the union may hold
+	Members []string
+	// Handwritten marks unions declared outside the generated file, with
+	// their own decoding
+	Handwritten bool
+	// Default is the member decoded when none of the Keys are present
+	Default string
+	// Keys are the JSON fields that identify the Other member
+	Keys  []string
+	Other string
+}
+
+// unions lists every oneOf in swagger.yaml. A oneOf missing from this table
+// fails the generator.
+var unions = []union{
+	{
+		Name:        "Parameter",
+		Members:     []string{"BooleanParameter", "ChoiceParameter", "ColorParameter", "IntegerParameter", "RangeParameter", "StringParameter", "TextParameter"},
+		Handwritten: true,
+	},
+	{
+		Name:        "Transport",
+		Members:     []string{"TransportBPMSync", "TransportTimeline"},
+		Handwritten: true,
+	},
+	{
+		Name:    "AnyEffect",
+		Members: []string{"AudioEffect", "VideoEffect"},
+		Default: "AudioEffect",
+		Keys:    []string{"display_name", "mixer", "effect"},
+		Other:   "VideoEffect",
+	},
+}
+
+// findUnion returns the union matching the members of a oneOf
+func findUnion(oneOf []schema) (union, error) {
+	var members []string
+	for _, s := range oneOf {
+		members = append(members, s.refName())
+	}
+	sort.Strings(members)
+	key := strings.Join(members, ",")
+	for _, u := range unions {
+		if strings.Join(u.Members, ",") == key {
+			return u, nil
+		}
+	}
+	return union{}, fmt.Errorf("no union for oneOf %s", key)
+}
+
+// decodeFunc returns the name of the function decoding the union from JSON
+func (u union) decodeFunc() string {
+	if u.Handwritten {
+		return "Decode" + u.Name
+	}
+	return "decode" + u.Name
+}
+
+// render renders the interface, its marker methods and its decoder
+func (u union) render(b *bytes.Buffer) {
+	members := make([]string, len(u.Members))
+	for i, m := range u.Members {
+		members[i] = "*" + m
+	}
+	marker := "is" + u.Name
+
+	fmt.Fprintf(b, "\n// %s is one of %s\n", u.Name, strings.Join(members, " or "))
+	fmt.Fprintf(b, "type %s interface {\n\t%s()\n}\n\n", u.Name, marker)
+	for _, m := range u.Members {
+		fmt.Fprintf(b, "func (*%s) %s() {}\n", m, marker)
+	}
+
+	quoted := make([]string, len(u.Keys))
+	for i, k := range u.Keys {
+		quoted[i] = fmt.Sprintf("%q", k)
+	}
+	fmt.Fprintf(b, "\n// %s decodes %s from JSON, choosing *%s when any of %s is present\n", u.decodeFunc(), u.Name, u.Other, strings.Join(u.Keys, ", "))
+	fmt.Fprintf(b, "func %s(data []byte) (%s, error) {\n", u.decodeFunc(), u.Name)
+	fmt.Fprintf(b, "\tvar fields map[string]json.RawMessage\n")
+	fmt.Fprintf(b, "\tif err := json.Unmarshal(data, &fields); err != nil {\n\t\treturn nil, fmt.Errorf(\"failed to decode %s: %%w\", err)\n\t}\n", u.Name)
+	fmt.Fprintf(b, "\tvar v %s = &%s{}\n", u.Name, u.Default)
+	fmt.Fprintf(b, "\tfor _, key := range []string{%s} {\n", strings.Join(quoted, ", "))
+	fmt.Fprintf(b, "\t\tif _, ok := fields[key]; ok {\n\t\t\tv = &%s{}\n\t\t\tbreak\n\t\t}\n\t}\n", u.Other)
+	fmt.Fprintf(b, "\tif err := json.Unmarshal(data, v); err != nil {\n\t\treturn nil, fmt.Errorf(\"failed to decode %s: %%w\", err)\n\t}\n", u.Name)
+	b.WriteString("\treturn v, nil\n}\n")
+}
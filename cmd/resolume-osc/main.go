@@ -0,0 +1,108 @@
+// Command resolume-osc listens for OSC messages on UDP and translates them
+// into Resolume REST API calls.
+//
+//	resolume-osc -listen 127.0.0.1:7001 -host localhost -port 8080 -routes routes.yaml
+//
+// It listens on the loopback interface by default. To take messages from
+// controllers on the network, listen on their interface and list them with
+// -allow, e.g. -listen :7001 -allow 192.168.1.0/24,10.0.0.5. Errors are only
+// sent back for messages matching a route, unless -reply-unmatched is set.
+//
+// The routes file maps address patterns to actions of the osc package, in
+// addition to and overriding the default routes:
+//
+//	/fader/*: set_parameter
+//	/go/*: connect_column
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/netip"
+	"os"
+	"os/signal"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/FlowingSPDG/resolume-go"
+	"github.com/FlowingSPDG/resolume-go/osc"
+)
+
+func main() {
+	listen := flag.String("listen", "127.0.0.1:7001", "UDP address to listen on")
+	allow := flag.String("allow", "", "comma-separated IP addresses or prefixes of the senders to serve, all by default")
+	replyUnmatched := flag.Bool("reply-unmatched", false, "send errors back for messages that match no route or cannot be decoded")
+	host := flag.String("host", "localhost", "Resolume web server host")
+	port := flag.String("port", "8080", "Resolume web server port")
+	routesPath := flag.String("routes", "", "YAML file mapping OSC address patterns to actions")
+	flag.Parse()
+
+	client, err := resolume.NewClient(*host, *port)
+	if err != nil {
+		log.Fatal(err)
+	}
+	allowed, err := parseAllowed(*allow)
+	if err != nil {
+		log.Fatal(err)
+	}
+	server := osc.NewServer(client,
+		osc.WithErrorHandler(func(m osc.Message, err error) {
+			log.Printf("%s: %v", m.Address, err)
+		}),
+		osc.WithAllowedSenders(allowed...),
+		osc.WithUnmatchedReplies(*replyUnmatched),
+	)
+	if *routesPath != "" {
+		routes, err := loadRoutes(*routesPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := server.Routes(routes); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	log.Printf("listening for OSC on %s", *listen)
+	if err := server.ListenAndServe(ctx, *listen); err != nil && err != context.Canceled {
+		log.Fatal(err)
+	}
+}
+
+// loadRoutes reads a YAML map of address patterns to action names
+func loadRoutes(name string) (map[string]string, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var routes map[string]string
+	if err := yaml.Unmarshal(data, &routes); err != nil {
+		return nil, err
+	}
+	return routes, nil
+}
+
+// parseAllowed parses a comma-separated list of IP addresses and prefixes
+func parseAllowed(s string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if addr, err := netip.ParseAddr(field); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -allow entry: %s", field)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
@@ -0,0 +1,79 @@
+// Command resolume-proxy serves the simplified HTTP/JSON API of the server
+// package in front of Resolume.
+//
+//	resolume-proxy -listen :8090 -host localhost -port 8080 -origins https://app.example.com
+//
+// API keys are read from the RESOLUME_PROXY_API_KEYS environment variable, a
+// comma-separated list, so they do not show up in the process list.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/FlowingSPDG/resolume-go"
+	"github.com/FlowingSPDG/resolume-go/server"
+)
+
+func main() {
+	listen := flag.String("listen", ":8090", "address to serve the API on")
+	host := flag.String("host", "localhost", "Resolume web server host")
+	port := flag.String("port", "8080", "Resolume web server port")
+	origins := flag.String("origins", "", "comma-separated origins allowed by CORS, or * for any")
+	actions := flag.String("actions", "", "comma-separated actions to allow, all by default")
+	cache := flag.Duration("cache", time.Second, "how long to cache the composition")
+	flag.Parse()
+
+	client, err := resolume.NewClient(*host, *port)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	opts := []server.Option{server.WithCacheTTL(*cache)}
+	if *origins != "" {
+		opts = append(opts, server.WithAllowedOrigins(split(*origins)...))
+	}
+	if keys := os.Getenv("RESOLUME_PROXY_API_KEYS"); keys != "" {
+		opts = append(opts, server.WithAPIKeys(split(keys)...))
+	} else {
+		log.Print("RESOLUME_PROXY_API_KEYS is not set, the API is open to anyone who can reach it")
+	}
+	if *actions != "" {
+		var allowed []server.Action
+		for _, a := range split(*actions) {
+			if !known(server.Action(a)) {
+				log.Fatalf("unknown action: %s", a)
+			}
+			allowed = append(allowed, server.Action(a))
+		}
+		opts = append(opts, server.WithActions(allowed...))
+	}
+
+	log.Printf("serving the Resolume API on %s", *listen)
+	log.Fatal(http.ListenAndServe(*listen, server.New(client, opts...)))
+}
+
+// split splits a comma-separated list, ignoring spaces and empty items
+func split(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// known reports whether an action exists
+func known(action server.Action) bool {
+	for _, a := range server.AllActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+)
+
+// product shows the product and version of Resolume
+func product(ctx context.Context, c *cli, args []string) error {
+	if _, err := parseArgs(flag.NewFlagSet("product", flag.ContinueOnError), args, 0, false); err != nil {
+		return err
+	}
+	info, err := c.client.GetProductContext(ctx)
+	if err != nil {
+		return err
+	}
+	version := fmt.Sprintf("%d.%d.%d", info.Major, info.Minor, info.Micro)
+	return c.output(info, []string{"NAME", "VERSION", "REVISION"},
+		[][]string{{info.Name, version, fmt.Sprint(info.Revision)}})
+}
+
+// compositionDump writes the whole composition as JSON, whatever the output
+// format, for backups and diffs
+func compositionDump(ctx context.Context, c *cli, args []string) error {
+	if _, err := parseArgs(flag.NewFlagSet("composition dump", flag.ContinueOnError), args, 0, false); err != nil {
+		return err
+	}
+	composition, err := c.client.GetCompositionContext(ctx)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(c.stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(composition)
+}
+
+// undo undoes the last change to the composition
+func undo(ctx context.Context, c *cli, args []string) error {
+	return compositionAction(ctx, c, "undo", args)
+}
+
+// redo redoes the last undone change to the composition
+func redo(ctx context.Context, c *cli, args []string) error {
+	return compositionAction(ctx, c, "redo", args)
+}
+
+func compositionAction(ctx context.Context, c *cli, action string, args []string) error {
+	if _, err := parseArgs(flag.NewFlagSet(action, flag.ContinueOnError), args, 0, false); err != nil {
+		return err
+	}
+	return c.client.CompositionActionContext(ctx, action)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/FlowingSPDG/resolume-go/contactsheet"
+)
+
+// contactSheet renders the clip grid of the open deck to a file, or to the
+// standard output with -o -
+func contactSheet(ctx context.Context, c *cli, args []string) error {
+	flags := flag.NewFlagSet("contactsheet", flag.ContinueOnError)
+	output := flags.String("o", "contactsheet.png", "output file, - for the standard output")
+	format := flags.String("format", "", "png or jpeg, by default from the output file extension")
+	size := flags.String("size", "160x90", "size of the thumbnails")
+	if _, err := parseArgs(flags, args, 0, false); err != nil {
+		return err
+	}
+
+	var width, height int
+	if _, err := fmt.Sscanf(*size, "%dx%d", &width, &height); err != nil || width <= 0 || height <= 0 {
+		return usageError("invalid size: " + *size)
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(*output), ".")
+		if *output == "-" || *format == "" {
+			*format = "png"
+		}
+	}
+	switch strings.ToLower(*format) {
+	case "png", "jpeg", "jpg":
+	default:
+		return usageError("unknown image format: " + *format)
+	}
+
+	img, err := contactsheet.Render(ctx, c.client, contactsheet.WithThumbnailSize(width, height))
+	if err != nil {
+		return err
+	}
+	w := c.stdout
+	if *output != "-" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := contactsheet.Encode(w, img, *format); err != nil {
+		return err
+	}
+	if f, ok := w.(*os.File); ok && *output != "-" {
+		return f.Close()
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strconv"
+	"strings"
+
+	"github.com/FlowingSPDG/resolume-go/uri"
+)
+
+// effectList lists the video effects available in Resolume
+func effectList(ctx context.Context, c *cli, args []string) error {
+	if _, err := parseArgs(flag.NewFlagSet("effect list", flag.ContinueOnError), args, 0, false); err != nil {
+		return err
+	}
+	effects, err := c.client.GetEffectsContext(ctx)
+	if err != nil {
+		return err
+	}
+	var rows [][]string
+	for _, effect := range effects.Video {
+		rows = append(rows, []string{effect.Name, effect.IDString, strconv.Itoa(len(effect.Presets))})
+	}
+	return c.output(effects, []string{"NAME", "IDSTRING", "PRESETS"}, rows)
+}
+
+// effectAdd adds a video effect, given by URI or by name, to the composition
+// or to a layer
+func effectAdd(ctx context.Context, c *cli, args []string) error {
+	flags := flag.NewFlagSet("effect add", flag.ContinueOnError)
+	layer := flags.Int64("layer", 0, "index of the layer to add the effect to, the composition by default")
+	args, err := parseArgs(flags, args, 1, false)
+	if err != nil {
+		return err
+	}
+	effect := args[0]
+	if !strings.Contains(effect, "://") {
+		effect = uri.EffectURI(effect, "").String()
+	}
+	if *layer != 0 {
+		return c.client.AddEffectToLayerContext(ctx, *layer, effect)
+	}
+	return c.client.AddEffectContext(ctx, effect)
+}
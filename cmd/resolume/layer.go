@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/FlowingSPDG/resolume-go"
+	"github.com/FlowingSPDG/resolume-go/uri"
+)
+
+// layerGet shows the parameters of a layer, without its clips, by path so
+// they can be passed to param get and set
+func layerGet(ctx context.Context, c *cli, args []string) error {
+	args, err := parseArgs(flag.NewFlagSet("layer get", flag.ContinueOnError), args, 1, false)
+	if err != nil {
+		return err
+	}
+	index, err := parseIndex(args[0], "layer")
+	if err != nil {
+		return err
+	}
+	layer, err := c.client.GetLayerContext(ctx, index)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	layerParameters(layer, func(path string, p resolume.Parameter) {
+		rows = append(rows, parameterRow(string(resolume.LayerPath(index).Child(path)), p))
+	})
+	return c.output(layer, []string{"PATH", "ID", "TYPE", "VALUE"}, rows)
+}
+
+// layerSet sets parameters of a layer given as path=value, the path being
+// relative to the layer such as "name", "bypassed" or "video/opacity". The
+// values are parsed by the type of the parameters, as with param set.
+func layerSet(ctx context.Context, c *cli, args []string) error {
+	args, err := parseArgs(flag.NewFlagSet("layer set", flag.ContinueOnError), args, 2, true)
+	if err != nil {
+		return err
+	}
+	index, err := parseIndex(args[0], "layer")
+	if err != nil {
+		return err
+	}
+	layer, err := c.client.GetLayerContext(ctx, index)
+	if err != nil {
+		return err
+	}
+	params := map[string]resolume.Parameter{}
+	layerParameters(layer, func(path string, p resolume.Parameter) {
+		params[path] = p
+	})
+
+	patch := resolume.PatchLayer()
+	for _, arg := range args[1:] {
+		path, s, ok := strings.Cut(arg, "=")
+		if !ok || path == "" {
+			return usageError("expected path=value, got " + arg)
+		}
+		p, ok := params[strings.Trim(path, "/")]
+		if !ok {
+			return usageError("unknown parameter: " + path)
+		}
+		value, err := typedValue(p, s)
+		if err != nil {
+			return err
+		}
+		patch = patch.Set(path, value)
+	}
+	return c.client.UpdateLayerContext(ctx, index, patch)
+}
+
+// layerClear disconnects the clips of a layer, or with -clips empties them
+func layerClear(ctx context.Context, c *cli, args []string) error {
+	flags := flag.NewFlagSet("layer clear", flag.ContinueOnError)
+	clips := flags.Bool("clips", false, "empty the clips instead of disconnecting them")
+	args, err := parseArgs(flags, args, 1, false)
+	if err != nil {
+		return err
+	}
+	index, err := parseIndex(args[0], "layer")
+	if err != nil {
+		return err
+	}
+	if *clips {
+		return c.client.ClearLayerClipsContext(ctx, index)
+	}
+	return c.client.ClearLayerContext(ctx, index)
+}
+
+// clipConnect connects a clip
+func clipConnect(ctx context.Context, c *cli, args []string) error {
+	layer, clip, _, err := clipArgs("clip connect", args, 0)
+	if err != nil {
+		return err
+	}
+	return c.client.ConnectClipContext(ctx, layer, clip, nil)
+}
+
+// clipOpen opens a file or source into a clip. A path without a scheme is a
+// file on the machine running Resolume.
+func clipOpen(ctx context.Context, c *cli, args []string) error {
+	layer, clip, rest, err := clipArgs("clip open", args, 1)
+	if err != nil {
+		return err
+	}
+	media := rest[0]
+	if !strings.Contains(media, "://") {
+		media = uri.FileURI(media).String()
+	}
+	return c.client.OpenClipContext(ctx, layer, clip, media)
+}
+
+// clipClear empties a clip
+func clipClear(ctx context.Context, c *cli, args []string) error {
+	layer, clip, _, err := clipArgs("clip clear", args, 0)
+	if err != nil {
+		return err
+	}
+	return c.client.ClearClipContext(ctx, layer, clip)
+}
+
+// columnConnect connects a column
+func columnConnect(ctx context.Context, c *cli, args []string) error {
+	args, err := parseArgs(flag.NewFlagSet("column connect", flag.ContinueOnError), args, 1, false)
+	if err != nil {
+		return err
+	}
+	index, err := parseIndex(args[0], "column")
+	if err != nil {
+		return err
+	}
+	return c.client.ConnectColumnContext(ctx, index, nil)
+}
+
+// clipArgs parses the layer and clip indexes of a clip command followed by
+// n more arguments
+func clipArgs(name string, args []string, n int) (layer, clip int64, rest []string, err error) {
+	args, err = parseArgs(flag.NewFlagSet(name, flag.ContinueOnError), args, 2+n, false)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	if layer, err = parseIndex(args[0], "layer"); err != nil {
+		return 0, 0, nil, err
+	}
+	if clip, err = parseIndex(args[1], "clip"); err != nil {
+		return 0, 0, nil, err
+	}
+	return layer, clip, args[2:], nil
+}
+
+// parseIndex parses a 1-based index
+func parseIndex(s, what string) (int64, error) {
+	index, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || index < 1 {
+		return 0, usageError(fmt.Sprintf("invalid %s index: %s", what, s))
+	}
+	return index, nil
+}
+
+// layerParameters calls fn with the parameters of a layer, without its clips,
+// and their paths relative to the layer such as "video/opacity"
+func layerParameters(layer *resolume.Layer, fn func(path string, p resolume.Parameter)) {
+	composition := &resolume.Composition{Layers: []resolume.Layer{*layer}}
+	prefix := string(resolume.LayerPath(1)) + "/"
+	composition.Walk(func(path resolume.Path, node interface{}) error {
+		switch node := node.(type) {
+		case *resolume.Clip:
+			return resolume.SkipChildren
+		case resolume.Parameter:
+			fn(strings.TrimPrefix(string(path), prefix), node)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,235 @@
+// Command resolume controls Resolume from the shell.
+//
+//	resolume [-host localhost] [-port 8080] [-json] <command> [arguments]
+//
+// Commands:
+//
+//	product                                  show the product and version
+//	composition dump                         write the composition as JSON
+//	layer get <layer>                        show a layer
+//	layer set <layer> <path>=<value>...      set parameters of a layer, e.g. video/opacity=0.5
+//	layer clear [-clips] <layer>             disconnect the clips of a layer, or empty them
+//	clip connect <layer> <clip>              connect a clip
+//	clip open <layer> <clip> <uri or path>   open a file or source into a clip
+//	clip clear <layer> <clip>                empty a clip
+//	column connect <column>                  connect a column
+//	param get <id or path>                   show a parameter
+//	param set <id or path> <value>           set the value of a parameter
+//	param reset [-animation] <id or path>    reset a parameter to its default
+//	effect list                              list the video effects
+//	effect add [-layer n] <uri or name>      add a video effect to the composition or a layer
+//	thumbnail get [-o file] <layer> <clip>   save the thumbnail of a clip
+//	thumbnail set [-resize] <layer> <clip> <file or uri>   set the thumbnail of a clip
+//	undo, redo                               undo or redo the last change
+//	contactsheet [-o sheet.png] [-size 160x90]   render the clip grid as an image
+//
+// Parameter paths are relative to the composition, as taken by
+// resolume.Resolver, e.g. "layers/2/video/opacity".
+//
+// Results are printed as tables, or as JSON with -json. The exit status is 0
+// on success, 2 for usage errors, 3 when Resolume answers 404 Not Found, 4 for
+// 400 Bad Request such as an invalid URI, 5 for 412 Precondition Failed, 6
+// when Resolume cannot be reached and 1 for other errors.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/FlowingSPDG/resolume-go"
+)
+
+// Exit statuses
+const (
+	exitError              = 1
+	exitUsage              = 2
+	exitNotFound           = 3
+	exitBadRequest         = 4
+	exitPreconditionFailed = 5
+	exitUnreachable        = 6
+)
+
+// cli is what commands run with
+type cli struct {
+	client *resolume.Client
+	stdout io.Writer
+	json   bool
+}
+
+// command is a subcommand of the tool, named by one or two words such as "layer get"
+type command struct {
+	usage string
+	run   func(ctx context.Context, c *cli, args []string) error
+}
+
+var commands = map[string]command{
+	"product":          {"", product},
+	"composition dump": {"", compositionDump},
+	"layer get":        {"<layer>", layerGet},
+	"layer set":        {"<layer> <path>=<value>...", layerSet},
+	"layer clear":      {"[-clips] <layer>", layerClear},
+	"clip connect":     {"<layer> <clip>", clipConnect},
+	"clip open":        {"<layer> <clip> <uri or path>", clipOpen},
+	"clip clear":       {"<layer> <clip>", clipClear},
+	"column connect":   {"<column>", columnConnect},
+	"param get":        {"<id or path>", paramGet},
+	"param set":        {"<id or path> <value>", paramSet},
+	"param reset":      {"[-animation] <id or path>", paramReset},
+	"effect list":      {"", effectList},
+	"effect add":       {"[-layer n] <uri or name>", effectAdd},
+	"thumbnail get":    {"[-o file] <layer> <clip>", thumbnailGet},
+	"thumbnail set":    {"[-resize] <layer> <clip> <file or uri>", thumbnailSet},
+	"undo":             {"", undo},
+	"redo":             {"", redo},
+	"contactsheet":     {"[-o sheet.png] [-format png|jpeg] [-size 160x90]", contactSheet},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run runs the tool with the arguments following the program name and
+// returns the exit status
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("resolume", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	host := flags.String("host", "localhost", "Resolume web server host")
+	port := flags.String("port", "8080", "Resolume web server port")
+	jsonOutput := flags.Bool("json", false, "print results as JSON instead of tables")
+	flags.Usage = func() { usage(flags) }
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return exitUsage
+	}
+
+	name, cmd, ok := lookup(flags.Args())
+	if !ok {
+		usage(flags)
+		return exitUsage
+	}
+	client, err := resolume.NewClient(*host, *port)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	c := &cli{client: client, stdout: stdout, json: *jsonOutput}
+	cmdArgs := flags.Args()[len(strings.Fields(name)):]
+	if err := cmd.run(ctx, c, cmdArgs); err != nil {
+		var usageErr usageError
+		if errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(stderr, "usage: resolume %s\n", strings.TrimSpace(name+" "+cmd.usage))
+			return 0
+		}
+		fmt.Fprintf(stderr, "resolume %s: %v\n", name, err)
+		if errors.As(err, &usageErr) {
+			fmt.Fprintf(stderr, "usage: resolume %s\n", strings.TrimSpace(name+" "+cmd.usage))
+		}
+		return exitCode(err)
+	}
+	return 0
+}
+
+// lookup finds the command named by the first one or two arguments
+func lookup(args []string) (string, command, bool) {
+	if len(args) >= 2 {
+		if cmd, ok := commands[args[0]+" "+args[1]]; ok {
+			return args[0] + " " + args[1], cmd, true
+		}
+	}
+	if len(args) >= 1 {
+		if cmd, ok := commands[args[0]]; ok {
+			return args[0], cmd, true
+		}
+	}
+	return "", command{}, false
+}
+
+// exitCode maps an error to the exit status of the tool
+func exitCode(err error) int {
+	var usageErr usageError
+	var apiErr *resolume.APIError
+	var urlErr *url.Error
+	switch {
+	case errors.As(err, &usageErr):
+		return exitUsage
+	case errors.Is(err, resolume.ErrNotFound):
+		return exitNotFound
+	case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest:
+		return exitBadRequest
+	case errors.Is(err, resolume.ErrPreconditionFailed):
+		return exitPreconditionFailed
+	case errors.As(err, &urlErr) && !errors.Is(err, context.Canceled):
+		return exitUnreachable
+	}
+	return exitError
+}
+
+// usageError is an error in the arguments of a command
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+// parseArgs parses the flags of a command and checks it got n arguments, or
+// at least n if variadic
+func parseArgs(flags *flag.FlagSet, args []string, n int, variadic bool) ([]string, error) {
+	flags.SetOutput(io.Discard)
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, err
+		}
+		return nil, usageError(err.Error())
+	}
+	if got := flags.NArg(); got < n || (!variadic && got > n) {
+		return nil, usageError(fmt.Sprintf("expected %d arguments, got %d", n, got))
+	}
+	return flags.Args(), nil
+}
+
+// output prints v as JSON with -json, and else as a table with a header
+func (c *cli) output(v interface{}, header []string, rows [][]string) error {
+	if c.json {
+		encoder := json.NewEncoder(c.stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+	w := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+func usage(flags *flag.FlagSet) {
+	out := flags.Output()
+	fmt.Fprintf(out, "usage: resolume [-host host] [-port port] [-json] <command> [arguments]\n\nCommands:\n")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(out, "  %s\n", strings.TrimSpace(name+" "+commands[name].usage))
+	}
+	fmt.Fprintf(out, "\nFlags:\n")
+	flags.PrintDefaults()
+}
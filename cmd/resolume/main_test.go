@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/FlowingSPDG/resolume-go"
+	"github.com/FlowingSPDG/resolume-go/resolumetest"
+)
+
+// resolumeCmd runs the tool against a server and returns its exit status and output
+func resolumeCmd(t *testing.T, server *resolumetest.Server, args ...string) (int, string) {
+	t.Helper()
+	u, _ := url.Parse(server.URL)
+	host, port, _ := net.SplitHostPort(u.Host)
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), append([]string{"-host", host, "-port", port}, args...), &stdout, &stderr)
+	if code != 0 {
+		t.Logf("resolume %s: %s", strings.Join(args, " "), stderr.String())
+	}
+	return code, stdout.String()
+}
+
+func TestLayerAndParam(t *testing.T) {
+	server := resolumetest.NewServer(resolumetest.WithSize(2, 3))
+	defer server.Close()
+
+	if code, _ := resolumeCmd(t, server, "layer", "set", "1", "name=Intro", "video/opacity=0.5", "bypassed=true"); code != 0 {
+		t.Fatalf("layer set exit status = %d", code)
+	}
+	layer := server.Composition().Layer(1)
+	if layer.Name.Value != "Intro" || layer.Video.Opacity.Value != 0.5 || !layer.Bypassed.Value {
+		t.Errorf("Layer after layer set = %s, %v, %v", layer.Name.Value, layer.Video.Opacity.Value, layer.Bypassed.Value)
+	}
+
+	// A number is sent as a string to a string parameter
+	if code, _ := resolumeCmd(t, server, "layer", "set", "2", "name=2024"); code != 0 {
+		t.Fatalf("layer set exit status = %d", code)
+	}
+	if got := server.Composition().Layer(2).Name.Value; got != "2024" {
+		t.Errorf("Name after layer set = %q, want 2024", got)
+	}
+	for _, arg := range []string{"video/opacity=loud", "volume/level=1"} {
+		if code, _ := resolumeCmd(t, server, "layer", "set", "1", arg); code != exitUsage {
+			t.Errorf("layer set %s exit status = %d, want %d", arg, code, exitUsage)
+		}
+	}
+
+	_, out := resolumeCmd(t, server, "layer", "get", "1")
+	if !strings.Contains(out, "/composition/layers/1/video/opacity") || !strings.Contains(out, "Intro") {
+		t.Errorf("layer get printed %q", out)
+	}
+	if _, out := resolumeCmd(t, server, "layer", "get", "2"); !strings.Contains(out, "/composition/layers/2/video/opacity") {
+		t.Errorf("layer get 2 printed %q", out)
+	}
+	// The index of a missing layer is only sent to the server
+	if code, _ := resolumeCmd(t, server, "layer", "set", "2000000000", "name=Outro"); code == 0 || code == exitUsage {
+		t.Errorf("layer set of a missing layer exit status = %d", code)
+	}
+
+	if code, _ := resolumeCmd(t, server, "param", "set", "layers/1/video/opacity", "0.25"); code != 0 {
+		t.Fatalf("param set exit status = %d", code)
+	}
+	id := server.Composition().Layer(1).Video.Opacity.ID
+	_, out = resolumeCmd(t, server, "-json", "param", "get", itoa(id))
+	var p resolume.RangeParameter
+	if err := json.Unmarshal([]byte(out), &p); err != nil || p.Value != 0.25 {
+		t.Errorf("param get -json = %q, %v", out, err)
+	}
+	if code, _ := resolumeCmd(t, server, "param", "set", itoa(id), "loud"); code != exitUsage {
+		t.Errorf("param set of an invalid value exit status = %d, want %d", code, exitUsage)
+	}
+
+	if code, _ := resolumeCmd(t, server, "undo"); code != 0 {
+		t.Fatalf("undo exit status = %d", code)
+	}
+	if got := server.Composition().Layer(1).Video.Opacity.Value; got != 0.5 {
+		t.Errorf("Opacity after undo = %v, want 0.5", got)
+	}
+}
+
+func TestClipsAndThumbnails(t *testing.T) {
+	server := resolumetest.NewServer(resolumetest.WithSize(2, 3))
+	defer server.Close()
+
+	for _, args := range [][]string{
+		{"clip", "open", "1", "2", "/Users/Resolume/intro.mov"},
+		{"clip", "connect", "1", "2"},
+		{"column", "connect", "3"},
+	} {
+		if code, _ := resolumeCmd(t, server, args...); code != 0 {
+			t.Fatalf("%v exit status = %d", args, code)
+		}
+	}
+	composition := server.Composition()
+	if clip := composition.Clip(1, 2); clip.Name.Value != "intro" || clip.Connected.Value != "Disconnected" {
+		t.Errorf("Clip 2 of layer 1 = %s, %s, want intro disconnected by the column", clip.Name.Value, clip.Connected.Value)
+	}
+	if got := composition.Column(3).Connected.Value; got != "Connected" {
+		t.Errorf("Column 3 = %s, want Connected", got)
+	}
+
+	file := filepath.Join(t.TempDir(), "thumbnail.png")
+	os.WriteFile(file, []byte("png data"), 0o644)
+	if code, _ := resolumeCmd(t, server, "thumbnail", "set", "1", "2", file); code != 0 {
+		t.Fatalf("thumbnail set exit status = %d", code)
+	}
+	if _, out := resolumeCmd(t, server, "thumbnail", "get", "-o", "-", "1", "2"); out != "png data" {
+		t.Errorf("thumbnail get = %q", out)
+	}
+
+	if code, _ := resolumeCmd(t, server, "clip", "clear", "1", "2"); code != 0 {
+		t.Fatalf("clip clear exit status = %d", code)
+	}
+	if got := server.Composition().Clip(1, 2).Connected.Value; got != "Empty" {
+		t.Errorf("Clip after clip clear = %s, want Empty", got)
+	}
+}
+
+func TestOutput(t *testing.T) {
+	server := resolumetest.NewServer(resolumetest.WithEffects(resolume.Effects{
+		Video: []resolume.Effect{{IDString: "A101", Name: "Blur"}},
+	}))
+	defer server.Close()
+
+	_, out := resolumeCmd(t, server, "effect", "list")
+	if lines := strings.Split(strings.TrimSpace(out), "\n"); len(lines) != 2 || !strings.HasPrefix(lines[0], "NAME") || !strings.Contains(lines[1], "A101") {
+		t.Errorf("effect list = %q, want a table", out)
+	}
+	_, out = resolumeCmd(t, server, "-json", "product")
+	var product resolume.ProductInfo
+	if err := json.Unmarshal([]byte(out), &product); err != nil || product.Name == "" {
+		t.Errorf("product -json = %q, %v", out, err)
+	}
+	_, out = resolumeCmd(t, server, "composition", "dump")
+	var composition resolume.Composition
+	if err := json.Unmarshal([]byte(out), &composition); err != nil || len(composition.Layers) != 3 {
+		t.Errorf("composition dump = %v layers, %v", len(composition.Layers), err)
+	}
+}
+
+func TestExitCodes(t *testing.T) {
+	server := resolumetest.NewServer()
+	defer server.Close()
+
+	tests := []struct {
+		name  string
+		setup func()
+		args  []string
+		want  int
+	}{
+		{"unknown command", nil, []string{"layer", "remove", "1"}, exitUsage},
+		{"invalid index", nil, []string{"layer", "get", "first"}, exitUsage},
+		{"missing argument", nil, []string{"clip", "connect", "1"}, exitUsage},
+		{"not found", nil, []string{"layer", "get", "9"}, exitNotFound},
+		{"invalid URI", nil, []string{"clip", "open", "1", "1", "bogus://x"}, exitBadRequest},
+		{"precondition failed", func() { server.FailNext(http.StatusPreconditionFailed, "locked") }, []string{"clip", "clear", "1", "1"}, exitPreconditionFailed},
+		{"server error", func() { server.FailNext(http.StatusInternalServerError, "") }, []string{"redo"}, exitError},
+	}
+	for _, tt := range tests {
+		if tt.setup != nil {
+			tt.setup()
+		}
+		if code, _ := resolumeCmd(t, server, tt.args...); code != tt.want {
+			t.Errorf("%s: exit status = %d, want %d", tt.name, code, tt.want)
+		}
+	}
+
+	sheet := filepath.Join(t.TempDir(), "sheet.gif")
+	if code, _ := resolumeCmd(t, server, "contactsheet", "-o", sheet); code != exitUsage {
+		t.Errorf("contactsheet with an unknown format exit status = %d, want %d", code, exitUsage)
+	}
+	if _, err := os.Stat(sheet); err == nil {
+		t.Error("contactsheet with an unknown format created the output file")
+	}
+
+	closed := resolumetest.NewServer()
+	closed.Close()
+	if code, _ := resolumeCmd(t, closed, "product"); code != exitUnreachable {
+		t.Errorf("Unreachable server exit status = %d, want %d", code, exitUnreachable)
+	}
+}
+
+func itoa(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
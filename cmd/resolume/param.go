@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/FlowingSPDG/resolume-go"
+)
+
+// paramGet shows a parameter by id or path
+func paramGet(ctx context.Context, c *cli, args []string) error {
+	args, err := parseArgs(flag.NewFlagSet("param get", flag.ContinueOnError), args, 1, false)
+	if err != nil {
+		return err
+	}
+	p, err := findParameter(ctx, c, args[0])
+	if err != nil {
+		return err
+	}
+	return c.output(p, []string{"PARAMETER", "ID", "TYPE", "VALUE"}, [][]string{parameterRow(args[0], p)})
+}
+
+// paramSet sets the value of a parameter by id or path, parsed according to
+// the type of the parameter
+func paramSet(ctx context.Context, c *cli, args []string) error {
+	args, err := parseArgs(flag.NewFlagSet("param set", flag.ContinueOnError), args, 2, false)
+	if err != nil {
+		return err
+	}
+	p, err := findParameter(ctx, c, args[0])
+	if err != nil {
+		return err
+	}
+	value, err := typedValue(p, args[1])
+	if err != nil {
+		return err
+	}
+	return c.client.SetParameterByIDContext(ctx, p.ParameterID(), map[string]interface{}{"value": value})
+}
+
+// paramReset resets a parameter by id or path to its default value
+func paramReset(ctx context.Context, c *cli, args []string) error {
+	flags := flag.NewFlagSet("param reset", flag.ContinueOnError)
+	animation := flags.Bool("animation", false, "also reset the animation of the parameter")
+	args, err := parseArgs(flags, args, 1, false)
+	if err != nil {
+		return err
+	}
+	id, err := parameterID(ctx, c, args[0])
+	if err != nil {
+		return err
+	}
+	return c.client.ResetParameterByIDContext(ctx, id, *animation)
+}
+
+// findParameter retrieves a parameter by id, or by path in the composition
+// such as "layers/2/video/opacity"
+func findParameter(ctx context.Context, c *cli, arg string) (resolume.Parameter, error) {
+	if id, err := strconv.ParseInt(arg, 10, 64); err == nil {
+		return c.client.GetParameterContext(ctx, id)
+	}
+	composition, err := c.client.GetCompositionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resolume.NewResolver(c.client, composition).GetByPath(ctx, arg)
+}
+
+// parameterID returns the id of a parameter given by id or path
+func parameterID(ctx context.Context, c *cli, arg string) (int64, error) {
+	if id, err := strconv.ParseInt(arg, 10, 64); err == nil {
+		return id, nil
+	}
+	composition, err := c.client.GetCompositionContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	p, _, err := resolume.NewResolver(c.client, composition).Lookup(arg)
+	if err != nil {
+		return 0, err
+	}
+	return p.ParameterID(), nil
+}
+
+// typedValue parses a value for a parameter: a number for range and integer
+// parameters, a bool for boolean and event parameters and a string for the others
+func typedValue(p resolume.Parameter, s string) (interface{}, error) {
+	var value interface{}
+	var err error
+	switch p.(type) {
+	case *resolume.RangeParameter:
+		value, err = strconv.ParseFloat(s, 64)
+	case *resolume.IntegerParameter:
+		value, err = strconv.ParseInt(s, 10, 64)
+	case *resolume.BooleanParameter, *resolume.EventParameter:
+		value, err = strconv.ParseBool(s)
+	default:
+		value = s
+	}
+	if err != nil {
+		return nil, usageError(fmt.Sprintf("invalid value for a %s: %s", p.ParameterType(), s))
+	}
+	return value, nil
+}
+
+// parameterRow is the table row of a parameter
+func parameterRow(name string, p resolume.Parameter) []string {
+	value, ok := resolume.ParameterValue(p)
+	if !ok {
+		value = ""
+	}
+	return []string{name, strconv.FormatInt(p.ParameterID(), 10), p.ParameterType(), fmt.Sprint(value)}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"os"
+	"strings"
+)
+
+// thumbnailGet saves the thumbnail of a clip to a file, or to the standard
+// output with -o -
+func thumbnailGet(ctx context.Context, c *cli, args []string) error {
+	flags := flag.NewFlagSet("thumbnail get", flag.ContinueOnError)
+	output := flags.String("o", "thumbnail.png", "output file, - for the standard output")
+	layer, clip, err := thumbnailArgs(flags, args, 0)
+	if err != nil {
+		return err
+	}
+	thumbnail, err := c.client.GetClipThumbnailContext(ctx, layer, clip)
+	if err != nil {
+		return err
+	}
+	defer thumbnail.Close()
+
+	if *output == "-" {
+		_, err = io.Copy(c.stdout, thumbnail)
+		return err
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, thumbnail); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// thumbnailSet sets the thumbnail of a clip to a local image file, streamed
+// to Resolume, or to a file URI on the machine running Resolume. With
+// -resize the image is scaled to the thumbnail size first.
+func thumbnailSet(ctx context.Context, c *cli, args []string) error {
+	flags := flag.NewFlagSet("thumbnail set", flag.ContinueOnError)
+	resize := flags.Bool("resize", false, "scale the image to the thumbnail size")
+	layer, clip, err := thumbnailArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	file := flags.Arg(2)
+	if strings.Contains(file, "://") {
+		if *resize {
+			return usageError("-resize needs a local file")
+		}
+		return c.client.SetClipThumbnailFromFileContext(ctx, layer, clip, file)
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if !*resize {
+		return c.client.SetClipThumbnailContext(ctx, layer, clip, f)
+	}
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return err
+	}
+	return c.client.SetClipThumbnailImageContext(ctx, layer, clip, img, true)
+}
+
+// thumbnailArgs parses the layer and clip indexes of a thumbnail command
+// followed by n more arguments
+func thumbnailArgs(flags *flag.FlagSet, args []string, n int) (layer, clip int, err error) {
+	args, err = parseArgs(flags, args, 2+n, false)
+	if err != nil {
+		return 0, 0, err
+	}
+	l, err := parseIndex(args[0], "layer")
+	if err != nil {
+		return 0, 0, err
+	}
+	c, err := parseIndex(args[1], "clip")
+	if err != nil {
+		return 0, 0, err
+	}
+	// The thumbnail methods take int indexes, which may be 32-bit
+	if int64(int(l)) != l {
+		return 0, 0, usageError("invalid layer index: " + args[0])
+	}
+	if int64(int(c)) != c {
+		return 0, 0, usageError("invalid clip index: " + args[1])
+	}
+	return int(l), int(c), nil
+}
@@ -0,0 +1,371 @@
+package resolume
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Path is the location of a node in the composition tree, made of the JSON
+// field names of the REST API and 1-based indexes, e.g.
+// "/composition/layers/2/clips/5/video/opacity". The WebSocket API uses the same
+// notation for parameter paths.
+type Path string
+
+// CompositionPath is the path of the composition itself
+const CompositionPath Path = "/composition"
+
+// LayerPath returns the path of a layer by 1-based index
+func LayerPath(index int64) Path {
+	return CompositionPath.Index("layers", index)
+}
+
+// ClipPath returns the path of the clip in a layer and column, both 1-based
+func ClipPath(layer, column int64) Path {
+	return LayerPath(layer).Index("clips", column)
+}
+
+// ColumnPath returns the path of a column by 1-based index
+func ColumnPath(index int64) Path {
+	return CompositionPath.Index("columns", index)
+}
+
+// DeckPath returns the path of a deck by 1-based index
+func DeckPath(index int64) Path {
+	return CompositionPath.Index("decks", index)
+}
+
+// LayerGroupPath returns the path of a layer group by 1-based index
+func LayerGroupPath(index int64) Path {
+	return CompositionPath.Index("layergroups", index)
+}
+
+// Child returns the path of a node below p, e.g. LayerPath(2).Child("video", "opacity")
+func (p Path) Child(elems ...string) Path {
+	return Path(strings.Join(append([]string{strings.TrimSuffix(string(p), "/")}, elems...), "/"))
+}
+
+// Index returns the path of the 1-based index-th element of a list below p
+func (p Path) Index(name string, index int64) Path {
+	return p.Child(name, strconv.FormatInt(index, 10))
+}
+
+// Segments returns the elements of the path, e.g. ["composition", "layers", "2"]
+func (p Path) Segments() []string {
+	s := strings.Trim(string(p), "/")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "/")
+}
+
+// Parent returns the path one level up, or the path itself at the root
+func (p Path) Parent() Path {
+	i := strings.LastIndex(strings.TrimSuffix(string(p), "/"), "/")
+	if i <= 0 {
+		return p
+	}
+	return p[:i]
+}
+
+func (p Path) String() string {
+	return string(p)
+}
+
+// entityLists are the lists whose elements have their own REST endpoint
+var entityLists = map[string]bool{
+	"layers":      true,
+	"clips":       true,
+	"columns":     true,
+	"decks":       true,
+	"layergroups": true,
+}
+
+// Endpoint returns the REST endpoint of the object containing the node, e.g.
+// "/composition/layers/2/clips/5" for "/composition/layers/2/clips/5/video/opacity".
+// Video effects map to their endpoint by offset, e.g. "/composition/layers/2/effects/video/1"
+// for "/composition/layers/2/video/effects/1/params/scale".
+func (p Path) Endpoint() string {
+	endpoint, _ := p.split()
+	return endpoint
+}
+
+// Parameter returns the part of the path below Endpoint, e.g. "video/opacity",
+// as taken by the parameter reset endpoints
+func (p Path) Parameter() string {
+	_, parameter := p.split()
+	return parameter
+}
+
+// split splits the path into the REST endpoint and the rest
+func (p Path) split() (string, string) {
+	segments := p.Segments()
+	if len(segments) == 0 || segments[0] != "composition" {
+		return "", strings.Join(segments, "/")
+	}
+	endpoint := []string{"", "composition"}
+	rest := segments[1:]
+	for {
+		switch {
+		case len(rest) >= 2 && entityLists[rest[0]] && isIndex(rest[1]):
+			endpoint = append(endpoint, rest[0], rest[1])
+			rest = rest[2:]
+			continue
+		case len(rest) >= 3 && rest[0] == "video" && rest[1] == "effects" && isIndex(rest[2]):
+			endpoint = append(endpoint, "effects", "video", rest[2])
+			rest = rest[3:]
+		}
+		return strings.Join(endpoint, "/"), strings.Join(rest, "/")
+	}
+}
+
+func isIndex(s string) bool {
+	n, err := strconv.ParseInt(s, 10, 64)
+	return err == nil && n > 0
+}
+
+// Layer returns the layer at a 1-based index, or nil
+func (c *Composition) Layer(index int64) *Layer {
+	if index < 1 || index > int64(len(c.Layers)) {
+		return nil
+	}
+	return &c.Layers[index-1]
+}
+
+// Column returns the column at a 1-based index, or nil
+func (c *Composition) Column(index int64) *Column {
+	if index < 1 || index > int64(len(c.Columns)) {
+		return nil
+	}
+	return &c.Columns[index-1]
+}
+
+// Deck returns the deck at a 1-based index, or nil
+func (c *Composition) Deck(index int64) *Deck {
+	if index < 1 || index > int64(len(c.Decks)) {
+		return nil
+	}
+	return &c.Decks[index-1]
+}
+
+// LayerGroup returns the layer group at a 1-based index, or nil
+func (c *Composition) LayerGroup(index int64) *LayerGroup {
+	if index < 1 || index > int64(len(c.LayerGroups)) {
+		return nil
+	}
+	return &c.LayerGroups[index-1]
+}
+
+// Clip returns the clip in a layer and column, both 1-based, or nil
+func (c *Composition) Clip(layer, column int64) *Clip {
+	l := c.Layer(layer)
+	if l == nil || column < 1 || column > int64(len(l.Clips)) {
+		return nil
+	}
+	return &l.Clips[column-1]
+}
+
+// FindLayerByName returns the first layer with the given name and its path, or nil
+func (c *Composition) FindLayerByName(name string) (*Layer, Path) {
+	for i := range c.Layers {
+		if l := &c.Layers[i]; l.Name != nil && l.Name.Value == name {
+			return l, LayerPath(int64(i + 1))
+		}
+	}
+	return nil, ""
+}
+
+// FindClipByID returns the clip with the given id and its path, or nil
+func (c *Composition) FindClipByID(id int64) (*Clip, Path) {
+	for i := range c.Layers {
+		for j := range c.Layers[i].Clips {
+			if clip := &c.Layers[i].Clips[j]; clip.ID == id {
+				return clip, ClipPath(int64(i+1), int64(j+1))
+			}
+		}
+	}
+	return nil, ""
+}
+
+// FindEffectByName returns the first video effect of the node at owner, such as
+// LayerPath(2), whose display name or name matches, and the path of the effect
+func (c *Composition) FindEffectByName(owner Path, name string) (*VideoEffect, Path) {
+	node, err := c.Node(owner.Child("video", "effects"))
+	if err != nil {
+		return nil, ""
+	}
+	effects, ok := node.(*[]VideoEffect)
+	if !ok {
+		return nil, ""
+	}
+	for i := range *effects {
+		if e := &(*effects)[i]; e.DisplayName == name || e.Name == name {
+			return e, owner.Child("video").Index("effects", int64(i+1))
+		}
+	}
+	return nil, ""
+}
+
+// Node returns the node at path: a pointer to a struct such as *Layer or
+// *RangeParameter, a Parameter from a ParameterCollection, or a pointer to a
+// list such as *[]VideoEffect. It returns an error matching ErrNotFound if
+// there is no node at path.
+func (c *Composition) Node(path Path) (interface{}, error) {
+	segments := path.Segments()
+	if len(segments) == 0 || segments[0] != "composition" {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+	}
+	v := reflect.ValueOf(c)
+	for _, segment := range segments[1:] {
+		var ok bool
+		if v, ok = childNode(v, segment); !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+		}
+	}
+	return v.Interface(), nil
+}
+
+// SkipChildren is returned by a WalkFunc to skip the nodes below the current one
+var SkipChildren = errors.New("skip children")
+
+// WalkFunc is called by Walk for each node with its path. Nodes are pointers
+// to structs such as *Layer or *TransportTimeline, and the Parameter values of
+// ParameterCollections.
+type WalkFunc func(path Path, node interface{}) error
+
+// Walk calls fn for the composition and every node below it, depth first in
+// the order of the REST API. Parameters are leaves. If fn returns SkipChildren
+// the nodes below are skipped, any other error stops the walk and is returned.
+func (c *Composition) Walk(fn WalkFunc) error {
+	return walkNode(CompositionPath, reflect.ValueOf(c), fn)
+}
+
+func walkNode(path Path, v reflect.Value, fn WalkFunc) error {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return walkNode(path, v.Elem(), fn)
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Elem().Kind() == reflect.Struct {
+			if err := fn(path, v.Interface()); err != nil {
+				if err == SkipChildren {
+					return nil
+				}
+				return err
+			}
+			if _, ok := v.Interface().(Parameter); ok {
+				return nil
+			}
+		}
+		return walkNode(path, v.Elem(), fn)
+	case reflect.Struct:
+		for _, f := range structFields(v) {
+			if err := walkNode(path.Child(f.name), addr(f.value), fn); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := walkNode(path.Child(strconv.Itoa(i+1)), v.Index(i).Addr(), fn); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, key := range keys {
+			if err := walkNode(path.Child(key.String()), v.MapIndex(key), fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// namedField is a struct field with its JSON name
+type namedField struct {
+	name  string
+	value reflect.Value
+}
+
+// structFields returns the exported fields of a struct by JSON name, with the
+// fields of embedded structs inlined
+func structFields(v reflect.Value) []namedField {
+	var fields []namedField
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			fields = append(fields, structFields(v.Field(i))...)
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, namedField{name: name, value: v.Field(i)})
+	}
+	return fields
+}
+
+// addr returns a pointer to v if it is an addressable struct, so it is
+// reported as a node like the structs referenced by pointer
+func addr(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Struct && v.CanAddr() {
+		return v.Addr()
+	}
+	return v
+}
+
+// childNode returns the node below v named by a path segment
+func childNode(v reflect.Value, segment string) (reflect.Value, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		for _, f := range structFields(v) {
+			if f.name == segment {
+				if f.value.Kind() == reflect.Slice || f.value.Kind() == reflect.Struct {
+					return f.value.Addr(), true
+				}
+				return f.value, !isNil(f.value)
+			}
+		}
+	case reflect.Slice:
+		i, err := strconv.Atoi(segment)
+		if err != nil || i < 1 || i > v.Len() {
+			return reflect.Value{}, false
+		}
+		return v.Index(i - 1).Addr(), true
+	case reflect.Map:
+		elem := v.MapIndex(reflect.ValueOf(segment))
+		return elem, elem.IsValid() && !isNil(elem)
+	}
+	return reflect.Value{}, false
+}
+
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
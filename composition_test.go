@@ -0,0 +1,203 @@
+package resolume
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+const testComposition = `{
+	"name": {"id": 1, "valuetype": "ParamString", "value": "Show"},
+	"layers": [
+		{
+			"id": 10,
+			"name": {"id": 11, "valuetype": "ParamString", "value": "Background"},
+			"clips": [{"id": 100, "name": {"id": 101, "valuetype": "ParamString", "value": "Intro"}}]
+		},
+		{
+			"id": 20,
+			"name": {"id": 21, "valuetype": "ParamString", "value": "Overlay"},
+			"video": {
+				"opacity": {"id": 22, "valuetype": "ParamRange", "value": 0.5},
+				"effects": [
+					{"id": 30, "name": "Transform", "display_name": "Transform", "params": {"Scale": {"id": 31, "valuetype": "ParamRange", "value": 100}}},
+					{"id": 40, "name": "Blur", "display_name": "Soft", "params": {}}
+				]
+			},
+			"clips": [
+				{"id": 200},
+				{
+					"id": 201,
+					"transporttype": {"id": 202, "valuetype": "ParamChoice", "value": "Timeline"},
+					"transport": {"position": {"id": 203, "valuetype": "ParamRange", "value": 0}}
+				}
+			]
+		}
+	],
+	"columns": [{"id": 50}]
+}`
+
+func decodeTestComposition(t *testing.T) *Composition {
+	t.Helper()
+	var c Composition
+	if err := json.Unmarshal([]byte(testComposition), &c); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	return &c
+}
+
+func TestPathEndpoint(t *testing.T) {
+	tests := []struct {
+		path      Path
+		endpoint  string
+		parameter string
+	}{
+		{CompositionPath, "/composition", ""},
+		{CompositionPath.Child("master"), "/composition", "master"},
+		{ClipPath(2, 5).Child("video", "opacity"), "/composition/layers/2/clips/5", "video/opacity"},
+		{LayerPath(2).Child("video").Index("effects", 1).Child("params", "Scale"), "/composition/layers/2/effects/video/1", "params/Scale"},
+		{ColumnPath(3), "/composition/columns/3", ""},
+		{LayerGroupPath(1).Index("layers", 2).Child("master"), "/composition/layergroups/1/layers/2", "master"},
+		{"/composition/layers/name", "/composition", "layers/name"},
+	}
+	for _, tt := range tests {
+		if got := tt.path.Endpoint(); got != tt.endpoint {
+			t.Errorf("%s.Endpoint() = %q, want %q", tt.path, got, tt.endpoint)
+		}
+		if got := tt.path.Parameter(); got != tt.parameter {
+			t.Errorf("%s.Parameter() = %q, want %q", tt.path, got, tt.parameter)
+		}
+	}
+
+	if got := ClipPath(2, 5).Parent(); got != "/composition/layers/2/clips" {
+		t.Errorf("Parent() = %s", got)
+	}
+}
+
+func TestCompositionNavigation(t *testing.T) {
+	c := decodeTestComposition(t)
+
+	if l := c.Layer(2); l == nil || l.ID != 20 {
+		t.Errorf("Layer(2) = %v", l)
+	}
+	if l := c.Layer(3); l != nil {
+		t.Errorf("Layer(3) = %v, want nil", l)
+	}
+	if clip := c.Clip(2, 2); clip == nil || clip.ID != 201 {
+		t.Errorf("Clip(2, 2) = %v", clip)
+	}
+	if clip := c.Clip(1, 2); clip != nil {
+		t.Errorf("Clip(1, 2) = %v, want nil", clip)
+	}
+	if col := c.Column(1); col == nil || col.ID != 50 {
+		t.Errorf("Column(1) = %v", col)
+	}
+
+	if l, path := c.FindLayerByName("Overlay"); l == nil || path != LayerPath(2) {
+		t.Errorf("FindLayerByName() = %v, %s", l, path)
+	}
+	if clip, path := c.FindClipByID(201); clip == nil || path != ClipPath(2, 2) {
+		t.Errorf("FindClipByID() = %v, %s", clip, path)
+	}
+	if clip, _ := c.FindClipByID(999); clip != nil {
+		t.Errorf("FindClipByID(999) = %v, want nil", clip)
+	}
+	if e, path := c.FindEffectByName(LayerPath(2), "Blur"); e == nil || e.ID != 40 || path != "/composition/layers/2/video/effects/2" {
+		t.Errorf("FindEffectByName(Blur) = %v, %s", e, path)
+	}
+	if e, _ := c.FindEffectByName(LayerPath(1), "Blur"); e != nil {
+		t.Errorf("FindEffectByName() on a layer without effects = %v", e)
+	}
+}
+
+func TestCompositionNode(t *testing.T) {
+	c := decodeTestComposition(t)
+
+	tests := []struct {
+		path Path
+		id   int64
+	}{
+		{LayerPath(2).Child("video", "opacity"), 22},
+		{LayerPath(2).Child("video").Index("effects", 1).Child("params", "Scale"), 31},
+		{ClipPath(2, 2).Child("transport", "position"), 203},
+		{ClipPath(1, 1).Child("name"), 101},
+	}
+	for _, tt := range tests {
+		node, err := c.Node(tt.path)
+		if err != nil {
+			t.Errorf("Node(%s) error = %v", tt.path, err)
+			continue
+		}
+		p, ok := node.(Parameter)
+		if !ok || p.ParameterID() != tt.id {
+			t.Errorf("Node(%s) = %#v, want parameter %d", tt.path, node, tt.id)
+		}
+	}
+
+	if node, err := c.Node(ClipPath(2, 1)); err != nil || node.(*Clip).ID != 200 {
+		t.Errorf("Node(clip) = %v, %v", node, err)
+	}
+	for _, path := range []Path{LayerPath(3), LayerPath(1).Child("video", "opacity"), "/composition/nothing", "/layers/1"} {
+		if _, err := c.Node(path); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Node(%s) error = %v, want ErrNotFound", path, err)
+		}
+	}
+}
+
+func TestCompositionWalk(t *testing.T) {
+	c := decodeTestComposition(t)
+
+	nodes := map[Path]interface{}{}
+	err := c.Walk(func(path Path, node interface{}) error {
+		nodes[path] = node
+		if path == ClipPath(1, 1) {
+			return SkipChildren
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	if _, ok := nodes[CompositionPath].(*Composition); !ok {
+		t.Error("Expected the composition to be visited")
+	}
+	if _, ok := nodes[LayerPath(2).Child("video")].(*VideoTrackLayer); !ok {
+		t.Error("Expected the layer video track to be visited")
+	}
+	if p, ok := nodes[LayerPath(2).Child("video").Index("effects", 1).Child("params", "Scale")].(*RangeParameter); !ok || p.ID != 31 {
+		t.Error("Expected the effect parameter to be visited")
+	}
+	if _, ok := nodes[ClipPath(2, 2).Child("transport")].(*TransportTimeline); !ok {
+		t.Error("Expected the clip transport to be visited")
+	}
+	if _, ok := nodes[ClipPath(1, 1).Child("name")]; ok {
+		t.Error("Expected the children of a skipped node not to be visited")
+	}
+	if _, ok := nodes[LayerPath(2).Child("video", "opacity", "view")]; ok {
+		t.Error("Expected parameters to be leaves")
+	}
+
+	stop := errors.New("stop")
+	if err := c.Walk(func(Path, interface{}) error { return stop }); err != stop {
+		t.Errorf("Walk() error = %v, want %v", err, stop)
+	}
+}
+
+func TestEncodingOfZeroValues(t *testing.T) {
+	tests := []struct {
+		v    interface{}
+		want string
+	}{
+		{Column{}, `{"id":0}`},
+		{FrameRate{}, `{"num":0,"denom":0}`},
+		{Effects{}, `{"video":null}`},
+		{VideoTrackClip{}, `{}`},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.v)
+		if err != nil || string(data) != tt.want {
+			t.Errorf("json.Marshal(%T{}) = %s, %v, want %s", tt.v, data, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+// Package contactsheet renders the clip grid of a composition as a single
+// image, for show documentation and setlist printouts.
+//
+// Clip thumbnails are laid out by layer and column as in Resolume, the top
+// layer first, and labelled with the clip, layer and column names. Clips are
+// tinted by their ColorID, and connected clips are highlighted with a border.
+package contactsheet
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // thumbnails may be GIF
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/FlowingSPDG/resolume-go"
+	"github.com/FlowingSPDG/resolume-go/thumbcache"
+)
+
+// Layout of the sheet in pixels
+const (
+	margin       = 12
+	gap          = 6
+	titleHeight  = 24
+	headerHeight = 20
+	labelHeight  = 16
+	layerWidth   = 120
+	border       = 3
+)
+
+var (
+	background = color.RGBA{0x1e, 0x1e, 0x1e, 0xff}
+	emptyCell  = color.RGBA{0x2c, 0x2c, 0x2c, 0xff}
+	labelColor = color.RGBA{0x3a, 0x3a, 0x3a, 0xff}
+	textColor  = color.RGBA{0xe6, 0xe6, 0xe6, 0xff}
+	dimText    = color.RGBA{0x90, 0x90, 0x90, 0xff}
+	// connectedColor is the border of connected clips
+	connectedColor = color.RGBA{0x5a, 0xe0, 0x5a, 0xff}
+)
+
+// Palette are the colors of the ColorID indexes from 1, index 0 being no color
+var Palette = []color.RGBA{
+	{0xe0, 0x4b, 0x4b, 0xff}, // red
+	{0xe8, 0x8a, 0x3a, 0xff}, // orange
+	{0xe0, 0xc8, 0x3c, 0xff}, // yellow
+	{0x6c, 0xc0, 0x4a, 0xff}, // green
+	{0x3c, 0xc0, 0xc0, 0xff}, // cyan
+	{0x4a, 0x7c, 0xe0, 0xff}, // blue
+	{0x9a, 0x5a, 0xe0, 0xff}, // purple
+	{0xe0, 0x5a, 0xb4, 0xff}, // pink
+}
+
+// options configures the rendering
+type options struct {
+	width, height int
+	cache         *thumbcache.Cache
+	title         string
+}
+
+// Option configures the rendering
+type Option func(*options)
+
+// WithThumbnailSize sets the size thumbnails are scaled to, 160x90 by default
+func WithThumbnailSize(width, height int) Option {
+	return func(o *options) {
+		o.width, o.height = width, height
+	}
+}
+
+// WithCache fetches the thumbnails through a cache, e.g. to share it with a
+// UI. By default a new cache is used for every rendering.
+func WithCache(cache *thumbcache.Cache) Option {
+	return func(o *options) {
+		o.cache = cache
+	}
+}
+
+// WithTitle sets the title of the sheet, by default the names of the
+// composition and of its open deck
+func WithTitle(title string) Option {
+	return func(o *options) {
+		o.title = title
+	}
+}
+
+// Render renders the clip grid of the deck open in Resolume
+func Render(ctx context.Context, client *resolume.Client, opts ...Option) (image.Image, error) {
+	composition, err := client.GetCompositionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return RenderComposition(ctx, client, composition, opts...)
+}
+
+// RenderComposition renders the clip grid of a composition, fetching the
+// thumbnails of its clips with client. A thumbnail that cannot be fetched
+// leaves the cell of its clip empty; only ctx being done fails the render.
+func RenderComposition(ctx context.Context, client *resolume.Client, composition *resolume.Composition, opts ...Option) (image.Image, error) {
+	o := options{width: 160, height: 90}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.cache == nil {
+		o.cache = thumbcache.New(client)
+	}
+	if o.title == "" {
+		o.title = title(composition)
+	}
+
+	// Fetch the thumbnails of the clips with content
+	var clips []*resolume.Clip
+	for i := range composition.Layers {
+		for j := range composition.Layers[i].Clips {
+			if clip := &composition.Layers[i].Clips[j]; !isEmpty(clip) {
+				clips = append(clips, clip)
+			}
+		}
+	}
+	if err := o.cache.Prefetch(ctx, clips...); err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	columns := len(composition.Columns)
+	for _, layer := range composition.Layers {
+		columns = max(columns, len(layer.Clips))
+	}
+	cellWidth, cellHeight := o.width+gap, o.height+labelHeight+gap
+	width := 2*margin + layerWidth + columns*cellWidth - gap
+	height := 2*margin + titleHeight + headerHeight + len(composition.Layers)*cellHeight - gap
+	img := image.NewRGBA(image.Rect(0, 0, max(width, 2*margin+layerWidth), height))
+	fill(img, img.Bounds(), background)
+
+	text(img, o.title, margin, margin+titleHeight-8, img.Bounds().Dx()-2*margin, textColor)
+	top := margin + titleHeight
+	for j := 0; j < columns; j++ {
+		name := fmt.Sprintf("Column %d", j+1)
+		if j < len(composition.Columns) {
+			name = stringValue(composition.Columns[j].Name, name)
+		}
+		text(img, name, margin+layerWidth+j*cellWidth, top+headerHeight-6, o.width, dimText)
+	}
+	top += headerHeight
+
+	// Resolume shows the last layer on top
+	for row := 0; row < len(composition.Layers); row++ {
+		i := len(composition.Layers) - 1 - row
+		layer := &composition.Layers[i]
+		y := top + row*cellHeight
+		text(img, stringValue(layer.Name, fmt.Sprintf("Layer %d", i+1)), margin, y+o.height/2+4, layerWidth-gap, textColor)
+
+		for j := range layer.Clips {
+			cell := image.Rect(0, 0, o.width, o.height+labelHeight).Add(image.Pt(margin+layerWidth+j*cellWidth, y))
+			if err := drawClip(ctx, img, cell, &layer.Clips[j], o); err != nil {
+				return nil, fmt.Errorf("clip %d of layer %d: %w", j+1, i+1, err)
+			}
+		}
+	}
+	return img, nil
+}
+
+// drawClip draws a clip in a cell: its thumbnail, and its name on its color
+func drawClip(ctx context.Context, img *image.RGBA, cell image.Rectangle, clip *resolume.Clip, o options) error {
+	thumb := image.Rect(cell.Min.X, cell.Min.Y, cell.Max.X, cell.Max.Y-labelHeight)
+	label := image.Rect(cell.Min.X, thumb.Max.Y, cell.Max.X, cell.Max.Y)
+	if isEmpty(clip) {
+		fill(img, cell, emptyCell)
+		return nil
+	}
+
+	// A thumbnail that cannot be fetched or decoded leaves the cell empty
+	var src image.Image
+	data, err := o.cache.Clip(ctx, clip)
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err == nil {
+		src, _, err = image.Decode(bytes.NewReader(data))
+	}
+	if err == nil {
+		draw.ApproxBiLinear.Scale(img, thumb, src, src.Bounds(), draw.Src, nil)
+	} else {
+		fill(img, thumb, emptyCell)
+	}
+
+	labelBackground := labelColor
+	if c, ok := tint(clip); ok {
+		labelBackground = c
+		// A bar of the color along the left of the thumbnail as well
+		fill(img, image.Rect(thumb.Min.X, thumb.Min.Y, thumb.Min.X+border, thumb.Max.Y), c)
+	}
+	fill(img, label, labelBackground)
+	text(img, stringValue(clip.Name, ""), label.Min.X+4, label.Max.Y-4, label.Dx()-8, textColor)
+
+	if isConnected(clip) {
+		outline(img, cell, connectedColor)
+	}
+	return nil
+}
+
+// title returns the names of a composition and of its selected deck
+func title(c *resolume.Composition) string {
+	name := stringValue(c.Name, "Composition")
+	for _, deck := range c.Decks {
+		if deck.Selected != nil && deck.Selected.Value {
+			return name + " - " + stringValue(deck.Name, "")
+		}
+	}
+	return name
+}
+
+func isEmpty(clip *resolume.Clip) bool {
+	return clip.Connected != nil && clip.Connected.Value == "Empty"
+}
+
+func isConnected(clip *resolume.Clip) bool {
+	return clip.Connected != nil && strings.HasPrefix(clip.Connected.Value, "Connected")
+}
+
+// tint returns the color of the ColorID of a clip
+func tint(clip *resolume.Clip) (color.RGBA, bool) {
+	if clip.ColorID == nil || clip.ColorID.Index < 1 || int(clip.ColorID.Index) > len(Palette) {
+		return color.RGBA{}, false
+	}
+	return Palette[clip.ColorID.Index-1], true
+}
+
+func stringValue(p *resolume.StringParameter, fallback string) string {
+	if p == nil || p.Value == "" {
+		return fallback
+	}
+	return p.Value
+}
+
+func fill(img *image.RGBA, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+func outline(img *image.RGBA, r image.Rectangle, c color.Color) {
+	fill(img, image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+border), c)
+	fill(img, image.Rect(r.Min.X, r.Max.Y-border, r.Max.X, r.Max.Y), c)
+	fill(img, image.Rect(r.Min.X, r.Min.Y, r.Min.X+border, r.Max.Y), c)
+	fill(img, image.Rect(r.Max.X-border, r.Min.Y, r.Max.X, r.Max.Y), c)
+}
+
+// text draws s with its baseline at y, shortened with "..." to fit width
+func text(img *image.RGBA, s string, x, y, width int, c color.Color) {
+	d := &font.Drawer{Dst: img, Src: image.NewUniform(c), Face: basicfont.Face7x13, Dot: fixed.P(x, y)}
+	if d.MeasureString(s).Ceil() > width {
+		runes := []rune(s)
+		for len(runes) > 0 && d.MeasureString(string(runes)+"...").Ceil() > width {
+			runes = runes[:len(runes)-1]
+		}
+		s = string(runes) + "..."
+	}
+	d.DrawString(s)
+}
+
+// Encode writes an image as "png" or "jpeg"
+func Encode(w io.Writer, img image.Image, format string) error {
+	switch strings.ToLower(format) {
+	case "png":
+		return png.Encode(w, img)
+	case "jpeg", "jpg":
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: 90})
+	}
+	return fmt.Errorf("unknown image format: %s", format)
+}
@@ -0,0 +1,137 @@
+package contactsheet
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/FlowingSPDG/resolume-go"
+	"github.com/FlowingSPDG/resolume-go/resolumetest"
+)
+
+func solid(c color.Color) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 32, 18))
+	fill(img, img.Bounds(), c)
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
+func TestRender(t *testing.T) {
+	server := resolumetest.NewServer(resolumetest.WithSize(2, 3))
+	defer server.Close()
+	client := server.Client()
+
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	client.OpenClip(1, 1, "file:///C:/red.mov")
+	client.SetClipThumbnail(1, 1, bytes.NewReader(solid(red)))
+	client.OpenClip(2, 2, "source:///video/Checkered")
+	client.ConnectClip(2, 2, nil)
+	server.Update(func(c *resolume.Composition) {
+		c.Layers[0].Clips[0].ColorID = &resolume.ChoiceParameter{ValueType: resolume.ParamChoice, Index: 6}
+	})
+
+	img, err := Render(context.Background(), client, WithThumbnailSize(80, 45))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	cellWidth, cellHeight := 80+gap, 45+labelHeight+gap
+	if got, want := img.Bounds().Dx(), 2*margin+layerWidth+3*cellWidth-gap; got != want {
+		t.Errorf("Width = %d, want %d", got, want)
+	}
+	if got, want := img.Bounds().Dy(), 2*margin+titleHeight+headerHeight+2*cellHeight-gap; got != want {
+		t.Errorf("Height = %d, want %d", got, want)
+	}
+
+	// at returns the color of a point of the cell of a clip, layer 1 being
+	// the bottom row
+	at := func(layer, column, x, y int) color.RGBA {
+		row := 2 - layer
+		return color.RGBAModel.Convert(img.At(margin+layerWidth+(column-1)*cellWidth+x, margin+titleHeight+headerHeight+row*cellHeight+y)).(color.RGBA)
+	}
+	if got := at(1, 1, 40, 20); got != red {
+		t.Errorf("Thumbnail of clip 1 of layer 1 = %v, want red", got)
+	}
+	if got := at(1, 1, 1, 20); got != Palette[5] {
+		t.Errorf("Color bar of clip 1 of layer 1 = %v, want %v", got, Palette[5])
+	}
+	if got := at(1, 1, 78, 45+labelHeight-1); got != Palette[5] {
+		t.Errorf("Label of clip 1 of layer 1 = %v, want %v", got, Palette[5])
+	}
+	if got := at(2, 2, 40, 0); got != connectedColor {
+		t.Errorf("Border of the connected clip = %v, want %v", got, connectedColor)
+	}
+	if got := at(1, 3, 40, 20); got != emptyCell {
+		t.Errorf("Empty clip = %v, want %v", got, emptyCell)
+	}
+}
+
+func TestRenderGIF(t *testing.T) {
+	server := resolumetest.NewServer(resolumetest.WithSize(1, 1))
+	defer server.Close()
+	client := server.Client()
+
+	// A blue pixel, written out so the test does not register the GIF decoder itself
+	blue := color.RGBA{0, 0, 0xff, 0xff}
+	const blueGIF = "GIF89a\x01\x00\x01\x00\x80\x00\x00\x00\x00\xff\x00\x00\x00,\x00\x00\x00\x00\x01\x00\x01\x00\x00\x02\x02D\x01\x00;"
+	client.OpenClip(1, 1, "file:///C:/blue.mov")
+	client.SetClipThumbnail(1, 1, strings.NewReader(blueGIF))
+
+	img, err := Render(context.Background(), client, WithThumbnailSize(80, 45))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	x, y := margin+layerWidth+40, margin+titleHeight+headerHeight+20
+	if got := color.RGBAModel.Convert(img.At(x, y)); got != blue {
+		t.Errorf("Clip with a GIF thumbnail = %v, want blue", got)
+	}
+}
+
+func TestRenderThumbnailError(t *testing.T) {
+	var failing atomic.Bool
+	server := resolumetest.NewServer(resolumetest.WithSize(1, 2), resolumetest.WithHook(func(r resolumetest.Request) *resolumetest.Fault {
+		if failing.Load() && strings.Contains(r.Path, "thumbnail") {
+			return &resolumetest.Fault{Status: http.StatusInternalServerError}
+		}
+		return nil
+	}))
+	defer server.Close()
+	client := server.Client()
+	client.OpenClip(1, 1, "file:///C:/red.mov")
+	client.SetClipThumbnail(1, 1, bytes.NewReader(solid(color.RGBA{0xff, 0, 0, 0xff})))
+	failing.Store(true)
+
+	img, err := Render(context.Background(), client, WithThumbnailSize(80, 45))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	x, y := margin+layerWidth+40, margin+titleHeight+headerHeight+20
+	if got := color.RGBAModel.Convert(img.At(x, y)); got != emptyCell {
+		t.Errorf("Clip with a failed thumbnail = %v, want %v", got, emptyCell)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Render(ctx, client); err == nil {
+		t.Error("Render() with a cancelled context error = nil")
+	}
+}
+
+func TestEncode(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for _, format := range []string{"png", "JPEG"} {
+		var buf bytes.Buffer
+		if err := Encode(&buf, img, format); err != nil || buf.Len() == 0 {
+			t.Errorf("Encode(%s) error = %v", format, err)
+		}
+	}
+	if err := Encode(&bytes.Buffer{}, img, "gif"); err == nil {
+		t.Error("Encode(gif) error = nil")
+	}
+}
@@ -0,0 +1,175 @@
+// Package cue runs shows from a list of cues, each a sequence of steps such as
+// connecting a column, waiting a number of beats, fading a parameter or
+// triggering a clip.
+//
+// A cue list is loaded from YAML or JSON:
+//
+//	name: Main show
+//	cues:
+//	  - name: Intro
+//	    steps:
+//	      - type: connect_column
+//	        column: 3
+//	      - type: wait
+//	        beats: 8
+//	      - type: fade
+//	        path: layers/2/video/opacity
+//	        to: 0
+//	        beats: 4
+//	        easing: ease-in-out-sine
+//	      - type: connect_clip
+//	        clip: 1618311116470
+//
+// A Player runs the cues one at a time on GO, and can step back or jump to a
+// cue by name. Preflight checks every layer, column, clip and parameter the
+// list refers to against the composition before the show.
+package cue
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/FlowingSPDG/resolume-go/animate"
+)
+
+// StepType is the kind of a step
+type StepType string
+
+// Step types
+const (
+	// ConnectColumn connects the column at the 1-based index Column
+	ConnectColumn StepType = "connect_column"
+	// ConnectClip connects the clip with the id Clip
+	ConnectClip StepType = "connect_clip"
+	// SetParameter sets the parameter with the id Parameter, or at Path, to Value
+	SetParameter StepType = "set_parameter"
+	// Fade fades the parameter with the id Parameter, or at Path, from its
+	// current value to To over Seconds or Beats
+	Fade StepType = "fade"
+	// Action undoes or redoes with the Action "undo" or "redo"
+	Action StepType = "action"
+	// DisconnectAll disconnects all clips
+	DisconnectAll StepType = "disconnect_all"
+	// Wait waits for Seconds or Beats
+	Wait StepType = "wait"
+)
+
+// Step is a single operation of a cue. Only the fields of its Type are used.
+type Step struct {
+	Type      StepType    `json:"type" yaml:"type"`
+	Column    int64       `json:"column,omitempty" yaml:"column,omitempty"`
+	Clip      int64       `json:"clip,omitempty" yaml:"clip,omitempty"`
+	Parameter int64       `json:"parameter,omitempty" yaml:"parameter,omitempty"`
+	Path      string      `json:"path,omitempty" yaml:"path,omitempty"`
+	Value     interface{} `json:"value,omitempty" yaml:"value,omitempty"`
+	To        *float64    `json:"to,omitempty" yaml:"to,omitempty"`
+	Action    string      `json:"action,omitempty" yaml:"action,omitempty"`
+	Seconds   float64     `json:"seconds,omitempty" yaml:"seconds,omitempty"`
+	Beats     float64     `json:"beats,omitempty" yaml:"beats,omitempty"`
+	Easing    string      `json:"easing,omitempty" yaml:"easing,omitempty"`
+}
+
+// Cue is a named sequence of steps run on a single GO
+type Cue struct {
+	Name  string `json:"name" yaml:"name"`
+	Steps []Step `json:"steps" yaml:"steps"`
+}
+
+// List is a cue list
+type List struct {
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	Cues []Cue  `json:"cues" yaml:"cues"`
+}
+
+// Parse parses a cue list from YAML or JSON and validates it
+func Parse(data []byte) (*List, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	var list List
+	if err := decoder.Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to parse cue list: %w", err)
+	}
+	if err := list.Validate(); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// Load reads and parses a cue list file
+func Load(name string) (*List, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Validate checks that every step has the fields its type needs. It does not
+// check them against a composition, which Player.Preflight does.
+func (l *List) Validate() error {
+	var errs []error
+	for i, c := range l.Cues {
+		for j, step := range c.Steps {
+			if err := step.validate(); err != nil {
+				errs = append(errs, fmt.Errorf("cue %d (%s) step %d: %w", i+1, c.Name, j+1, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s Step) validate() error {
+	switch s.Type {
+	case ConnectColumn:
+		if s.Column < 1 {
+			return fmt.Errorf("%s needs a column", s.Type)
+		}
+	case ConnectClip:
+		if s.Clip == 0 {
+			return fmt.Errorf("%s needs a clip id", s.Type)
+		}
+	case SetParameter, Fade:
+		if (s.Parameter == 0) == (s.Path == "") {
+			return fmt.Errorf("%s needs either a parameter id or a path", s.Type)
+		}
+		if s.Type == SetParameter && s.Value == nil {
+			return fmt.Errorf("%s needs a value", s.Type)
+		}
+		if s.Type == Fade {
+			if s.To == nil {
+				return fmt.Errorf("%s needs a target value", s.Type)
+			}
+			if _, err := animate.ParseEasing(s.Easing); err != nil {
+				return err
+			}
+		}
+	case Action:
+		if s.Action != "undo" && s.Action != "redo" {
+			return fmt.Errorf("invalid action: %s (must be 'undo' or 'redo')", s.Action)
+		}
+	case DisconnectAll:
+	case Wait:
+		if s.Seconds <= 0 && s.Beats <= 0 {
+			return fmt.Errorf("%s needs seconds or beats", s.Type)
+		}
+	default:
+		return fmt.Errorf("unknown step type: %q", s.Type)
+	}
+	if s.Seconds < 0 || s.Beats < 0 || (s.Seconds > 0 && s.Beats > 0) {
+		return fmt.Errorf("%s needs either seconds or beats", s.Type)
+	}
+	return nil
+}
+
+// length returns the duration of a wait or fade step
+func (s Step) length() animate.Length {
+	if s.Beats > 0 {
+		return animate.Beats(s.Beats)
+	}
+	return animate.Time(time.Duration(s.Seconds * float64(time.Second)))
+}
@@ -0,0 +1,203 @@
+package cue
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/FlowingSPDG/resolume-go"
+)
+
+const testList = `
+name: Test show
+cues:
+  - name: Intro
+    steps:
+      - type: connect_column
+        column: 3
+      - type: wait
+        beats: 0.1
+      - type: fade
+        path: layers/2/video/opacity
+        to: 0
+        seconds: 0.02
+        easing: ease-out-quad
+  - name: Verse
+    steps:
+      - type: connect_clip
+        clip: 100
+      - type: set_parameter
+        parameter: 20
+        value: 0.5
+  - name: Outro
+    steps:
+      - type: disconnect_all
+      - type: action
+        action: undo
+`
+
+const testComposition = `{
+	"columns": [{"id": 1}, {"id": 2}, {"id": 3}],
+	"layers": [
+		{"id": 10},
+		{"id": 11, "video": {"opacity": {"id": 20, "valuetype": "ParamRange", "value": 1, "min": 0, "max": 1}},
+		 "clips": [{"id": 100}], "name": {"id": 21, "valuetype": "ParamString", "value": "Layer 2"}}
+	],
+	"tempo_controller": {"tempo": {"id": 1, "valuetype": "ParamRange", "value": 600}}
+}`
+
+// newShowServer serves testComposition and the opacity parameter, and records
+// the POST and PUT requests
+func newShowServer(t *testing.T) (*resolume.Client, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/composition":
+			io.WriteString(w, testComposition)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/parameter/by-id/20":
+			io.WriteString(w, `{"id": 20, "valuetype": "ParamRange", "value": 1, "min": 0, "max": 1}`)
+		case r.Method == http.MethodGet:
+			http.NotFound(w, r)
+		default:
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			requests = append(requests, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/api/v1")+" "+strings.TrimSpace(string(body)))
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := resolume.NewClientFromURL(server.URL+"/api/v1", resolume.WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewClientFromURL() error = %v", err)
+	}
+	return client, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), requests...)
+	}
+}
+
+func TestParse(t *testing.T) {
+	list, err := Parse([]byte(testList))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(list.Cues) != 3 || len(list.Cues[0].Steps) != 3 || list.Cues[0].Steps[2].Easing != "ease-out-quad" {
+		t.Errorf("Parse() = %+v", list)
+	}
+
+	json := `{"cues": [{"name": "A", "steps": [{"type": "wait", "seconds": 1}]}]}`
+	if _, err := Parse([]byte(json)); err != nil {
+		t.Errorf("Parse() of JSON error = %v", err)
+	}
+
+	invalid := []string{
+		`{"cues": [{"steps": [{"type": "jump"}]}]}`,
+		`{"cues": [{"steps": [{"type": "wait"}]}]}`,
+		`{"cues": [{"steps": [{"type": "fade", "parameter": 1, "path": "x", "to": 0}]}]}`,
+		`{"cues": [{"steps": [{"type": "fade", "parameter": 1, "to": 0, "easing": "bounce"}]}]}`,
+		`{"cues": [{"steps": [{"type": "connect_column", "colum": 1}]}]}`,
+	}
+	for _, data := range invalid {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("Parse(%s) error = nil", data)
+		}
+	}
+}
+
+func TestPreflight(t *testing.T) {
+	client, _ := newShowServer(t)
+
+	list, _ := Parse([]byte(testList))
+	if err := NewPlayer(client, list).Preflight(context.Background()); err != nil {
+		t.Errorf("Preflight() error = %v", err)
+	}
+
+	list, _ = Parse([]byte(`
+cues:
+  - name: Broken
+    steps:
+      - {type: connect_column, column: 4}
+      - {type: connect_clip, clip: 101}
+      - {type: set_parameter, path: layers/3/video/opacity, value: 1}
+      - {type: fade, path: layers/2/name, to: 1}
+`))
+	err := NewPlayer(client, list).Preflight(context.Background())
+	if !errors.Is(err, resolume.ErrNotFound) {
+		t.Fatalf("Preflight() error = %v, want ErrNotFound", err)
+	}
+	for _, want := range []string{"step 1", "step 2", "step 3", "cannot fade a ParamString"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Preflight() error = %v, want it to mention %s", err, want)
+		}
+	}
+}
+
+func TestPlayer(t *testing.T) {
+	client, requests := newShowServer(t)
+	list, _ := Parse([]byte(testList))
+	player := NewPlayer(client, list)
+	ctx := context.Background()
+
+	if err := player.Go(ctx); err != nil {
+		t.Fatalf("Go() error = %v", err)
+	}
+	sent := requests()
+	if sent[0] != "POST /composition/columns/3/connect null" || sent[len(sent)-1] != `PUT /parameter/by-id/20 {"value":0}` {
+		t.Errorf("Intro sent %v", sent)
+	}
+	if next := player.Next(); next == nil || next.Name != "Verse" {
+		t.Errorf("Next() = %v, want Verse", next)
+	}
+
+	player.Back()
+	if player.Position() != 0 {
+		t.Errorf("Position() after Back() = %d, want 0", player.Position())
+	}
+	if err := player.Jump("Outro"); err != nil {
+		t.Fatalf("Jump() error = %v", err)
+	}
+	before := len(requests())
+	if err := player.Go(ctx); err != nil {
+		t.Fatalf("Go() error = %v", err)
+	}
+	want := []string{"POST /composition/disconnect-all ", "POST /composition/action undo"}
+	if got := requests()[before:]; strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Outro sent %v, want %v", got, want)
+	}
+	if err := player.Go(ctx); !errors.Is(err, ErrEndOfList) {
+		t.Errorf("Go() after the last cue error = %v, want ErrEndOfList", err)
+	}
+	if err := player.Jump("Bridge"); !errors.Is(err, resolume.ErrNotFound) {
+		t.Errorf("Jump() to a missing cue error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestPlayerCancel(t *testing.T) {
+	client, _ := newShowServer(t)
+	list, _ := Parse([]byte(`{"cues": [{"name": "Long", "steps": [{"type": "wait", "seconds": 3600}]}]}`))
+
+	player := NewPlayer(client, list)
+	if err := player.Preflight(context.Background()); err != nil {
+		t.Fatalf("Preflight() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := player.Go(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Go() error = %v, want context.Canceled", err)
+	}
+	// The next Go runs the stopped cue again
+	if next := player.Next(); player.Position() != 0 || next == nil || next.Name != "Long" {
+		t.Errorf("Position() after a stopped cue = %d, want 0", player.Position())
+	}
+}
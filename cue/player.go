@@ -0,0 +1,245 @@
+package cue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/FlowingSPDG/resolume-go"
+	"github.com/FlowingSPDG/resolume-go/animate"
+)
+
+// ErrEndOfList is returned by Player.Go after the last cue
+var ErrEndOfList = errors.New("end of cue list")
+
+// Player runs a cue list against a Client. Its position is the next cue to
+// run, and it is safe to move it with Back or Jump while a cue is running.
+type Player struct {
+	client   *resolume.Client
+	list     *List
+	animator *animate.Animator
+
+	mu       sync.Mutex
+	next     int
+	resolver *resolume.Resolver
+}
+
+// Option configures a Player
+type Option func(*Player)
+
+// WithAnimator sets the Animator running the fades, e.g. to share its rate
+// with other fades. By default the Player has its own.
+func WithAnimator(a *animate.Animator) Option {
+	return func(p *Player) {
+		p.animator = a
+	}
+}
+
+// NewPlayer creates a Player positioned at the first cue
+func NewPlayer(client *resolume.Client, list *List, opts ...Option) *Player {
+	p := &Player{
+		client: client,
+		list:   list,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.animator == nil {
+		p.animator = animate.New(client)
+	}
+	return p
+}
+
+// Preflight retrieves the composition and checks that every column, clip and
+// parameter the cue list refers to exists, that faded parameters are numbers
+// and that the composition has a tempo if any step is in beats. It reports
+// every problem found, not only the first one. Parameter paths are resolved
+// against this composition until the next Preflight.
+func (p *Player) Preflight(ctx context.Context) error {
+	composition, err := p.client.GetCompositionContext(ctx)
+	if err != nil {
+		return err
+	}
+	resolver := resolume.NewResolver(p.client, composition)
+
+	params := map[int64]resolume.Parameter{}
+	composition.Walk(func(path resolume.Path, node interface{}) error {
+		if param, ok := node.(resolume.Parameter); ok && param.ParameterID() != 0 {
+			params[param.ParameterID()] = param
+		}
+		return nil
+	})
+	hasTempo := composition.TempoController != nil && composition.TempoController.Tempo != nil
+
+	var errs []error
+	for i, c := range p.list.Cues {
+		for j, step := range c.Steps {
+			if err := checkStep(step, composition, resolver, params, hasTempo); err != nil {
+				errs = append(errs, fmt.Errorf("cue %d (%s) step %d: %w", i+1, c.Name, j+1, err))
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.resolver = resolver
+	p.mu.Unlock()
+	return nil
+}
+
+// checkStep checks the references of a step against the composition
+func checkStep(step Step, composition *resolume.Composition, resolver *resolume.Resolver, params map[int64]resolume.Parameter, hasTempo bool) error {
+	if err := step.validate(); err != nil {
+		return err
+	}
+	if step.Beats > 0 && !hasTempo {
+		return fmt.Errorf("%s in beats: the composition has no tempo", step.Type)
+	}
+	switch step.Type {
+	case ConnectColumn:
+		if composition.Column(step.Column) == nil {
+			return fmt.Errorf("%w: no column %d", resolume.ErrNotFound, step.Column)
+		}
+	case ConnectClip:
+		if clip, _ := composition.FindClipByID(step.Clip); clip == nil {
+			return fmt.Errorf("%w: no clip with id %d", resolume.ErrNotFound, step.Clip)
+		}
+	case SetParameter, Fade:
+		param, ok := params[step.Parameter]
+		if step.Path != "" {
+			var err error
+			if param, _, err = resolver.Lookup(step.Path); err != nil {
+				return err
+			}
+		} else if !ok {
+			return fmt.Errorf("%w: no parameter with id %d", resolume.ErrNotFound, step.Parameter)
+		}
+		if step.Type == Fade {
+			switch param.(type) {
+			case *resolume.RangeParameter, *resolume.IntegerParameter:
+			default:
+				return fmt.Errorf("cannot fade a %s", param.ParameterType())
+			}
+		}
+	}
+	return nil
+}
+
+// Position returns the 0-based index of the next cue to run
+func (p *Player) Position() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.next
+}
+
+// Next returns the next cue to run, or nil after the last cue
+func (p *Player) Next() *Cue {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.next >= len(p.list.Cues) {
+		return nil
+	}
+	return &p.list.Cues[p.next]
+}
+
+// Go runs the next cue and moves to the one after it. It runs Preflight first
+// if it has not been run, and returns ErrEndOfList after the last cue. A
+// failed step stops the cue, and cancelling ctx stops it between or during
+// waits and fades; the player then stays on the cue so the next Go runs it
+// again.
+func (p *Player) Go(ctx context.Context) error {
+	p.mu.Lock()
+	resolver := p.resolver
+	p.mu.Unlock()
+	if resolver == nil {
+		if err := p.Preflight(ctx); err != nil {
+			return err
+		}
+	}
+
+	p.mu.Lock()
+	if p.next >= len(p.list.Cues) {
+		p.mu.Unlock()
+		return ErrEndOfList
+	}
+	index := p.next
+	c := p.list.Cues[index]
+	resolver = p.resolver
+	p.mu.Unlock()
+
+	for i, step := range c.Steps {
+		if err := p.run(ctx, resolver, step); err != nil {
+			return fmt.Errorf("cue %d (%s) step %d: %w", index+1, c.Name, i+1, err)
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// Back or Jump while the cue ran take precedence
+	if p.next == index {
+		p.next++
+	}
+	return nil
+}
+
+// Back moves to the previous cue, so that the next Go runs it again
+func (p *Player) Back() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.next > 0 {
+		p.next--
+	}
+}
+
+// Jump moves to the first cue with the given name
+func (p *Player) Jump(name string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, c := range p.list.Cues {
+		if c.Name == name {
+			p.next = i
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: no cue named %q", resolume.ErrNotFound, name)
+}
+
+// run runs a single step
+func (p *Player) run(ctx context.Context, resolver *resolume.Resolver, step Step) error {
+	switch step.Type {
+	case ConnectColumn:
+		return p.client.ConnectColumnContext(ctx, step.Column, nil)
+	case ConnectClip:
+		return p.client.ConnectClipByIDContext(ctx, step.Clip, nil)
+	case SetParameter:
+		if step.Path != "" {
+			return resolver.SetByPath(ctx, step.Path, step.Value)
+		}
+		return p.client.SetParameterByIDContext(ctx, step.Parameter, map[string]interface{}{"value": step.Value})
+	case Fade:
+		id := step.Parameter
+		if step.Path != "" {
+			param, _, err := resolver.Lookup(step.Path)
+			if err != nil {
+				return err
+			}
+			id = param.ParameterID()
+		}
+		easing, err := animate.ParseEasing(step.Easing)
+		if err != nil {
+			return err
+		}
+		return p.animator.FadeTo(ctx, id, *step.To, step.length(), easing)
+	case Action:
+		return p.client.CompositionActionContext(ctx, step.Action)
+	case DisconnectAll:
+		return p.client.DisconnectAllClipsContext(ctx)
+	case Wait:
+		return p.animator.Wait(ctx, step.length())
+	default:
+		return fmt.Errorf("unknown step type: %q", step.Type)
+	}
+}
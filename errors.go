@@ -0,0 +1,90 @@
+package resolume
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors matched by *APIError with errors.Is
+var (
+	// ErrNotFound is matched by a 404 response, e.g. for a layer or clip that does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidURI is matched by a 400 response to a request sending a resource
+	// URI, such as an effect, a file or the path of a layer
+	ErrInvalidURI = errors.New("invalid URI")
+	// ErrPreconditionFailed is matched by a 412 response, e.g. when the composition is locked
+	ErrPreconditionFailed = errors.New("precondition failed")
+)
+
+// maxErrorBody limits how much of an error response body is kept
+const maxErrorBody = 64 << 10
+
+// Error represents the JSON error body returned by some endpoints
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("API error: %d - %s", e.Code, e.Message)
+}
+
+// APIError is returned for responses with a 4xx or 5xx status
+type APIError struct {
+	// Method and Endpoint identify the request, e.g. "POST" and "/composition/layers/1/clips/2/open"
+	Method   string
+	Endpoint string
+	// StatusCode is the HTTP status of the response
+	StatusCode int
+	// Body is the raw response body
+	Body []byte
+	// Message is the message of a JSON Error body, or the plain-text body such as
+	// "the clip cannot be changed currently"
+	Message string
+
+	// sentURI is set when the request body was a uriText
+	sentURI bool
+}
+
+func (e *APIError) Error() string {
+	status := fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message == "" {
+		return fmt.Sprintf("%s %s: %s", e.Method, e.Endpoint, status)
+	}
+	return fmt.Sprintf("%s %s: %s: %s", e.Method, e.Endpoint, status, e.Message)
+}
+
+// Is matches ErrNotFound, ErrInvalidURI and ErrPreconditionFailed by status code
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrInvalidURI:
+		return e.StatusCode == http.StatusBadRequest && e.sentURI
+	case ErrPreconditionFailed:
+		return e.StatusCode == http.StatusPreconditionFailed
+	}
+	return false
+}
+
+// newAPIError reads an error response into an *APIError
+func newAPIError(method, endpoint string, resp *http.Response) *APIError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	e := &APIError{
+		Method:     method,
+		Endpoint:   endpoint,
+		StatusCode: resp.StatusCode,
+		Body:       body,
+	}
+	var apiErr Error
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Message != "" {
+		e.Message = apiErr.Message
+	} else {
+		e.Message = strings.TrimSpace(string(body))
+	}
+	return e
+}
@@ -0,0 +1,93 @@
+package resolume
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newErrorServer(t *testing.T, status int, contentType, body string) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClientFromURL(server.URL+"/api/v1", WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewClientFromURL() error = %v", err)
+	}
+	return client
+}
+
+func TestAPIErrorPlainText(t *testing.T) {
+	client := newErrorServer(t, http.StatusPreconditionFailed, "text/plain", "the clip cannot be changed currently\n")
+
+	err := client.OpenClip(1, 2, "file:///C:/a.mov")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("OpenClip() error = %v, want *APIError", err)
+	}
+	if apiErr.Method != http.MethodPost || apiErr.Endpoint != "/composition/layers/1/clips/2/open" {
+		t.Errorf("request = %s %s", apiErr.Method, apiErr.Endpoint)
+	}
+	if apiErr.StatusCode != http.StatusPreconditionFailed {
+		t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, http.StatusPreconditionFailed)
+	}
+	if apiErr.Message != "the clip cannot be changed currently" {
+		t.Errorf("Message = %q", apiErr.Message)
+	}
+	if string(apiErr.Body) != "the clip cannot be changed currently\n" {
+		t.Errorf("Body = %q", apiErr.Body)
+	}
+	if !errors.Is(err, ErrPreconditionFailed) {
+		t.Error("errors.Is(err, ErrPreconditionFailed) = false, want true")
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Error("errors.Is(err, ErrNotFound) = true, want false")
+	}
+	want := "POST /composition/layers/1/clips/2/open: 412 Precondition Failed: the clip cannot be changed currently"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestAPIErrorJSON(t *testing.T) {
+	client := newErrorServer(t, http.StatusNotFound, "application/json", `{"code": 404, "message": "layer not found"}`)
+
+	_, err := client.GetLayer(9)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("GetLayer() error = %v, want *APIError", err)
+	}
+	if apiErr.Method != http.MethodGet || apiErr.Message != "layer not found" {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("errors.Is(err, ErrNotFound) = false, want true")
+	}
+}
+
+func TestAPIErrorInvalidURI(t *testing.T) {
+	client := newErrorServer(t, http.StatusBadRequest, "text/plain", "")
+
+	if err := client.AddEffect("effect:///video/Missing"); !errors.Is(err, ErrInvalidURI) {
+		t.Errorf("AddEffect() error = %v, want ErrInvalidURI", err)
+	}
+	// Paths of composition elements are URIs too
+	if err := client.AddLayer("/composition/layers/3"); !errors.Is(err, ErrInvalidURI) {
+		t.Errorf("AddLayer() error = %v, want ErrInvalidURI", err)
+	}
+	if err := client.MoveLayerToGroup(1, "/composition/layers/9"); !errors.Is(err, ErrInvalidURI) {
+		t.Errorf("MoveLayerToGroup() error = %v, want ErrInvalidURI", err)
+	}
+	if err := client.SetClipThumbnailFromFile(1, 1, "file:///C:/missing.png"); !errors.Is(err, ErrInvalidURI) {
+		t.Errorf("SetClipThumbnailFromFile() error = %v, want ErrInvalidURI", err)
+	}
+	if err := client.SetLayerEffectDisplayName(1, 2, "Glow"); errors.Is(err, ErrInvalidURI) {
+		t.Errorf("SetLayerEffectDisplayName() error = %v, want no ErrInvalidURI", err)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/FlowingSPDG/resolume-go/ws"
+)
+
+func main() {
+	// Create a new WebSocket client
+	client := ws.NewClient("localhost", "8080", ws.WithErrorHandler(func(err error) {
+		log.Println(err)
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+
+	// Wait for the initial composition
+	composition, err := client.WaitComposition(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Composition: %s (%d layers)\n", composition.Name.Value, len(composition.Layers))
+
+	// Follow the composition master
+	sub, err := client.SubscribePath("/composition/master")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	for update := range sub.C {
+		fmt.Printf("Master: %v\n", update.Value)
+	}
+}
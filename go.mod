@@ -1,3 +1,9 @@
 module github.com/FlowingSPDG/resolume-go
 
 go 1.21
+
+require (
+	github.com/gorilla/websocket v1.5.3
+	golang.org/x/image v0.18.0
+	gopkg.in/yaml.v3 v3.0.1
+)
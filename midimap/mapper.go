@@ -0,0 +1,315 @@
+// Package midimap maps MIDI control changes and notes to Resolume through the
+// client, beyond what Resolume's own shortcuts allow: a control change sets a
+// range parameter scaled into its Min and Max, absolutely, with soft takeover
+// or from an endless encoder, and a note connects a clip.
+//
+// MIDI ports are abstracted by the MIDIInput and MIDIOutput interfaces, so any
+// MIDI library can be plugged in and tests can feed a ChannelInput.
+package midimap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+
+	"github.com/FlowingSPDG/resolume-go"
+)
+
+// Mapper applies a Config to the MIDI messages it receives
+type Mapper struct {
+	client  *resolume.Client
+	config  *Config
+	output  MIDIOutput
+	onError func(Message, error)
+
+	mu      sync.Mutex
+	targets []*target
+}
+
+// param is the state of a mapped parameter, shared by its mappings
+type param struct {
+	id       int64
+	min, max float64
+	value    float64
+}
+
+// target is the state of a control change mapping
+type target struct {
+	*param
+	// pickedUp is whether a soft takeover control has reached the value
+	pickedUp bool
+	// last is the previous value of a soft takeover control not picked up
+	last float64
+}
+
+// Option configures a Mapper
+type Option func(*Mapper)
+
+// WithOutput sets the output receiving the feedback of the mappings with Feedback
+func WithOutput(output MIDIOutput) Option {
+	return func(m *Mapper) {
+		m.output = output
+	}
+}
+
+// WithErrorHandler sets a function called with every message Run failed to
+// apply. By default the errors are dropped so a failed call does not stop Run.
+func WithErrorHandler(fn func(Message, error)) Option {
+	return func(m *Mapper) {
+		m.onError = fn
+	}
+}
+
+// New creates a Mapper. Call Prepare before handling messages.
+func New(client *resolume.Client, config *Config, opts ...Option) *Mapper {
+	m := &Mapper{
+		client: client,
+		config: config,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Prepare resolves the parameter of every control change mapping and
+// retrieves its Min, Max and current value, and sends the feedback of the
+// current values. Paths are resolved against the current composition.
+func (m *Mapper) Prepare(ctx context.Context) error {
+	var resolver *resolume.Resolver
+	targets := make([]*target, len(m.config.Mappings))
+	params := map[int64]*param{}
+	var errs []error
+	for i, mapping := range m.config.Mappings {
+		if mapping.CC == nil {
+			continue
+		}
+		id := mapping.Parameter
+		if mapping.Path != "" {
+			if resolver == nil {
+				composition, err := m.client.GetCompositionContext(ctx)
+				if err != nil {
+					return err
+				}
+				resolver = resolume.NewResolver(m.client, composition)
+			}
+			p, _, err := resolver.Lookup(mapping.Path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("mapping %d: %w", i+1, err))
+				continue
+			}
+			id = p.ParameterID()
+		}
+		if params[id] == nil {
+			p, err := m.client.GetRangeParameterContext(ctx, id)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("mapping %d: %w", i+1, err))
+				continue
+			}
+			params[id] = &param{id: id, min: p.Min, max: p.Max, value: p.Value}
+		}
+		targets[i] = &target{param: params[id], last: math.NaN()}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.targets = targets
+	m.mu.Unlock()
+	for _, id := range m.Parameters() {
+		m.feedback(params[id])
+	}
+	return nil
+}
+
+// Parameters returns the ids of the mapped parameters, e.g. to subscribe to
+// their updates over WebSocket and pass them to Update
+func (m *Mapper) Parameters() []int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var ids []int64
+	seen := map[int64]bool{}
+	for _, t := range m.targets {
+		if t != nil && !seen[t.id] {
+			seen[t.id] = true
+			ids = append(ids, t.id)
+		}
+	}
+	return ids
+}
+
+// Run handles the messages of an input until it ends or ctx is done. It
+// returns nil when the input ends with io.EOF.
+func (m *Mapper) Run(ctx context.Context, input MIDIInput) error {
+	for {
+		msg, err := input.ReadMessage(ctx)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := m.Handle(ctx, msg); err != nil && m.onError != nil {
+			m.onError(msg, err)
+		}
+	}
+}
+
+// Handle applies every mapping matching a message
+func (m *Mapper) Handle(ctx context.Context, msg Message) error {
+	var errs []error
+	for i, mapping := range m.config.Mappings {
+		if !mapping.matches(msg) {
+			continue
+		}
+		var err error
+		if mapping.CC != nil {
+			err = m.controlChange(ctx, i, msg.Value)
+		} else if msg.Kind == NoteOn {
+			err = m.client.ConnectClipByIDContext(ctx, mapping.Clip, nil)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("mapping %d: %w", i+1, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// controlChange sets the parameter of the i-th mapping from a controller value
+func (m *Mapper) controlChange(ctx context.Context, i, value int) error {
+	mapping := m.config.Mappings[i]
+	m.mu.Lock()
+	if i >= len(m.targets) || m.targets[i] == nil {
+		m.mu.Unlock()
+		return fmt.Errorf("the mapping is not prepared")
+	}
+	t := m.targets[i]
+	v, ok := t.next(mapping, value)
+	if ok {
+		m.moved(t.param, v)
+		t.pickedUp = true
+		t.value = v
+	}
+	m.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	if err := m.client.SetParameterByIDContext(ctx, t.id, map[string]interface{}{"value": v}); err != nil {
+		return err
+	}
+	m.feedback(t.param)
+	return nil
+}
+
+// next returns the value a controller value sets, and false if it sets none
+func (t *target) next(mapping Mapping, value int) (float64, bool) {
+	switch mapping.Mode {
+	case Relative:
+		step := mapping.Step
+		if step == 0 {
+			step = 1.0 / 127
+		}
+		return t.clamp(t.value + float64(mapping.Encoding.delta(value))*step*(t.max-t.min)), true
+	case SoftTakeover:
+		v := t.scale(value)
+		if !t.pickedUp {
+			// Pick up when the control is close to the value or has crossed it
+			crossed := !math.IsNaN(t.last) && (t.last-t.value)*(v-t.value) <= 0
+			t.last = v
+			if math.Abs(v-t.value) > t.tolerance() && !crossed {
+				return 0, false
+			}
+			t.pickedUp = true
+		}
+		return v, true
+	default:
+		return t.scale(value), true
+	}
+}
+
+// scale scales a controller value into the parameter range
+func (p *param) scale(value int) float64 {
+	return p.min + (p.max-p.min)*float64(value)/127
+}
+
+// tolerance is the distance from the value within which a soft takeover
+// control picks it up, two controller steps
+func (p *param) tolerance() float64 {
+	return 2 * (p.max - p.min) / 127
+}
+
+func (p *param) clamp(v float64) float64 {
+	return math.Max(p.min, math.Min(p.max, v))
+}
+
+// Update records a value of a parameter changed outside the Mapper, e.g.
+// received over WebSocket, for the soft takeover and feedback of its mappings
+func (m *Mapper) Update(parameterID int64, value float64) {
+	m.mu.Lock()
+	var p *param
+	for _, t := range m.targets {
+		if t != nil && t.id == parameterID {
+			p = t.param
+			break
+		}
+	}
+	if p == nil {
+		m.mu.Unlock()
+		return
+	}
+	m.moved(p, value)
+	p.value = value
+	m.mu.Unlock()
+	m.feedback(p)
+}
+
+// moved drops the pickup of the soft takeover controls of a parameter moving
+// away from them. It is called with mu held.
+func (m *Mapper) moved(p *param, value float64) {
+	if math.Abs(value-p.value) <= p.tolerance() {
+		return
+	}
+	for _, t := range m.targets {
+		if t != nil && t.param == p {
+			t.pickedUp = false
+			t.last = math.NaN()
+		}
+	}
+}
+
+// feedback sends the value of a parameter to the controllers of its mappings
+// with Feedback
+func (m *Mapper) feedback(p *param) {
+	if m.output == nil {
+		return
+	}
+	m.mu.Lock()
+	value := 0
+	if p.max > p.min {
+		value = int(math.Round((p.value - p.min) / (p.max - p.min) * 127))
+	}
+	var messages []Message
+	for i, t := range m.targets {
+		mapping := m.config.Mappings[i]
+		if t == nil || t.param != p || !mapping.Feedback {
+			continue
+		}
+		channel := mapping.Channel
+		if channel == 0 {
+			channel = 1
+		}
+		messages = append(messages, Message{Kind: ControlChange, Channel: channel, Number: *mapping.CC, Value: value})
+	}
+	m.mu.Unlock()
+
+	for _, msg := range messages {
+		if err := m.output.WriteMessage(msg); err != nil && m.onError != nil {
+			m.onError(msg, fmt.Errorf("failed to send feedback: %w", err))
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package midimap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Mode is how a control change sets a parameter
+type Mode string
+
+// Control change modes
+const (
+	// Absolute scales the controller value from 0-127 into the parameter's Min and Max
+	Absolute Mode = "absolute"
+	// SoftTakeover is Absolute, but ignores the controller until it reaches
+	// the current value of the parameter, so a fader out of position does not
+	// make the parameter jump
+	SoftTakeover Mode = "soft"
+	// Relative treats the controller value as an increment from an endless encoder
+	Relative Mode = "relative"
+)
+
+// Encoding is how an endless encoder encodes its increments
+type Encoding string
+
+// Relative encodings
+const (
+	// TwosComplement sends 1 to 63 for increments and 127 down to 65 for decrements
+	TwosComplement Encoding = "twos-complement"
+	// SignMagnitude sends 1 to 63 for increments and 65 to 127 for decrements
+	SignMagnitude Encoding = "sign-magnitude"
+	// BinaryOffset sends 65 and above for increments and 63 and below for decrements
+	BinaryOffset Encoding = "binary-offset"
+)
+
+// Mapping maps a control change to a range parameter, or a note to a clip
+type Mapping struct {
+	// Channel is the MIDI channel from 1 to 16, or 0 for any channel
+	Channel int `json:"channel,omitempty" yaml:"channel,omitempty"`
+	// CC is the controller number of a control change mapping
+	CC *int `json:"cc,omitempty" yaml:"cc,omitempty"`
+	// Note is the note number of a note mapping
+	Note *int `json:"note,omitempty" yaml:"note,omitempty"`
+
+	// Parameter is the id of the range parameter of a control change mapping
+	Parameter int64 `json:"parameter,omitempty" yaml:"parameter,omitempty"`
+	// Path is the path of the parameter instead of its id, e.g. "layers/2/video/opacity"
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// Mode is Absolute by default
+	Mode Mode `json:"mode,omitempty" yaml:"mode,omitempty"`
+	// Encoding is the encoding of a Relative mapping, TwosComplement by default
+	Encoding Encoding `json:"encoding,omitempty" yaml:"encoding,omitempty"`
+	// Step is the fraction of the parameter range per encoder increment, 1/127 by default
+	Step float64 `json:"step,omitempty" yaml:"step,omitempty"`
+	// Feedback sends the parameter value back to the controller as a control change
+	Feedback bool `json:"feedback,omitempty" yaml:"feedback,omitempty"`
+
+	// Clip is the id of the clip a note mapping connects
+	Clip int64 `json:"clip,omitempty" yaml:"clip,omitempty"`
+}
+
+// Config is a list of mappings, loaded from YAML or JSON:
+//
+//	mappings:
+//	  - cc: 21
+//	    path: layers/1/master
+//	    mode: soft
+//	    feedback: true
+//	  - cc: 16
+//	    channel: 2
+//	    parameter: 1618311116471
+//	    mode: relative
+//	    encoding: twos-complement
+//	  - note: 36
+//	    clip: 1618311116470
+type Config struct {
+	Mappings []Mapping `json:"mappings" yaml:"mappings"`
+}
+
+// Parse parses a mapping file in YAML or JSON and validates it
+func Parse(data []byte) (*Config, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	var config Config
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("failed to parse MIDI mappings: %w", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Load reads and parses a mapping file
+func Load(name string) (*Config, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Validate checks that every mapping is complete and consistent
+func (c *Config) Validate() error {
+	var errs []error
+	for i, m := range c.Mappings {
+		if err := m.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("mapping %d: %w", i+1, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m Mapping) validate() error {
+	if m.Channel < 0 || m.Channel > 16 {
+		return fmt.Errorf("invalid channel %d", m.Channel)
+	}
+	switch {
+	case m.CC != nil && m.Note == nil:
+		if *m.CC < 0 || *m.CC > 127 {
+			return fmt.Errorf("invalid controller number %d", *m.CC)
+		}
+		if (m.Parameter == 0) == (m.Path == "") {
+			return fmt.Errorf("a control change mapping needs either a parameter id or a path")
+		}
+		switch m.Mode {
+		case "", Absolute, SoftTakeover:
+			if m.Encoding != "" {
+				return fmt.Errorf("an encoding only applies to relative mappings")
+			}
+		case Relative:
+			switch m.Encoding {
+			case "", TwosComplement, SignMagnitude, BinaryOffset:
+			default:
+				return fmt.Errorf("unknown encoding: %s", m.Encoding)
+			}
+		default:
+			return fmt.Errorf("unknown mode: %s", m.Mode)
+		}
+	case m.Note != nil && m.CC == nil:
+		if *m.Note < 0 || *m.Note > 127 {
+			return fmt.Errorf("invalid note number %d", *m.Note)
+		}
+		if m.Clip == 0 {
+			return fmt.Errorf("a note mapping needs a clip id")
+		}
+	default:
+		return fmt.Errorf("a mapping needs either a cc or a note")
+	}
+	return nil
+}
+
+// matches reports whether the mapping applies to a message
+func (m Mapping) matches(msg Message) bool {
+	if m.Channel != 0 && m.Channel != msg.Channel {
+		return false
+	}
+	switch msg.Kind {
+	case ControlChange:
+		return m.CC != nil && *m.CC == msg.Number
+	case NoteOn, NoteOff:
+		return m.Note != nil && *m.Note == msg.Number
+	}
+	return false
+}
+
+// delta decodes an encoder increment
+func (e Encoding) delta(value int) int {
+	switch e {
+	case SignMagnitude:
+		if value&0x40 != 0 {
+			return -(value & 0x3f)
+		}
+		return value & 0x3f
+	case BinaryOffset:
+		return value - 64
+	default:
+		if value < 64 {
+			return value
+		}
+		return value - 128
+	}
+}
@@ -0,0 +1,112 @@
+package midimap
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Kind is the type of a MIDI channel message
+type Kind int
+
+// Kinds of MIDI messages handled by a Mapper
+const (
+	NoteOff Kind = iota + 1
+	NoteOn
+	ControlChange
+)
+
+func (k Kind) String() string {
+	switch k {
+	case NoteOff:
+		return "note off"
+	case NoteOn:
+		return "note on"
+	case ControlChange:
+		return "control change"
+	default:
+		return fmt.Sprintf("kind %d", int(k))
+	}
+}
+
+// Message is a MIDI note or control change message
+type Message struct {
+	Kind Kind
+	// Channel is the MIDI channel, from 1 to 16
+	Channel int
+	// Number is the note or controller number, from 0 to 127
+	Number int
+	// Value is the velocity or controller value, from 0 to 127
+	Value int
+}
+
+// ParseMessage decodes a MIDI channel message. It returns false for other
+// messages, such as clock or system exclusive. A note on with a velocity of 0
+// is a note off.
+func ParseMessage(data []byte) (Message, bool) {
+	if len(data) < 3 {
+		return Message{}, false
+	}
+	m := Message{
+		Channel: int(data[0]&0x0f) + 1,
+		Number:  int(data[1] & 0x7f),
+		Value:   int(data[2] & 0x7f),
+	}
+	switch data[0] & 0xf0 {
+	case 0x80:
+		m.Kind = NoteOff
+	case 0x90:
+		m.Kind = NoteOn
+		if m.Value == 0 {
+			m.Kind = NoteOff
+		}
+	case 0xb0:
+		m.Kind = ControlChange
+	default:
+		return Message{}, false
+	}
+	return m, true
+}
+
+// Bytes encodes the message
+func (m Message) Bytes() []byte {
+	var status byte
+	switch m.Kind {
+	case NoteOff:
+		status = 0x80
+	case NoteOn:
+		status = 0x90
+	case ControlChange:
+		status = 0xb0
+	}
+	return []byte{status | byte(m.Channel-1)&0x0f, byte(m.Number) & 0x7f, byte(m.Value) & 0x7f}
+}
+
+// MIDIInput is a source of MIDI messages, such as a hardware port opened with
+// a MIDI library, or a ChannelInput in tests
+type MIDIInput interface {
+	// ReadMessage blocks until the next message is received or ctx is done
+	ReadMessage(ctx context.Context) (Message, error)
+}
+
+// MIDIOutput receives the feedback of a Mapper, e.g. to light LED rings
+type MIDIOutput interface {
+	WriteMessage(m Message) error
+}
+
+// ChannelInput is a MIDIInput reading from a channel. Closing the channel
+// ends the input with io.EOF.
+type ChannelInput chan Message
+
+// ReadMessage implements MIDIInput
+func (c ChannelInput) ReadMessage(ctx context.Context) (Message, error) {
+	select {
+	case <-ctx.Done():
+		return Message{}, ctx.Err()
+	case m, ok := <-c:
+		if !ok {
+			return Message{}, io.EOF
+		}
+		return m, nil
+	}
+}
@@ -0,0 +1,257 @@
+package midimap
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/FlowingSPDG/resolume-go"
+)
+
+const testConfig = `
+mappings:
+  - cc: 1
+    parameter: 5
+    feedback: true
+  - cc: 2
+    channel: 2
+    path: layers/1/master
+    mode: soft
+  - cc: 3
+    parameter: 5
+    mode: relative
+    step: 0.1
+  - note: 36
+    clip: 100
+`
+
+// newFakeResolume serves parameter 5 ranging from 0 to 10, the master of layer
+// 1 with id 7, and records the requests setting values
+func newFakeResolume(t *testing.T) (*resolume.Client, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/composition":
+			io.WriteString(w, `{"layers": [{"id": 10, "master": {"id": 7, "valuetype": "ParamRange", "value": 1, "min": 0, "max": 1}}]}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/parameter/by-id/5":
+			io.WriteString(w, `{"id": 5, "valuetype": "ParamRange", "value": 5, "min": 0, "max": 10}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/parameter/by-id/7":
+			io.WriteString(w, `{"id": 7, "valuetype": "ParamRange", "value": 1, "min": 0, "max": 1}`)
+		case r.Method == http.MethodGet:
+			http.NotFound(w, r)
+		default:
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			requests = append(requests, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/api/v1")+" "+strings.TrimSpace(string(body)))
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := resolume.NewClientFromURL(server.URL+"/api/v1", resolume.WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewClientFromURL() error = %v", err)
+	}
+	return client, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		sent := requests
+		requests = nil
+		return sent
+	}
+}
+
+// recordingOutput records feedback messages
+type recordingOutput struct {
+	messages []Message
+}
+
+func (o *recordingOutput) WriteMessage(m Message) error {
+	o.messages = append(o.messages, m)
+	return nil
+}
+
+// values returns the values set by PUT requests
+func values(t *testing.T, requests []string) []float64 {
+	t.Helper()
+	var vs []float64
+	for _, r := range requests {
+		var body struct {
+			Value float64 `json:"value"`
+		}
+		if err := json.Unmarshal([]byte(r[strings.Index(r, "{"):]), &body); err != nil {
+			t.Fatalf("Unexpected request %s", r)
+		}
+		vs = append(vs, body.Value)
+	}
+	return vs
+}
+
+func TestParseMessage(t *testing.T) {
+	tests := []struct {
+		data []byte
+		want Message
+		ok   bool
+	}{
+		{[]byte{0xb1, 21, 64}, Message{Kind: ControlChange, Channel: 2, Number: 21, Value: 64}, true},
+		{[]byte{0x90, 36, 100}, Message{Kind: NoteOn, Channel: 1, Number: 36, Value: 100}, true},
+		{[]byte{0x90, 36, 0}, Message{Kind: NoteOff, Channel: 1, Number: 36}, true},
+		{[]byte{0xf8, 0, 0}, Message{}, false},
+		{[]byte{0xb0, 1}, Message{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseMessage(tt.data)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseMessage(% x) = %+v, %v, want %+v, %v", tt.data, got, ok, tt.want, tt.ok)
+		}
+	}
+	if got := (Message{Kind: ControlChange, Channel: 16, Number: 7, Value: 127}).Bytes(); !reflect.DeepEqual(got, []byte{0xbf, 7, 127}) {
+		t.Errorf("Bytes() = % x", got)
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	config, err := Parse([]byte(testConfig))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(config.Mappings) != 4 || *config.Mappings[3].Note != 36 || config.Mappings[1].Mode != SoftTakeover {
+		t.Errorf("Parse() = %+v", config)
+	}
+
+	for _, data := range []string{
+		`{"mappings": [{"cc": 1}]}`,
+		`{"mappings": [{"cc": 1, "note": 2, "clip": 3}]}`,
+		`{"mappings": [{"cc": 128, "parameter": 1}]}`,
+		`{"mappings": [{"cc": 1, "parameter": 1, "mode": "jump"}]}`,
+		`{"mappings": [{"cc": 1, "parameter": 1, "encoding": "binary-offset"}]}`,
+		`{"mappings": [{"note": 1}]}`,
+		`{"mappings": [{"note": 1, "clip": 2, "channel": 17}]}`,
+		`{"mappings": [{"note": 1, "clips": 2}]}`,
+	} {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("Parse(%s) error = nil", data)
+		}
+	}
+}
+
+func TestEncodings(t *testing.T) {
+	tests := []struct {
+		encoding Encoding
+		value    int
+		want     int
+	}{
+		{TwosComplement, 1, 1}, {TwosComplement, 127, -1}, {TwosComplement, 65, -63},
+		{SignMagnitude, 3, 3}, {SignMagnitude, 67, -3},
+		{BinaryOffset, 66, 2}, {BinaryOffset, 62, -2},
+	}
+	for _, tt := range tests {
+		if got := tt.encoding.delta(tt.value); got != tt.want {
+			t.Errorf("%s.delta(%d) = %d, want %d", tt.encoding, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestMapper(t *testing.T) {
+	client, requests := newFakeResolume(t)
+	config, _ := Parse([]byte(testConfig))
+	output := &recordingOutput{}
+	mapper := New(client, config, WithOutput(output))
+	ctx := context.Background()
+
+	if err := mapper.Prepare(ctx); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	if ids := mapper.Parameters(); !reflect.DeepEqual(ids, []int64{5, 7}) {
+		t.Errorf("Parameters() = %v", ids)
+	}
+
+	// Absolute: 127 is the maximum of 10, with feedback
+	mapper.Handle(ctx, Message{Kind: ControlChange, Channel: 1, Number: 1, Value: 127})
+	if got := values(t, requests()); !reflect.DeepEqual(got, []float64{10}) {
+		t.Errorf("Absolute set %v, want [10]", got)
+	}
+	want := []Message{
+		{Kind: ControlChange, Channel: 1, Number: 1, Value: 64},
+		{Kind: ControlChange, Channel: 1, Number: 1, Value: 127},
+	}
+	if !reflect.DeepEqual(output.messages, want) {
+		t.Errorf("Feedback = %+v, want %+v", output.messages, want)
+	}
+
+	// Relative: two decrements of a tenth of the range from 10
+	mapper.Handle(ctx, Message{Kind: ControlChange, Channel: 1, Number: 3, Value: 126})
+	if got := values(t, requests()); len(got) != 1 || got[0] < 7.99 || got[0] > 8.01 {
+		t.Errorf("Relative set %v, want [8]", got)
+	}
+
+	// Soft takeover: the master is at 1, so low values are ignored until the
+	// fader reaches it, and other channels do not match
+	for _, v := range []int{0, 60, 120} {
+		mapper.Handle(ctx, Message{Kind: ControlChange, Channel: 2, Number: 2, Value: v})
+	}
+	mapper.Handle(ctx, Message{Kind: ControlChange, Channel: 1, Number: 2, Value: 127})
+	if got := requests(); len(got) != 0 {
+		t.Errorf("Soft takeover sent %v before picking up", got)
+	}
+	mapper.Handle(ctx, Message{Kind: ControlChange, Channel: 2, Number: 2, Value: 126})
+	mapper.Handle(ctx, Message{Kind: ControlChange, Channel: 2, Number: 2, Value: 0})
+	if got := values(t, requests()); len(got) != 2 || got[1] != 0 {
+		t.Errorf("Soft takeover set %v, want the fader values after picking up", got)
+	}
+
+	// An outside change drops the pickup
+	mapper.Update(7, 1)
+	mapper.Handle(ctx, Message{Kind: ControlChange, Channel: 2, Number: 2, Value: 10})
+	if got := requests(); len(got) != 0 {
+		t.Errorf("Soft takeover sent %v after an outside change", got)
+	}
+
+	// Notes connect clips on note on only
+	mapper.Handle(ctx, Message{Kind: NoteOn, Channel: 1, Number: 36, Value: 100})
+	mapper.Handle(ctx, Message{Kind: NoteOff, Channel: 1, Number: 36})
+	if got := requests(); !reflect.DeepEqual(got, []string{"POST /composition/clips/by-id/100/connect null"}) {
+		t.Errorf("Note sent %v", got)
+	}
+}
+
+func TestMapperRun(t *testing.T) {
+	client, requests := newFakeResolume(t)
+	config, _ := Parse([]byte(`{"mappings": [{"cc": 1, "parameter": 5}, {"cc": 2, "parameter": 6}]}`))
+	var failed []Message
+	mapper := New(client, config, WithErrorHandler(func(m Message, err error) {
+		failed = append(failed, m)
+	}))
+
+	if err := mapper.Prepare(context.Background()); err == nil {
+		t.Fatal("Prepare() with a missing parameter error = nil")
+	}
+	config.Mappings = config.Mappings[:1]
+	if err := mapper.Prepare(context.Background()); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	input := make(ChannelInput, 2)
+	input <- Message{Kind: ControlChange, Channel: 1, Number: 1, Value: 0}
+	input <- Message{Kind: ControlChange, Channel: 1, Number: 1, Value: 127}
+	close(input)
+	if err := mapper.Run(context.Background(), input); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got := values(t, requests()); !reflect.DeepEqual(got, []float64{0, 10}) {
+		t.Errorf("Run() set %v, want [0 10]", got)
+	}
+	if len(failed) != 0 {
+		t.Errorf("Run() failed for %v", failed)
+	}
+}
@@ -0,0 +1,188 @@
+// Code generated by resolume-gen from swagger.yaml. DO NOT EDIT.
+
+package resolume
+
+// operations maps each operationId in swagger.yaml to its Client method
+var operations = map[string]string{
+	"add_composition_effect_offset":                "AddEffectAtOffset",
+	"add_effect_clip":                              "AddEffectToClip",
+	"add_effect_clip_by_id":                        "AddEffectToClipByID",
+	"add_effect_composition":                       "AddEffect",
+	"add_effect_layer_by_id":                       "AddEffectToLayerByID",
+	"add_effect_layergroup":                        "AddEffectToLayerGroup",
+	"add_effect_layergroup_by_id":                  "AddEffectToLayerGroupByID",
+	"add_effect_selected_clip":                     "AddEffectToSelectedClip",
+	"add_effect_selected_layer":                    "AddEffectToSelectedLayer",
+	"add_effect_selected_layergroup":               "AddEffectToSelectedLayerGroup",
+	"add_layer_effect":                             "AddEffectToLayer",
+	"clip_add_effect_offset":                       "AddEffectToClipAtOffset",
+	"clip_add_effect_offset_by_id":                 "AddEffectToClipByIDAtOffset",
+	"clip_clear":                                   "ClearClip",
+	"clip_clear_by_id":                             "ClearClipByID",
+	"clip_connect":                                 "ConnectClip",
+	"clip_connect_by_id":                           "ConnectClipByID",
+	"clip_open":                                    "OpenClip",
+	"clip_open_by_id":                              "OpenClipByID",
+	"clip_openfile":                                "OpenFileClip",
+	"clip_openfile_by_id":                          "OpenFileClipByID",
+	"clip_select":                                  "SelectClip",
+	"clip_select_by_id":                            "SelectClipByID",
+	"column_connect":                               "ConnectColumn",
+	"column_connect_by_id":                         "ConnectColumnByID",
+	"column_select":                                "SelectColumn",
+	"column_select_by_id":                          "SelectColumnByID",
+	"composition_action":                           "CompositionAction",
+	"composition_add_column":                       "AddColumn",
+	"composition_add_deck":                         "AddDeck",
+	"composition_add_layer":                        "AddLayer",
+	"composition_add_layer_to_group":               "AddLayerToGroup",
+	"composition_add_layer_to_group_by_id":         "AddLayerToGroupByID",
+	"composition_add_layer_to_selected_group":      "AddLayerToSelectedGroup",
+	"composition_add_layergroup":                   "AddLayerGroup",
+	"composition_close_deck_by_id":                 "CloseDeckByID",
+	"composition_disconnect_all":                   "DisconnectAllClips",
+	"composition_duplicate_column":                 "DuplicateColumn",
+	"composition_duplicate_column_by_id":           "DuplicateColumnByID",
+	"composition_duplicate_deck":                   "DuplicateDeck",
+	"composition_duplicate_deck_by_id":             "DuplicateDeckByID",
+	"composition_duplicate_layer":                  "DuplicateLayer",
+	"composition_duplicate_layer_by_id":            "DuplicateLayerByID",
+	"composition_duplicate_layer_group":            "DuplicateLayerGroup",
+	"composition_duplicate_layer_group_by_id":      "DuplicateLayerGroupByID",
+	"composition_duplicate_selected_layer":         "DuplicateSelectedLayer",
+	"composition_duplicate_selected_layer_group":   "DuplicateSelectedLayerGroup",
+	"composition_move_layer_to_group":              "MoveLayerToGroup",
+	"composition_move_layer_to_group_by_id":        "MoveLayerToGroupByID",
+	"composition_move_layer_to_selected_group":     "MoveLayerToSelectedGroup",
+	"composition_open_deck_by_id":                  "OpenDeckByID",
+	"deck_select":                                  "SelectDeck",
+	"deck_select_by_id":                            "SelectDeckByID",
+	"delete_clip_effect":                           "DeleteClipEffect",
+	"delete_clip_effect_by_id":                     "DeleteClipEffectByID",
+	"delete_column":                                "DeleteColumn",
+	"delete_composition_effect":                    "DeleteEffect",
+	"delete_deck":                                  "DeleteDeck",
+	"delete_deck_by_id":                            "DeleteDeckByID",
+	"delete_layer":                                 "DeleteLayer",
+	"delete_layer_by_id":                           "DeleteLayerByID",
+	"delete_layer_effect":                          "DeleteLayerEffect",
+	"delete_layer_effect_by_id":                    "DeleteLayerEffectByID",
+	"delete_layer_group":                           "DeleteLayerGroup",
+	"delete_layer_group_effect":                    "DeleteLayerGroupEffect",
+	"delete_layer_group_effect_by_id":              "DeleteLayerGroupEffectByID",
+	"delete_layergroup_by_id":                      "DeleteLayerGroupByID",
+	"delete_selected_clip_effect":                  "DeleteSelectedClipEffect",
+	"delete_selected_layer_effect":                 "DeleteSelectedLayerEffect",
+	"delete_selected_layer_group":                  "DeleteSelectedLayerGroup",
+	"delete_selected_layer_group_effect":           "DeleteSelectedLayerGroupEffect",
+	"effect_set_display_name_by_id":                "SetEffectDisplayName",
+	"get_clip_by_id":                               "GetClipByID",
+	"get_clip_by_position":                         "GetClipByPosition",
+	"get_clip_thumbnail_by_id_and_timestamp":       "GetClipThumbnailByIDAndTimestamp",
+	"get_clip_thumbnail_by_position_and_timestamp": "GetClipThumbnailByTimestamp",
+	"get_column":                                   "GetColumn",
+	"get_column_by_id":                             "GetColumnByID",
+	"get_deck":                                     "GetDeck",
+	"get_deck_by_id":                               "GetDeckByID",
+	"get_effect_by_id":                             "GetEffectByID",
+	"get_effects":                                  "GetEffects",
+	"get_last_clip_thumbnail_by_timestamp":         "GetSelectedClipThumbnailByTimestamp",
+	"get_layer":                                    "GetLayer",
+	"get_layer_by_id":                              "GetLayerByID",
+	"get_layergroup":                               "GetLayerGroup",
+	"get_layergroup_by_id":                         "GetLayerGroupByID",
+	"get_layergroup_column":                        "GetLayerGroupColumn",
+	"get_parameter_by_id":                          "GetParameterByID",
+	"get_product":                                  "GetProduct",
+	"get_sources":                                  "GetSources",
+	"layer_add_effect_by_id_offset":                "AddEffectToLayerByIDAtOffset",
+	"layer_add_effect_offset":                      "AddEffectToLayerAtOffset",
+	"layer_clear":                                  "ClearLayer",
+	"layer_clear_by_id":                            "ClearLayerByID",
+	"layer_clear_clips":                            "ClearLayerClips",
+	"layer_clear_clips_by_id":                      "ClearLayerClipsByID",
+	"layer_group_clear":                            "ClearLayerGroup",
+	"layer_group_clear_by_id":                      "ClearLayerGroupByID",
+	"layer_group_column_connect":                   "ConnectLayerGroupColumn",
+	"layer_group_column_select":                    "SelectLayerGroupColumn",
+	"layer_select":                                 "SelectLayer",
+	"layer_select_by_id":                           "SelectLayerByID",
+	"layergroup_add_effect_by_id_offset":           "AddEffectToLayerGroupByIDAtOffset",
+	"layergroup_add_effect_offset":                 "AddEffectToLayerGroupAtOffset",
+	"layergroup_select":                            "SelectLayerGroup",
+	"layergroup_select_by_id":                      "SelectLayerGroupByID",
+	"list_clip_thumbnail_by_id":                    "GetClipThumbnailByID",
+	"list_clip_thumbnail_by_position":              "GetClipThumbnail",
+	"list_composition":                             "GetComposition",
+	"list_dummy_thumbnail":                         "GetDummyThumbnail",
+	"list_selected_clip":                           "GetSelectedClip",
+	"list_selected_clip_thumbnail":                 "GetSelectedClipThumbnail",
+	"list_selected_layer":                          "GetSelectedLayer",
+	"list_selected_layergroup":                     "GetSelectedLayerGroup",
+	"move_effect_clip":                             "MoveEffectToClip",
+	"move_effect_clip_by_id":                       "MoveEffectToClipByID",
+	"move_effect_clip_offset":                      "MoveEffectToClipOffset",
+	"move_effect_clip_offset_by_id":                "MoveEffectToClipByIDOffset",
+	"move_effect_composition":                      "MoveEffect",
+	"move_effect_composition_offset":               "MoveEffectToOffset",
+	"move_effect_layer":                            "MoveEffectToLayer",
+	"move_effect_layer_by_id":                      "MoveEffectToLayerByID",
+	"move_effect_layer_group":                      "MoveEffectToLayerGroup",
+	"move_effect_layer_group_by_id":                "MoveEffectToLayerGroupByID",
+	"move_effect_layer_group_offset":               "MoveEffectToLayerGroupOffset",
+	"move_effect_layer_group_offset_by_id":         "MoveEffectToLayerGroupByIDOffset",
+	"move_effect_layer_offset":                     "MoveEffectToLayerOffset",
+	"move_effect_layer_offset_by_id":               "MoveEffectToLayerByIDOffset",
+	"remove_column_by_id":                          "DeleteColumnByID",
+	"replace_clip_by_id":                           "ReplaceClipByID",
+	"replace_clip_by_position":                     "ReplaceClipByPosition",
+	"replace_column":                               "ReplaceColumn",
+	"replace_column_by_id":                         "ReplaceColumnByID",
+	"replace_composition":                          "ReplaceComposition",
+	"replace_deck":                                 "ReplaceDeck",
+	"replace_deck_by_id":                           "ReplaceDeckByID",
+	"replace_effect_by_id":                         "ReplaceEffectByID",
+	"replace_layer":                                "ReplaceLayer",
+	"replace_layer_by_id":                          "ReplaceLayerByID",
+	"replace_layergroup":                           "ReplaceLayerGroup",
+	"replace_layergroup_by_id":                     "ReplaceLayerGroupByID",
+	"replace_layergroup_column":                    "ReplaceLayerGroupColumn",
+	"replace_selected_clip":                        "ReplaceSelectedClip",
+	"replace_selected_layer":                       "ReplaceSelectedLayer",
+	"replace_selected_layergroup":                  "ReplaceSelectedLayerGroup",
+	"reset_clip_param":                             "ResetClipParameter",
+	"reset_clip_param_by_id":                       "ResetClipParameterByID",
+	"reset_column_param":                           "ResetColumnParameter",
+	"reset_column_param_by_id":                     "ResetColumnParameterByID",
+	"reset_composition_param":                      "ResetCompositionParameter",
+	"reset_deck_param":                             "ResetDeckParameter",
+	"reset_deck_param_by_id":                       "ResetDeckParameterByID",
+	"reset_layer_group_param":                      "ResetLayerGroupParameter",
+	"reset_layer_group_param_by_id":                "ResetLayerGroupParameterByID",
+	"reset_layer_param":                            "ResetLayerParameter",
+	"reset_layer_param_by_id":                      "ResetLayerParameterByID",
+	"reset_parameter_by_id":                        "ResetParameterByID",
+	"reset_selected_clip_param":                    "ResetSelectedClipParameter",
+	"reset_selected_layer_group_param":             "ResetSelectedLayerGroupParameter",
+	"reset_selected_layer_param":                   "ResetSelectedLayerParameter",
+	"revert_clip_thumbnail_by_id":                  "ResetClipThumbnailByID",
+	"revert_clip_thumbnail_by_position":            "ResetClipThumbnail",
+	"revert_selected_clip_thumbnail":               "ResetSelectedClipThumbnail",
+	"selected_clip_add_effect_offset":              "AddEffectToSelectedClipAtOffset",
+	"selected_clip_clear":                          "ClearSelectedClip",
+	"selected_clip_connect":                        "ConnectSelectedClip",
+	"selected_clip_open":                           "OpenSelectedClip",
+	"selected_clip_openfile":                       "OpenFileSelectedClip",
+	"selected_layer_add_effect_offset":             "AddEffectToSelectedLayerAtOffset",
+	"selected_layer_clear":                         "ClearSelectedLayer",
+	"selected_layer_clear_clips":                   "ClearSelectedLayerClips",
+	"selected_layer_group_clear":                   "ClearSelectedLayerGroup",
+	"selected_layergroup_add_effect_offset":        "AddEffectToSelectedLayerGroupAtOffset",
+	"set_clip_thumbnail_by_id":                     "SetClipThumbnailByID",
+	"set_clip_thumbnail_by_position":               "SetClipThumbnail",
+	"set_parameter_by_id":                          "SetParameterByID",
+	"set_selected_clip_thumbnail":                  "SetSelectedClipThumbnail",
+	"video_effect_set_display_name_clip":           "SetClipEffectDisplayName",
+	"video_effect_set_display_name_layer":          "SetLayerEffectDisplayName",
+	"video_effect_set_display_name_layer_group":    "SetLayerGroupEffectDisplayName",
+}
@@ -0,0 +1,81 @@
+package resolume
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the http.Client used for requests. A nil client is ignored.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient == nil {
+			return
+		}
+		c.httpClient = httpClient
+	}
+}
+
+// WithTransport sets the http.RoundTripper used for requests
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		c.transport = transport
+	}
+}
+
+// WithTimeout sets a timeout for each request, including reading the response body
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithScheme sets the URL scheme, e.g. "https"
+func WithScheme(scheme string) Option {
+	return func(c *Client) {
+		c.baseURL.Scheme = scheme
+	}
+}
+
+// WithBasePath sets the API path prefix, "/api/v1" by default
+func WithBasePath(basePath string) Option {
+	return func(c *Client) {
+		c.baseURL.Path = basePath
+	}
+}
+
+// WithBaseURL sets the full base URL including the API path prefix. A nil URL
+// is ignored.
+func WithBaseURL(baseURL *url.URL) Option {
+	return func(c *Client) {
+		if baseURL == nil {
+			return
+		}
+		u := *baseURL
+		c.baseURL = &u
+	}
+}
+
+// applyOptions applies opts to the client and finalizes its http.Client
+func (c *Client) applyOptions(opts []Option) {
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.transport == nil && c.timeout == 0 {
+		return
+	}
+
+	// Copy the http.Client so a shared client passed by the caller is not mutated
+	httpClient := *c.httpClient
+	if c.transport != nil {
+		httpClient.Transport = c.transport
+	}
+	if c.timeout != 0 {
+		httpClient.Timeout = c.timeout
+	}
+	c.httpClient = &httpClient
+}
@@ -0,0 +1,100 @@
+package resolume
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type recordingTransport struct {
+	requests []*http.Request
+	next     http.RoundTripper
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests = append(t.requests, req)
+	return t.next.RoundTrip(req)
+}
+
+func TestNewClientOptions(t *testing.T) {
+	client, err := NewClient("resolume.local", "443", WithScheme("https"), WithBasePath("/proxy/api/v1"), WithTimeout(3*time.Second))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if got, want := client.url("/product"), "https://resolume.local:443/proxy/api/v1/product"; got != want {
+		t.Errorf("url() = %s, want %s", got, want)
+	}
+	if client.httpClient.Timeout != 3*time.Second {
+		t.Errorf("Expected timeout 3s, got %s", client.httpClient.Timeout)
+	}
+}
+
+func TestNilOptions(t *testing.T) {
+	client, err := NewClient("localhost", "8080", WithHTTPClient(nil), WithBaseURL(nil), WithTimeout(time.Second))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if got, want := client.url("/product"), "http://localhost:8080/api/v1/product"; got != want {
+		t.Errorf("url() = %s, want %s", got, want)
+	}
+	if client.httpClient == nil || client.httpClient.Timeout != time.Second {
+		t.Errorf("Expected the default http.Client with a timeout, got %v", client.httpClient)
+	}
+}
+
+func TestNewClientFromURL(t *testing.T) {
+	tests := []struct {
+		rawURL  string
+		want    string
+		wantErr bool
+	}{
+		{rawURL: "http://localhost:8080", want: "http://localhost:8080/api/v1/product"},
+		{rawURL: "https://example.com/resolume/api/v1", want: "https://example.com/resolume/api/v1/product"},
+		{rawURL: "localhost:8080", wantErr: true},
+		{rawURL: "://bad", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		client, err := NewClientFromURL(tt.rawURL)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NewClientFromURL(%q) error = %v, wantErr %v", tt.rawURL, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if got := client.url("/product"); got != tt.want {
+			t.Errorf("NewClientFromURL(%q) url() = %s, want %s", tt.rawURL, got, tt.want)
+		}
+	}
+}
+
+func TestWithTransport(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name": "Avenue"}`))
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	transport := &recordingTransport{next: http.DefaultTransport}
+	shared := &http.Client{}
+	client, err := NewClient(serverURL.Hostname(), serverURL.Port(), WithHTTPClient(shared), WithTransport(transport))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.GetProduct(); err != nil {
+		t.Fatalf("GetProduct() error = %v", err)
+	}
+	if len(transport.requests) != 1 {
+		t.Errorf("Expected 1 request through transport, got %d", len(transport.requests))
+	}
+	if shared.Transport != nil {
+		t.Error("WithTransport mutated the shared http.Client")
+	}
+}
@@ -0,0 +1,246 @@
+package osc
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/FlowingSPDG/resolume-go"
+)
+
+// DefaultRoutes are the routes of a new Server, from address pattern to action
+var DefaultRoutes = map[string]string{
+	"/composition/disconnect-all": "disconnect_all",
+	"/composition/undo":           "undo",
+	"/composition/redo":           "redo",
+	"/column/*/connect":           "connect_column",
+	"/column/*/name":              "rename_column",
+	"/layer/*/select":             "select_layer",
+	"/layer/*/clear":              "clear_layer",
+	"/layer/*/name":               "rename_layer",
+	"/layer/*/clip/*/connect":     "connect_clip",
+	"/layer/*/clip/*/select":      "select_clip",
+	"/layer/*/clip/*/open":        "open_clip",
+	"/layer/*/clip/*/name":        "rename_clip",
+	"/clip/*/connect":             "connect_clip_by_id",
+	"/clip/*/open":                "open_clip_by_id",
+	"/clip/*/name":                "rename_clip_by_id",
+	"/clip/*/thumbnail":           "clip_thumbnail_by_id",
+	"/parameter/*/set":            "set_parameter",
+	"/parameter/*/get":            "get_parameter",
+}
+
+// Actions are the handlers that can be routed by name. Their arguments are
+// the wildcard segments of the address followed by the message arguments:
+//
+//	disconnect_all, undo, redo
+//	connect_column       column
+//	rename_column        column, name
+//	select_layer         layer
+//	clear_layer          layer
+//	rename_layer         layer, name
+//	connect_clip         layer, column
+//	select_clip          layer, column
+//	open_clip            layer, column, uri
+//	rename_clip          layer, column, name
+//	connect_clip_by_id   clip id
+//	open_clip_by_id      clip id, uri
+//	rename_clip_by_id    clip id, name
+//	clip_thumbnail_by_id clip id; replies with the image as a blob
+//	set_parameter        parameter id, value
+//	get_parameter        parameter id; replies with the value
+//
+// Actions without a value argument ignore button releases, see Request.Released.
+var Actions = map[string]Handler{
+	"disconnect_all": trigger(func(ctx context.Context, c *resolume.Client, req *Request) error {
+		return c.DisconnectAllClipsContext(ctx)
+	}),
+	"undo": trigger(func(ctx context.Context, c *resolume.Client, req *Request) error {
+		return c.CompositionActionContext(ctx, "undo")
+	}),
+	"redo": trigger(func(ctx context.Context, c *resolume.Client, req *Request) error {
+		return c.CompositionActionContext(ctx, "redo")
+	}),
+	"connect_column": trigger(func(ctx context.Context, c *resolume.Client, req *Request) error {
+		column, err := req.VarInt(0)
+		if err != nil {
+			return err
+		}
+		return c.ConnectColumnContext(ctx, column, nil)
+	}),
+	"rename_column": func(ctx context.Context, c *resolume.Client, req *Request) ([]interface{}, error) {
+		column, err := req.VarInt(0)
+		if err != nil {
+			return nil, err
+		}
+		name, err := req.StringArg(0)
+		if err != nil {
+			return nil, err
+		}
+		return nil, c.UpdateColumnContext(ctx, column, resolume.PatchColumn().Name(name))
+	},
+	"select_layer": trigger(func(ctx context.Context, c *resolume.Client, req *Request) error {
+		layer, err := req.VarInt(0)
+		if err != nil {
+			return err
+		}
+		return c.SelectLayerContext(ctx, layer)
+	}),
+	"clear_layer": trigger(func(ctx context.Context, c *resolume.Client, req *Request) error {
+		layer, err := req.VarInt(0)
+		if err != nil {
+			return err
+		}
+		return c.ClearLayerContext(ctx, layer)
+	}),
+	"rename_layer": func(ctx context.Context, c *resolume.Client, req *Request) ([]interface{}, error) {
+		layer, err := req.VarInt(0)
+		if err != nil {
+			return nil, err
+		}
+		name, err := req.StringArg(0)
+		if err != nil {
+			return nil, err
+		}
+		return nil, c.UpdateLayerContext(ctx, layer, resolume.PatchLayer().Name(name))
+	},
+	"connect_clip": trigger(func(ctx context.Context, c *resolume.Client, req *Request) error {
+		layer, column, err := clipPosition(req)
+		if err != nil {
+			return err
+		}
+		return c.ConnectClipContext(ctx, layer, column, nil)
+	}),
+	"select_clip": trigger(func(ctx context.Context, c *resolume.Client, req *Request) error {
+		layer, column, err := clipPosition(req)
+		if err != nil {
+			return err
+		}
+		return c.SelectClipContext(ctx, layer, column)
+	}),
+	"open_clip": func(ctx context.Context, c *resolume.Client, req *Request) ([]interface{}, error) {
+		layer, column, err := clipPosition(req)
+		if err != nil {
+			return nil, err
+		}
+		uri, err := req.StringArg(0)
+		if err != nil {
+			return nil, err
+		}
+		return nil, c.OpenClipContext(ctx, layer, column, uri)
+	},
+	"rename_clip": func(ctx context.Context, c *resolume.Client, req *Request) ([]interface{}, error) {
+		layer, column, err := clipPosition(req)
+		if err != nil {
+			return nil, err
+		}
+		name, err := req.StringArg(0)
+		if err != nil {
+			return nil, err
+		}
+		return nil, c.UpdateClipContext(ctx, layer, column, resolume.PatchClip().Name(name))
+	},
+	"connect_clip_by_id": trigger(func(ctx context.Context, c *resolume.Client, req *Request) error {
+		id, err := req.VarInt(0)
+		if err != nil {
+			return err
+		}
+		return c.ConnectClipByIDContext(ctx, id, nil)
+	}),
+	"open_clip_by_id": func(ctx context.Context, c *resolume.Client, req *Request) ([]interface{}, error) {
+		id, err := req.VarInt(0)
+		if err != nil {
+			return nil, err
+		}
+		uri, err := req.StringArg(0)
+		if err != nil {
+			return nil, err
+		}
+		return nil, c.OpenClipByIDContext(ctx, id, uri)
+	},
+	"rename_clip_by_id": func(ctx context.Context, c *resolume.Client, req *Request) ([]interface{}, error) {
+		id, err := req.VarInt(0)
+		if err != nil {
+			return nil, err
+		}
+		name, err := req.StringArg(0)
+		if err != nil {
+			return nil, err
+		}
+		return nil, c.UpdateClipByIDContext(ctx, id, resolume.PatchClip().Name(name))
+	},
+	"clip_thumbnail_by_id": clipThumbnail,
+	"set_parameter": func(ctx context.Context, c *resolume.Client, req *Request) ([]interface{}, error) {
+		id, err := req.VarInt(0)
+		if err != nil {
+			return nil, err
+		}
+		value, err := req.Arg(0)
+		if err != nil {
+			return nil, err
+		}
+		return nil, c.SetParameterByIDContext(ctx, id, map[string]interface{}{"value": value})
+	},
+	"get_parameter": func(ctx context.Context, c *resolume.Client, req *Request) ([]interface{}, error) {
+		id, err := req.VarInt(0)
+		if err != nil {
+			return nil, err
+		}
+		p, err := c.GetParameterContext(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		value, ok := resolume.ParameterValue(p)
+		if !ok {
+			return nil, fmt.Errorf("parameter %d is a %s without a value", id, p.ParameterType())
+		}
+		return []interface{}{value}, nil
+	},
+}
+
+// trigger adapts a call without results to a Handler ignoring button releases
+func trigger(call func(ctx context.Context, c *resolume.Client, req *Request) error) Handler {
+	return func(ctx context.Context, c *resolume.Client, req *Request) ([]interface{}, error) {
+		if req.Released() {
+			return nil, nil
+		}
+		return nil, call(ctx, c, req)
+	}
+}
+
+// clipPosition returns the layer and column of a clip from the address
+func clipPosition(req *Request) (int64, int64, error) {
+	layer, err := req.VarInt(0)
+	if err != nil {
+		return 0, 0, err
+	}
+	column, err := req.VarInt(1)
+	if err != nil {
+		return 0, 0, err
+	}
+	return layer, column, nil
+}
+
+// maxThumbnailSize leaves room in a UDP packet for the address and type tags
+const maxThumbnailSize = maxPacketSize - 1024
+
+// clipThumbnail replies with the thumbnail of a clip, if it fits in a packet
+func clipThumbnail(ctx context.Context, c *resolume.Client, req *Request) ([]interface{}, error) {
+	id, err := req.VarInt(0)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.GetClipThumbnailByIDContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	image, err := io.ReadAll(io.LimitReader(body, maxThumbnailSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(image) > maxThumbnailSize {
+		return nil, fmt.Errorf("the thumbnail of clip %d is too large for an OSC packet", id)
+	}
+	return []interface{}{image}, nil
+}
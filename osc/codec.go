@@ -0,0 +1,282 @@
+package osc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Message is an OSC message. Decoded arguments are int32 (i), float32 (f),
+// string (s and S), []byte (b), int64 (h), float64 (d), Timetag (t), bool (T
+// and F) and nil (N).
+type Message struct {
+	Address string
+	Args    []interface{}
+}
+
+// Bundle is an OSC bundle of messages
+type Bundle struct {
+	Timetag  Timetag
+	Messages []Message
+}
+
+// Timetag is an OSC time tag, in NTP format
+type Timetag uint64
+
+// Immediately is the time tag of a bundle to process on receipt
+const Immediately Timetag = 1
+
+const bundleTag = "#bundle"
+
+// MarshalBinary encodes the message. Arguments may be int32, int (as i if it
+// fits, h otherwise), int64 (h), float32 and float64 (both as f, which every
+// OSC implementation understands), string, []byte, Timetag, bool and nil.
+func (m Message) MarshalBinary() ([]byte, error) {
+	if len(m.Address) == 0 || m.Address[0] != '/' {
+		return nil, fmt.Errorf("invalid OSC address: %q", m.Address)
+	}
+	var buf bytes.Buffer
+	writeString(&buf, m.Address)
+
+	tags := []byte{','}
+	var args bytes.Buffer
+	for _, arg := range m.Args {
+		switch v := arg.(type) {
+		case int32:
+			tags = append(tags, 'i')
+			binary.Write(&args, binary.BigEndian, v)
+		case int:
+			if v >= math.MinInt32 && v <= math.MaxInt32 {
+				tags = append(tags, 'i')
+				binary.Write(&args, binary.BigEndian, int32(v))
+			} else {
+				tags = append(tags, 'h')
+				binary.Write(&args, binary.BigEndian, int64(v))
+			}
+		case int64:
+			tags = append(tags, 'h')
+			binary.Write(&args, binary.BigEndian, v)
+		case float32:
+			tags = append(tags, 'f')
+			binary.Write(&args, binary.BigEndian, v)
+		case float64:
+			tags = append(tags, 'f')
+			binary.Write(&args, binary.BigEndian, float32(v))
+		case string:
+			tags = append(tags, 's')
+			writeString(&args, v)
+		case []byte:
+			tags = append(tags, 'b')
+			binary.Write(&args, binary.BigEndian, int32(len(v)))
+			args.Write(v)
+			args.Write(make([]byte, pad(len(v))))
+		case Timetag:
+			tags = append(tags, 't')
+			binary.Write(&args, binary.BigEndian, uint64(v))
+		case bool:
+			if v {
+				tags = append(tags, 'T')
+			} else {
+				tags = append(tags, 'F')
+			}
+		case nil:
+			tags = append(tags, 'N')
+		default:
+			return nil, fmt.Errorf("unsupported OSC argument type %T", arg)
+		}
+	}
+	writeString(&buf, string(tags))
+	buf.Write(args.Bytes())
+	return buf.Bytes(), nil
+}
+
+// MarshalBinary encodes the bundle
+func (b Bundle) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	writeString(&buf, bundleTag)
+	binary.Write(&buf, binary.BigEndian, uint64(b.Timetag))
+	for _, m := range b.Messages {
+		data, err := m.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		binary.Write(&buf, binary.BigEndian, int32(len(data)))
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode decodes an OSC packet, a message or a bundle, into its messages.
+// Nested bundles are flattened in order, and their time tags are ignored.
+func Decode(data []byte) ([]Message, error) {
+	if bytes.HasPrefix(data, []byte(bundleTag+"\x00")) {
+		return decodeBundle(data)
+	}
+	m, err := decodeMessage(data)
+	if err != nil {
+		return nil, err
+	}
+	return []Message{m}, nil
+}
+
+func decodeBundle(data []byte) ([]Message, error) {
+	r := &reader{data: data}
+	r.string()
+	if _, err := r.uint64(); err != nil {
+		return nil, err
+	}
+	var messages []Message
+	for r.len() > 0 {
+		size, err := r.int32()
+		if err != nil {
+			return nil, err
+		}
+		element, err := r.bytes(int(size))
+		if err != nil {
+			return nil, err
+		}
+		elements, err := Decode(element)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, elements...)
+	}
+	return messages, nil
+}
+
+func decodeMessage(data []byte) (Message, error) {
+	r := &reader{data: data}
+	address, err := r.string()
+	if err != nil {
+		return Message{}, err
+	}
+	if len(address) == 0 || address[0] != '/' {
+		return Message{}, fmt.Errorf("invalid OSC address: %q", address)
+	}
+	m := Message{Address: address}
+	if r.len() == 0 {
+		// Type tags are optional in old implementations
+		return m, nil
+	}
+	tags, err := r.string()
+	if err != nil {
+		return Message{}, err
+	}
+	if len(tags) == 0 || tags[0] != ',' {
+		return Message{}, fmt.Errorf("invalid OSC type tags: %q", tags)
+	}
+	for _, tag := range tags[1:] {
+		var arg interface{}
+		switch tag {
+		case 'i':
+			arg, err = r.int32()
+		case 'f':
+			var v uint32
+			v, err = r.uint32()
+			arg = math.Float32frombits(v)
+		case 's', 'S':
+			arg, err = r.string()
+		case 'b':
+			var size int32
+			if size, err = r.int32(); err == nil {
+				var blob []byte
+				blob, err = r.bytes(int(size))
+				arg = append([]byte(nil), blob...)
+			}
+		case 'h':
+			var v uint64
+			v, err = r.uint64()
+			arg = int64(v)
+		case 'd':
+			var v uint64
+			v, err = r.uint64()
+			arg = math.Float64frombits(v)
+		case 't':
+			var v uint64
+			v, err = r.uint64()
+			arg = Timetag(v)
+		case 'T':
+			arg = true
+		case 'F':
+			arg = false
+		case 'N':
+			arg = nil
+		default:
+			return Message{}, fmt.Errorf("unsupported OSC type tag %q", tag)
+		}
+		if err != nil {
+			return Message{}, err
+		}
+		m.Args = append(m.Args, arg)
+	}
+	return m, nil
+}
+
+var errShort = errors.New("truncated OSC packet")
+
+// reader reads the 4-byte aligned fields of an OSC packet
+type reader struct {
+	data []byte
+	off  int
+}
+
+func (r *reader) len() int {
+	return len(r.data) - r.off
+}
+
+// bytes reads n bytes and the padding after them
+func (r *reader) bytes(n int) ([]byte, error) {
+	if n < 0 || n+pad(n) > r.len() {
+		return nil, errShort
+	}
+	b := r.data[r.off : r.off+n]
+	r.off += n + pad(n)
+	return b, nil
+}
+
+func (r *reader) string() (string, error) {
+	end := bytes.IndexByte(r.data[r.off:], 0)
+	if end < 0 {
+		return "", errShort
+	}
+	s := string(r.data[r.off : r.off+end])
+	// The terminating null counts towards the padding
+	if _, err := r.bytes(end + 1); err != nil {
+		return "", err
+	}
+	return s, nil
+}
+
+func (r *reader) uint32() (uint32, error) {
+	b, err := r.bytes(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(b), nil
+}
+
+func (r *reader) int32() (int32, error) {
+	v, err := r.uint32()
+	return int32(v), err
+}
+
+func (r *reader) uint64() (uint64, error) {
+	b, err := r.bytes(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+// writeString writes a null-terminated string padded to 4 bytes
+func writeString(buf *bytes.Buffer, s string) {
+	buf.WriteString(s)
+	buf.Write(make([]byte, 1+pad(len(s)+1)))
+}
+
+// pad returns the number of bytes aligning n to 4 bytes
+func pad(n int) int {
+	return (4 - n%4) % 4
+}
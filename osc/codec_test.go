@@ -0,0 +1,70 @@
+package osc
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestMessageRoundTrip(t *testing.T) {
+	m := Message{
+		Address: "/layer/2/clip/3/open",
+		Args:    []interface{}{int32(7), float32(0.5), "file:///video.mov", []byte{1, 2, 3}, int64(1618311116470), true, false, nil, Timetag(42)},
+	}
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+	if len(data)%4 != 0 {
+		t.Errorf("Expected a packet aligned to 4 bytes, got %d bytes", len(data))
+	}
+	got, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if len(got) != 1 || !reflect.DeepEqual(got[0], m) {
+		t.Errorf("Decode() = %#v, want %#v", got, m)
+	}
+}
+
+func TestMessageEncoding(t *testing.T) {
+	data, _ := Message{Address: "/a", Args: []interface{}{1, 2.0, "hi"}}.MarshalBinary()
+	want := []byte("/a\x00\x00,ifs\x00\x00\x00\x00\x00\x00\x00\x01\x40\x00\x00\x00hi\x00\x00")
+	if !bytes.Equal(data, want) {
+		t.Errorf("MarshalBinary() = %q, want %q", data, want)
+	}
+	if _, err := (Message{Address: "a"}).MarshalBinary(); err == nil {
+		t.Error("MarshalBinary() of an address without a slash error = nil")
+	}
+}
+
+func TestDecodeBundle(t *testing.T) {
+	inner, _ := Bundle{Timetag: Immediately, Messages: []Message{{Address: "/b", Args: []interface{}{int32(2)}}}}.MarshalBinary()
+	outer, _ := Bundle{Timetag: Immediately, Messages: []Message{{Address: "/a"}}}.MarshalBinary()
+	// Nest the inner bundle as a second element
+	outer = append(outer, 0, 0, 0, byte(len(inner)))
+	outer = append(outer, inner...)
+
+	got, err := Decode(outer)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	want := []Message{{Address: "/a"}, {Address: "/b", Args: []interface{}{int32(2)}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode() = %#v, want %#v", got, want)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	for _, data := range [][]byte{
+		[]byte("/a"),
+		[]byte("/a\x00\x00,i\x00\x00\x00\x00"),
+		[]byte("/a\x00\x00,r\x00\x00\x00\x00\x00\x00"),
+		[]byte("a\x00\x00\x00"),
+		[]byte("#bundle\x00\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x10/a\x00\x00"),
+	} {
+		if _, err := Decode(data); err == nil {
+			t.Errorf("Decode(%q) error = nil", data)
+		}
+	}
+}
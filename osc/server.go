@@ -0,0 +1,352 @@
+// Package osc bridges OSC to the Resolume REST API, for lighting consoles and
+// controllers such as TouchOSC, covering what Resolume's own OSC input does
+// not, e.g. opening files into clips, renaming and thumbnails.
+//
+// A Server listens on UDP and routes the messages whose address matches a
+// pattern such as "/layer/*/clip/*/connect" to a handler calling the Client.
+// The segments matched by wildcards and the message arguments are the
+// arguments of the call. Results are sent back to the sender at the address of
+// the message, and errors at "/error" with the address and the error text.
+// Packets that cannot be decoded or match no route get no reply unless
+// WithUnmatchedReplies is set, and WithAllowedSenders restricts who is served.
+//
+// Bundles are processed in order on receipt, regardless of their time tag.
+package osc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/FlowingSPDG/resolume-go"
+)
+
+// ErrorAddress is the address of the replies reporting a failed call
+const ErrorAddress = "/error"
+
+// maxPacketSize is the largest UDP payload
+const maxPacketSize = 65507
+
+// Request is a message matched by a route
+type Request struct {
+	Message
+	// Vars are the address segments matched by the wildcards of the pattern
+	Vars []string
+	// From is the address of the sender
+	From net.Addr
+}
+
+// Handler handles the messages of a route and returns the arguments of the
+// reply, or none to send no reply
+type Handler func(ctx context.Context, client *resolume.Client, req *Request) ([]interface{}, error)
+
+// route is a pattern and its handler
+type route struct {
+	pattern  []string
+	wildcard []bool
+	handler  Handler
+}
+
+// Server routes OSC messages to Client calls
+type Server struct {
+	client         *resolume.Client
+	onError        func(Message, error)
+	allowed        []netip.Prefix
+	replyUnmatched bool
+
+	mu     sync.RWMutex
+	routes []route
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithErrorHandler sets a function called with every message that failed,
+// including messages that matched no route, e.g. to log them
+func WithErrorHandler(fn func(Message, error)) Option {
+	return func(s *Server) {
+		s.onError = fn
+	}
+}
+
+// WithAllowedSenders restricts the senders served to the addresses within
+// prefixes. Packets from other senders are dropped without a reply. By
+// default every sender is served.
+func WithAllowedSenders(prefixes ...netip.Prefix) Option {
+	return func(s *Server) {
+		s.allowed = append(s.allowed, prefixes...)
+	}
+}
+
+// WithUnmatchedReplies sets whether errors are sent back for packets that
+// cannot be decoded or match no route. By default they are only reported to
+// the error handler, so the Server does not answer arbitrary packets.
+func WithUnmatchedReplies(reply bool) Option {
+	return func(s *Server) {
+		s.replyUnmatched = reply
+	}
+}
+
+// NewServer creates a Server with the DefaultRoutes
+func NewServer(client *resolume.Client, opts ...Option) *Server {
+	s := &Server{client: client}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if err := s.Routes(DefaultRoutes); err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Handle routes the messages matching pattern to a handler. Pattern segments
+// may use the wildcards of path.Match, e.g. "/layer/*/clip/*/connect". Routes
+// added later take precedence.
+func (s *Server) Handle(pattern string, handler Handler) error {
+	if !strings.HasPrefix(pattern, "/") {
+		return fmt.Errorf("invalid OSC address pattern: %q", pattern)
+	}
+	r := route{pattern: strings.Split(pattern, "/")[1:], handler: handler}
+	for _, segment := range r.pattern {
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("invalid OSC address pattern %q: %w", pattern, err)
+		}
+		r.wildcard = append(r.wildcard, strings.ContainsAny(segment, "*?["))
+	}
+	s.mu.Lock()
+	s.routes = append(s.routes, r)
+	s.mu.Unlock()
+	return nil
+}
+
+// Route routes the messages matching pattern to the action with the given name
+func (s *Server) Route(pattern, action string) error {
+	handler, ok := Actions[action]
+	if !ok {
+		return fmt.Errorf("unknown action: %s", action)
+	}
+	return s.Handle(pattern, handler)
+}
+
+// Routes routes each pattern of a map to the action named by its value, in
+// the order of the patterns
+func (s *Server) Routes(routes map[string]string) error {
+	patterns := make([]string, 0, len(routes))
+	for pattern := range routes {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		if err := s.Route(pattern, routes[pattern]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// match returns the handler of the latest route matching address and the
+// segments matched by its wildcards
+func (s *Server) match(address string) (Handler, []string) {
+	segments := strings.Split(address, "/")[1:]
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for i := len(s.routes) - 1; i >= 0; i-- {
+		r := s.routes[i]
+		if len(r.pattern) != len(segments) {
+			continue
+		}
+		var vars []string
+		matched := true
+		for j, segment := range segments {
+			if ok, _ := path.Match(r.pattern[j], segment); !ok {
+				matched = false
+				break
+			}
+			if r.wildcard[j] {
+				vars = append(vars, segment)
+			}
+		}
+		if matched {
+			return r.handler, vars
+		}
+	}
+	return nil, nil
+}
+
+// ListenAndServe listens on the UDP address and serves until ctx is done
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, conn)
+}
+
+// Serve serves the packets received on conn until ctx is done, and closes
+// conn. Packets are handled one at a time, in the order received. Read errors
+// other than conn being closed are retried after a wait growing up to a second.
+func (s *Server) Serve(ctx context.Context, conn net.PacketConn) error {
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+
+	buf := make([]byte, maxPacketSize)
+	var wait time.Duration
+	for {
+		n, from, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return err
+			}
+			wait = min(max(2*wait, 5*time.Millisecond), time.Second)
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+			continue
+		}
+		wait = 0
+		if !s.allowedSender(from) {
+			continue
+		}
+		messages, err := Decode(buf[:n])
+		if err != nil {
+			s.failUnmatched(conn, from, Message{}, err)
+			continue
+		}
+		for _, m := range messages {
+			s.serveMessage(ctx, conn, from, m)
+		}
+	}
+}
+
+// serveMessage calls the handler of a message and sends the reply
+func (s *Server) serveMessage(ctx context.Context, conn net.PacketConn, from net.Addr, m Message) {
+	handler, vars := s.match(m.Address)
+	if handler == nil {
+		s.failUnmatched(conn, from, m, fmt.Errorf("no route for %s", m.Address))
+		return
+	}
+	results, err := handler(ctx, s.client, &Request{Message: m, Vars: vars, From: from})
+	if err != nil {
+		s.fail(conn, from, m, err)
+		return
+	}
+	if len(results) == 0 {
+		return
+	}
+	reply, err := Message{Address: m.Address, Args: results}.MarshalBinary()
+	if err != nil {
+		s.fail(conn, from, m, err)
+		return
+	}
+	conn.WriteTo(reply, from)
+}
+
+// failUnmatched reports an error for a packet that could not be decoded or
+// matched no route, to the sender only WithUnmatchedReplies
+func (s *Server) failUnmatched(conn net.PacketConn, from net.Addr, m Message, err error) {
+	if s.replyUnmatched {
+		s.fail(conn, from, m, err)
+	} else if s.onError != nil {
+		s.onError(m, err)
+	}
+}
+
+// allowedSender reports whether packets from an address are served
+func (s *Server) allowedSender(from net.Addr) bool {
+	if len(s.allowed) == 0 {
+		return true
+	}
+	udp, ok := from.(*net.UDPAddr)
+	if !ok {
+		return false
+	}
+	addr := udp.AddrPort().Addr().Unmap()
+	for _, prefix := range s.allowed {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// fail reports an error to the error handler and to the sender
+func (s *Server) fail(conn net.PacketConn, from net.Addr, m Message, err error) {
+	if s.onError != nil {
+		s.onError(m, err)
+	}
+	reply, _ := Message{Address: ErrorAddress, Args: []interface{}{m.Address, err.Error()}}.MarshalBinary()
+	conn.WriteTo(reply, from)
+}
+
+// VarInt returns the i-th wildcard segment as an integer
+func (r *Request) VarInt(i int) (int64, error) {
+	if i >= len(r.Vars) {
+		return 0, fmt.Errorf("%s: missing address segment %d", r.Address, i+1)
+	}
+	v, err := strconv.ParseInt(r.Vars[i], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: address segment %q is not a number", r.Address, r.Vars[i])
+	}
+	return v, nil
+}
+
+// Arg returns the i-th argument
+func (r *Request) Arg(i int) (interface{}, error) {
+	if i >= len(r.Args) {
+		return nil, fmt.Errorf("%s: missing argument %d", r.Address, i+1)
+	}
+	return r.Args[i], nil
+}
+
+// StringArg returns the i-th argument as a string
+func (r *Request) StringArg(i int) (string, error) {
+	arg, err := r.Arg(i)
+	if err != nil {
+		return "", err
+	}
+	s, ok := arg.(string)
+	if !ok {
+		return "", fmt.Errorf("%s: argument %d is a %T, not a string", r.Address, i+1, arg)
+	}
+	return s, nil
+}
+
+// Released reports whether the message is the release of a button: a first
+// argument of 0 or false, as sent by controllers such as TouchOSC. Trigger
+// actions ignore releases so a button press triggers them once.
+func (r *Request) Released() bool {
+	if len(r.Args) == 0 {
+		return false
+	}
+	switch v := r.Args[0].(type) {
+	case int32:
+		return v == 0
+	case int64:
+		return v == 0
+	case float32:
+		return v == 0
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	}
+	return false
+}
@@ -0,0 +1,266 @@
+package osc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/FlowingSPDG/resolume-go"
+)
+
+// newLoopback starts a Server on a local UDP port backed by a fake Resolume
+// recording its POST and PUT requests, and returns a connection to send
+// messages from
+func newLoopback(t *testing.T, setup func(*Server), opts ...Option) (net.PacketConn, net.Addr, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/parameter/by-id/5":
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{"id": 5, "valuetype": "ParamRange", "value": 0.75, "min": 0, "max": 1}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/composition/clips/by-id/9/thumbnail":
+			w.Header().Set("Content-Type", "image/png")
+			io.WriteString(w, "PNG")
+		case r.Method == http.MethodGet:
+			http.NotFound(w, r)
+		default:
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			requests = append(requests, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/api/v1")+" "+strings.TrimSpace(string(body)))
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(api.Close)
+
+	client, err := resolume.NewClientFromURL(api.URL+"/api/v1", resolume.WithHTTPClient(api.Client()))
+	if err != nil {
+		t.Fatalf("NewClientFromURL() error = %v", err)
+	}
+	server := NewServer(client, opts...)
+	if setup != nil {
+		setup(server)
+	}
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		server.Serve(ctx, conn)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	sender, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket() error = %v", err)
+	}
+	t.Cleanup(func() { sender.Close() })
+	return sender, conn.LocalAddr(), func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), requests...)
+	}
+}
+
+// failingConn is a PacketConn whose reads always fail
+type failingConn struct {
+	net.PacketConn
+	mu     sync.Mutex
+	reads  int
+	closes int
+}
+
+func (c *failingConn) ReadFrom([]byte) (int, net.Addr, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reads++
+	if c.closes > 0 {
+		return 0, nil, net.ErrClosed
+	}
+	return 0, nil, errors.New("connection refused")
+}
+
+func (c *failingConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closes++
+	return nil
+}
+
+func (c *failingConn) counts() (int, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.reads, c.closes
+}
+
+// send sends a message to the server
+func send(t *testing.T, conn net.PacketConn, to net.Addr, m Message) {
+	t.Helper()
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+	if _, err := conn.WriteTo(data, to); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+}
+
+// receive reads a reply from the server
+func receive(t *testing.T, conn net.PacketConn) Message {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	buf := make([]byte, maxPacketSize)
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("ReadFrom() error = %v", err)
+	}
+	messages, err := Decode(buf[:n])
+	if err != nil || len(messages) != 1 {
+		t.Fatalf("Decode() = %v, %v", messages, err)
+	}
+	return messages[0]
+}
+
+func TestServerRoutes(t *testing.T) {
+	conn, addr, requests := newLoopback(t, nil)
+
+	bundle, _ := Bundle{Timetag: Immediately, Messages: []Message{
+		{Address: "/layer/2/clip/3/connect", Args: []interface{}{float32(1)}},
+		{Address: "/layer/2/clip/3/connect", Args: []interface{}{float32(0)}},
+		{Address: "/clip/9/open", Args: []interface{}{"file:///video.mov"}},
+		{Address: "/layer/1/name", Args: []interface{}{"Intro"}},
+		{Address: "/parameter/5/set", Args: []interface{}{float32(0.5)}},
+		{Address: "/parameter/5/get"},
+	}}.MarshalBinary()
+	conn.WriteTo(bundle, addr)
+
+	reply := receive(t, conn)
+	if want := (Message{Address: "/parameter/5/get", Args: []interface{}{float32(0.75)}}); !reflect.DeepEqual(reply, want) {
+		t.Errorf("Reply = %#v, want %#v", reply, want)
+	}
+	want := []string{
+		"POST /composition/layers/2/clips/3/connect null",
+		"POST /composition/clips/by-id/9/open file:///video.mov",
+		`PUT /composition/layers/1 {"name":{"value":"Intro"}}`,
+		`PUT /parameter/by-id/5 {"value":0.5}`,
+	}
+	if got := requests(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Requests = %q, want %q", got, want)
+	}
+
+	send(t, conn, addr, Message{Address: "/clip/9/thumbnail"})
+	if reply := receive(t, conn); !reflect.DeepEqual(reply.Args, []interface{}{[]byte("PNG")}) {
+		t.Errorf("Thumbnail reply = %#v", reply)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	conn, addr, _ := newLoopback(t, nil)
+
+	// Unmatched messages get no reply by default
+	send(t, conn, addr, Message{Address: "/nowhere"})
+	send(t, conn, addr, Message{Address: "/parameter/6/get"})
+	reply := receive(t, conn)
+	if reply.Address != ErrorAddress || reply.Args[0] != "/parameter/6/get" || !strings.Contains(reply.Args[1].(string), "404") {
+		t.Errorf("Reply = %#v, want a 404 error", reply)
+	}
+
+	send(t, conn, addr, Message{Address: "/layer/x/clear"})
+	if reply := receive(t, conn); reply.Address != ErrorAddress {
+		t.Errorf("Reply = %#v, want an error for a non-numeric layer", reply)
+	}
+
+	conn, addr, _ = newLoopback(t, nil, WithUnmatchedReplies(true))
+	send(t, conn, addr, Message{Address: "/nowhere"})
+	if reply := receive(t, conn); reply.Address != ErrorAddress || reply.Args[0] != "/nowhere" {
+		t.Errorf("Reply = %#v, want an error for /nowhere", reply)
+	}
+}
+
+func TestAllowedSenders(t *testing.T) {
+	get := Message{Address: "/parameter/5/get"}
+	conn, addr, _ := newLoopback(t, nil, WithAllowedSenders(netip.MustParsePrefix("127.0.0.1/32")))
+	send(t, conn, addr, get)
+	if reply := receive(t, conn); reply.Address != get.Address {
+		t.Errorf("Reply to an allowed sender = %#v", reply)
+	}
+
+	conn, addr, requests := newLoopback(t, nil, WithAllowedSenders(netip.MustParsePrefix("10.0.0.0/8")))
+	send(t, conn, addr, Message{Address: "/layer/1/clear"})
+	send(t, conn, addr, get)
+	conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	if _, _, err := conn.ReadFrom(make([]byte, maxPacketSize)); err == nil {
+		t.Error("Server replied to a sender not allowed")
+	}
+	if got := requests(); len(got) != 0 {
+		t.Errorf("Requests from a sender not allowed = %q", got)
+	}
+}
+
+func TestServeReadErrors(t *testing.T) {
+	conn := &failingConn{}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := NewServer(nil).Serve(ctx, conn); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Serve() error = %v, want context.DeadlineExceeded", err)
+	}
+	if reads, _ := conn.counts(); reads > 10 {
+		t.Errorf("Serve() read %d times in 100ms, want a growing wait between failed reads", reads)
+	}
+}
+
+func TestServeClosedByCaller(t *testing.T) {
+	conn := &failingConn{closes: 1}
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := NewServer(nil).Serve(ctx, conn); !errors.Is(err, net.ErrClosed) {
+		t.Errorf("Serve() error = %v, want net.ErrClosed", err)
+	}
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	if _, closes := conn.counts(); closes != 2 {
+		t.Errorf("Close called %d times after Serve returned, want only by Serve", closes-1)
+	}
+}
+
+func TestServerCustomRoutes(t *testing.T) {
+	conn, addr, requests := newLoopback(t, func(s *Server) {
+		if err := s.Routes(map[string]string{"/go/*": "connect_column", "/layer/*/clear": "select_layer"}); err != nil {
+			t.Fatalf("Routes() error = %v", err)
+		}
+		if err := s.Route("/x", "explode"); err == nil {
+			t.Error("Route() with an unknown action error = nil")
+		}
+		s.Handle("/ping", func(ctx context.Context, c *resolume.Client, req *Request) ([]interface{}, error) {
+			return []interface{}{"pong"}, nil
+		})
+	})
+
+	send(t, conn, addr, Message{Address: "/go/4"})
+	send(t, conn, addr, Message{Address: "/layer/2/clear"})
+	send(t, conn, addr, Message{Address: "/ping"})
+	if reply := receive(t, conn); !reflect.DeepEqual(reply.Args, []interface{}{"pong"}) {
+		t.Errorf("Reply = %#v, want pong", reply)
+	}
+	want := []string{"POST /composition/columns/4/connect null", "POST /composition/layers/2/select "}
+	if got := requests(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Requests = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,351 @@
+package resolume
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Parameter value types as reported in the valuetype field
+const (
+	ParamBoolean = "ParamBoolean"
+	ParamChoice  = "ParamChoice"
+	ParamState   = "ParamState"
+	ParamColor   = "ParamColor"
+	ParamEvent   = "ParamEvent"
+	ParamNumber  = "ParamNumber"
+	ParamRange   = "ParamRange"
+	ParamString  = "ParamString"
+	ParamText    = "ParamText"
+)
+
+// Parameter is implemented by all parameter types
+type Parameter interface {
+	// ParameterID returns the unique identifier of the parameter
+	ParameterID() int64
+	// ParameterType returns the valuetype of the parameter, e.g. ParamRange
+	ParameterType() string
+}
+
+// ParameterID implements Parameter
+func (p *BooleanParameter) ParameterID() int64 { return p.ID }
+
+// ParameterType implements Parameter
+func (p *BooleanParameter) ParameterType() string { return p.ValueType }
+
+// ParameterID implements Parameter
+func (p *ChoiceParameter) ParameterID() int64 { return p.ID }
+
+// ParameterType implements Parameter
+func (p *ChoiceParameter) ParameterType() string { return p.ValueType }
+
+// ParameterID implements Parameter
+func (p *ColorParameter) ParameterID() int64 { return p.ID }
+
+// ParameterType implements Parameter
+func (p *ColorParameter) ParameterType() string { return p.ValueType }
+
+// ParameterID implements Parameter
+func (p *EventParameter) ParameterID() int64 { return p.ID }
+
+// ParameterType implements Parameter
+func (p *EventParameter) ParameterType() string { return p.ValueType }
+
+// ParameterID implements Parameter
+func (p *IntegerParameter) ParameterID() int64 { return p.ID }
+
+// ParameterType implements Parameter
+func (p *IntegerParameter) ParameterType() string { return p.ValueType }
+
+// ParameterID implements Parameter
+func (p *RangeParameter) ParameterID() int64 { return p.ID }
+
+// ParameterType implements Parameter
+func (p *RangeParameter) ParameterType() string { return p.ValueType }
+
+// ParameterID implements Parameter
+func (p *StringParameter) ParameterID() int64 { return p.ID }
+
+// ParameterType implements Parameter
+func (p *StringParameter) ParameterType() string { return p.ValueType }
+
+// ParameterID implements Parameter
+func (p *TextParameter) ParameterID() int64 { return p.ID }
+
+// ParameterType implements Parameter
+func (p *TextParameter) ParameterType() string { return p.ValueType }
+
+// UnknownParameter represents a parameter with a valuetype this package does not know.
+// It keeps the raw JSON so it is sent back unchanged.
+type UnknownParameter struct {
+	ID        int64
+	ValueType string
+	Raw       json.RawMessage
+}
+
+// ParameterID implements Parameter
+func (p *UnknownParameter) ParameterID() int64 { return p.ID }
+
+// ParameterType implements Parameter
+func (p *UnknownParameter) ParameterType() string { return p.ValueType }
+
+// MarshalJSON returns the raw JSON of the parameter
+func (p *UnknownParameter) MarshalJSON() ([]byte, error) {
+	if p.Raw == nil {
+		return []byte("null"), nil
+	}
+	return p.Raw, nil
+}
+
+// UnmarshalJSON stores the raw JSON of the parameter
+func (p *UnknownParameter) UnmarshalJSON(data []byte) error {
+	var header parameterHeader
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+	p.ID = header.ID
+	p.ValueType = header.ValueType
+	p.Raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// ParameterValue returns the value of a parameter, and false if it has none,
+// such as an event parameter
+func ParameterValue(p Parameter) (interface{}, bool) {
+	switch p := p.(type) {
+	case *BooleanParameter:
+		return p.Value, true
+	case *ChoiceParameter:
+		return p.Value, true
+	case *ColorParameter:
+		return p.Value, true
+	case *IntegerParameter:
+		return p.Value, true
+	case *RangeParameter:
+		return p.Value, true
+	case *StringParameter:
+		return p.Value, true
+	case *TextParameter:
+		return p.Value, true
+	case *UnknownParameter:
+		var fields struct {
+			Value interface{} `json:"value"`
+		}
+		if err := json.Unmarshal(p.Raw, &fields); err != nil || fields.Value == nil {
+			return nil, false
+		}
+		return fields.Value, true
+	default:
+		return nil, false
+	}
+}
+
+// parameterHeader holds the fields shared by all parameter types
+type parameterHeader struct {
+	ID        int64  `json:"id"`
+	ValueType string `json:"valuetype"`
+}
+
+// DecodeParameter decodes a parameter, choosing the concrete type from its valuetype
+func DecodeParameter(data []byte) (Parameter, error) {
+	var header parameterHeader
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("failed to decode parameter: %w", err)
+	}
+
+	var p Parameter
+	switch header.ValueType {
+	case ParamBoolean:
+		p = &BooleanParameter{}
+	case ParamChoice, ParamState:
+		p = &ChoiceParameter{}
+	case ParamColor:
+		p = &ColorParameter{}
+	case ParamEvent:
+		p = &EventParameter{}
+	case ParamNumber:
+		p = &IntegerParameter{}
+	case ParamRange:
+		p = &RangeParameter{}
+	case ParamString:
+		p = &StringParameter{}
+	case ParamText:
+		p = &TextParameter{}
+	default:
+		p = &UnknownParameter{}
+	}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", header.ValueType, err)
+	}
+	return p, nil
+}
+
+// UnmarshalJSON decodes every parameter in the collection into its concrete type
+func (pc *ParameterCollection) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		*pc = nil
+		return nil
+	}
+
+	collection := make(ParameterCollection, len(raw))
+	for name, value := range raw {
+		p, err := DecodeParameter(value)
+		if err != nil {
+			return fmt.Errorf("parameter %q: %w", name, err)
+		}
+		collection[name] = p
+	}
+	*pc = collection
+	return nil
+}
+
+// Boolean returns the named parameter if it is a BooleanParameter
+func (pc ParameterCollection) Boolean(name string) (*BooleanParameter, bool) {
+	p, ok := pc[name].(*BooleanParameter)
+	return p, ok
+}
+
+// Choice returns the named parameter if it is a ChoiceParameter
+func (pc ParameterCollection) Choice(name string) (*ChoiceParameter, bool) {
+	p, ok := pc[name].(*ChoiceParameter)
+	return p, ok
+}
+
+// Color returns the named parameter if it is a ColorParameter
+func (pc ParameterCollection) Color(name string) (*ColorParameter, bool) {
+	p, ok := pc[name].(*ColorParameter)
+	return p, ok
+}
+
+// Event returns the named parameter if it is an EventParameter
+func (pc ParameterCollection) Event(name string) (*EventParameter, bool) {
+	p, ok := pc[name].(*EventParameter)
+	return p, ok
+}
+
+// Integer returns the named parameter if it is an IntegerParameter
+func (pc ParameterCollection) Integer(name string) (*IntegerParameter, bool) {
+	p, ok := pc[name].(*IntegerParameter)
+	return p, ok
+}
+
+// Range returns the named parameter if it is a RangeParameter
+func (pc ParameterCollection) Range(name string) (*RangeParameter, bool) {
+	p, ok := pc[name].(*RangeParameter)
+	return p, ok
+}
+
+// String returns the named parameter if it is a StringParameter
+func (pc ParameterCollection) String(name string) (*StringParameter, bool) {
+	p, ok := pc[name].(*StringParameter)
+	return p, ok
+}
+
+// Text returns the named parameter if it is a TextParameter
+func (pc ParameterCollection) Text(name string) (*TextParameter, bool) {
+	p, ok := pc[name].(*TextParameter)
+	return p, ok
+}
+
+// GetParameter retrieves a parameter given its unique id and decodes it into its concrete type
+func (c *Client) GetParameter(parameterID int64) (Parameter, error) {
+	return c.GetParameterContext(context.Background(), parameterID)
+}
+
+// GetParameterContext is like GetParameter but with a context
+func (c *Client) GetParameterContext(ctx context.Context, parameterID int64) (Parameter, error) {
+	endpoint := fmt.Sprintf("/parameter/by-id/%d", parameterID)
+	var raw json.RawMessage
+	if err := c.get(ctx, endpoint, &raw); err != nil {
+		return nil, err
+	}
+	return DecodeParameter(raw)
+}
+
+// getTypedParameter retrieves a parameter by id and checks it has the expected concrete type
+func getTypedParameter[T Parameter](ctx context.Context, c *Client, parameterID int64) (T, error) {
+	var zero T
+	p, err := c.GetParameterContext(ctx, parameterID)
+	if err != nil {
+		return zero, err
+	}
+	typed, ok := p.(T)
+	if !ok {
+		return zero, fmt.Errorf("parameter %d has unexpected type %s", parameterID, p.ParameterType())
+	}
+	return typed, nil
+}
+
+// GetBooleanParameter retrieves a BooleanParameter given its unique id
+func (c *Client) GetBooleanParameter(parameterID int64) (*BooleanParameter, error) {
+	return c.GetBooleanParameterContext(context.Background(), parameterID)
+}
+
+// GetBooleanParameterContext is like GetBooleanParameter but with a context
+func (c *Client) GetBooleanParameterContext(ctx context.Context, parameterID int64) (*BooleanParameter, error) {
+	return getTypedParameter[*BooleanParameter](ctx, c, parameterID)
+}
+
+// GetChoiceParameter retrieves a ChoiceParameter given its unique id
+func (c *Client) GetChoiceParameter(parameterID int64) (*ChoiceParameter, error) {
+	return c.GetChoiceParameterContext(context.Background(), parameterID)
+}
+
+// GetChoiceParameterContext is like GetChoiceParameter but with a context
+func (c *Client) GetChoiceParameterContext(ctx context.Context, parameterID int64) (*ChoiceParameter, error) {
+	return getTypedParameter[*ChoiceParameter](ctx, c, parameterID)
+}
+
+// GetColorParameter retrieves a ColorParameter given its unique id
+func (c *Client) GetColorParameter(parameterID int64) (*ColorParameter, error) {
+	return c.GetColorParameterContext(context.Background(), parameterID)
+}
+
+// GetColorParameterContext is like GetColorParameter but with a context
+func (c *Client) GetColorParameterContext(ctx context.Context, parameterID int64) (*ColorParameter, error) {
+	return getTypedParameter[*ColorParameter](ctx, c, parameterID)
+}
+
+// GetIntegerParameter retrieves an IntegerParameter given its unique id
+func (c *Client) GetIntegerParameter(parameterID int64) (*IntegerParameter, error) {
+	return c.GetIntegerParameterContext(context.Background(), parameterID)
+}
+
+// GetIntegerParameterContext is like GetIntegerParameter but with a context
+func (c *Client) GetIntegerParameterContext(ctx context.Context, parameterID int64) (*IntegerParameter, error) {
+	return getTypedParameter[*IntegerParameter](ctx, c, parameterID)
+}
+
+// GetRangeParameter retrieves a RangeParameter given its unique id
+func (c *Client) GetRangeParameter(parameterID int64) (*RangeParameter, error) {
+	return c.GetRangeParameterContext(context.Background(), parameterID)
+}
+
+// GetRangeParameterContext is like GetRangeParameter but with a context
+func (c *Client) GetRangeParameterContext(ctx context.Context, parameterID int64) (*RangeParameter, error) {
+	return getTypedParameter[*RangeParameter](ctx, c, parameterID)
+}
+
+// GetStringParameter retrieves a StringParameter given its unique id
+func (c *Client) GetStringParameter(parameterID int64) (*StringParameter, error) {
+	return c.GetStringParameterContext(context.Background(), parameterID)
+}
+
+// GetStringParameterContext is like GetStringParameter but with a context
+func (c *Client) GetStringParameterContext(ctx context.Context, parameterID int64) (*StringParameter, error) {
+	return getTypedParameter[*StringParameter](ctx, c, parameterID)
+}
+
+// GetTextParameter retrieves a TextParameter given its unique id
+func (c *Client) GetTextParameter(parameterID int64) (*TextParameter, error) {
+	return c.GetTextParameterContext(context.Background(), parameterID)
+}
+
+// GetTextParameterContext is like GetTextParameter but with a context
+func (c *Client) GetTextParameterContext(ctx context.Context, parameterID int64) (*TextParameter, error) {
+	return getTypedParameter[*TextParameter](ctx, c, parameterID)
+}
@@ -0,0 +1,112 @@
+package resolume
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDecodeParameter(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{data: `{"id": 1, "valuetype": "ParamBoolean", "value": true}`, want: "*resolume.BooleanParameter"},
+		{data: `{"id": 2, "valuetype": "ParamChoice", "value": "A", "index": 0, "options": ["A"]}`, want: "*resolume.ChoiceParameter"},
+		{data: `{"id": 3, "valuetype": "ParamState", "value": "A", "index": 0, "options": ["A"]}`, want: "*resolume.ChoiceParameter"},
+		{data: `{"id": 4, "valuetype": "ParamColor", "value": "#ffffff"}`, want: "*resolume.ColorParameter"},
+		{data: `{"id": 5, "valuetype": "ParamEvent"}`, want: "*resolume.EventParameter"},
+		{data: `{"id": 6, "valuetype": "ParamNumber", "value": 100}`, want: "*resolume.IntegerParameter"},
+		{data: `{"id": 7, "valuetype": "ParamRange", "value": 0.5, "min": 0, "max": 1}`, want: "*resolume.RangeParameter"},
+		{data: `{"id": 8, "valuetype": "ParamString", "value": "Intro"}`, want: "*resolume.StringParameter"},
+		{data: `{"id": 9, "valuetype": "ParamText", "value": "Multi\nLine"}`, want: "*resolume.TextParameter"},
+		{data: `{"id": 10, "valuetype": "ParamFuture", "value": [1, 2]}`, want: "*resolume.UnknownParameter"},
+	}
+
+	for _, tt := range tests {
+		p, err := DecodeParameter([]byte(tt.data))
+		if err != nil {
+			t.Errorf("DecodeParameter(%s) error = %v", tt.data, err)
+			continue
+		}
+		if got := fmt.Sprintf("%T", p); got != tt.want {
+			t.Errorf("DecodeParameter(%s) = %s, want %s", tt.data, got, tt.want)
+		}
+		if p.ParameterID() == 0 {
+			t.Errorf("DecodeParameter(%s) lost the id", tt.data)
+		}
+	}
+}
+
+func TestParameterCollectionRoundTrip(t *testing.T) {
+	data := `{"Scale":{"id":1,"valuetype":"ParamRange","min":0,"max":1000,"in":0,"out":1000,"value":100},"Custom":{"id":2,"valuetype":"ParamFuture","value":{"x":1,"y":[2,3]}}}`
+
+	var pc ParameterCollection
+	if err := json.Unmarshal([]byte(data), &pc); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	scale, ok := pc.Range("Scale")
+	if !ok {
+		t.Fatal("Expected Scale to be a RangeParameter")
+	}
+	if scale.Value != 100 {
+		t.Errorf("Expected Scale value 100, got %v", scale.Value)
+	}
+	if _, ok := pc.Boolean("Scale"); ok {
+		t.Error("Expected Scale not to be a BooleanParameter")
+	}
+
+	custom, ok := pc["Custom"].(*UnknownParameter)
+	if !ok {
+		t.Fatal("Expected Custom to be an UnknownParameter")
+	}
+	if custom.ValueType != "ParamFuture" {
+		t.Errorf("Expected valuetype ParamFuture, got %s", custom.ValueType)
+	}
+
+	out, err := json.Marshal(pc)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var got map[string]interface{}
+	json.Unmarshal(out, &got)
+	if gotCustom, _ := json.Marshal(got["Custom"]); string(gotCustom) != `{"id":2,"value":{"x":1,"y":[2,3]},"valuetype":"ParamFuture"}` {
+		t.Errorf("Unknown parameter did not round-trip, got %s", gotCustom)
+	}
+}
+
+func TestGetRangeParameter(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/parameter/by-id/42":
+			w.Write([]byte(`{"id": 42, "valuetype": "ParamRange", "value": 0.5, "min": 0, "max": 1}`))
+		case "/api/v1/parameter/by-id/43":
+			w.Write([]byte(`{"id": 43, "valuetype": "ParamBoolean", "value": true}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClientFromURL(server.URL+"/api/v1", WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewClientFromURL() error = %v", err)
+	}
+
+	param, err := client.GetRangeParameter(42)
+	if err != nil {
+		t.Fatalf("GetRangeParameter() error = %v", err)
+	}
+	if param.Value != 0.5 || param.Max != 1 {
+		t.Errorf("Unexpected parameter %+v", param)
+	}
+
+	if _, err := client.GetRangeParameter(43); err == nil {
+		t.Error("Expected error for a BooleanParameter")
+	}
+}
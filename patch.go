@@ -0,0 +1,393 @@
+package resolume
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Patch is a partial update holding only the fields to change, nested like
+// the types in types.go, e.g. {"video": {"opacity": {"value": 0.5}}}. Unlike
+// a struct fetched with GetLayer and passed to ReplaceLayer, it does not resend
+// the values of other parameters, which may have been changed live since.
+type Patch map[string]interface{}
+
+// Set sets the value of the parameter at path, relative to the object, e.g. "video/opacity"
+func (p Patch) Set(path string, value interface{}) Patch {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	node := p
+	for _, segment := range segments[:len(segments)-1] {
+		child, ok := node[segment].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			node[segment] = child
+		}
+		node = child
+	}
+	node[segments[len(segments)-1]] = map[string]interface{}{"value": value}
+	return p
+}
+
+// DiffPatch returns a patch with the parameters whose values differ from
+// before to after, both being the same type such as *Layer. Items of lists
+// such as clips or effects are patched by position, with an empty object for
+// unchanged items; a list whose length changed cannot be patched.
+func DiffPatch(before, after interface{}) (Patch, error) {
+	a, err := toJSONValue(before)
+	if err != nil {
+		return nil, err
+	}
+	b, err := toJSONValue(after)
+	if err != nil {
+		return nil, err
+	}
+	diff, _, err := diffValue("", a, b)
+	if err != nil {
+		return nil, err
+	}
+	patch, _ := diff.(map[string]interface{})
+	if patch == nil {
+		patch = Patch{}
+	}
+	return patch, nil
+}
+
+// toJSONValue converts v to its generic JSON representation
+func toJSONValue(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode patch: %w", err)
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("failed to decode patch: %w", err)
+	}
+	return value, nil
+}
+
+// diffValue returns the patch turning a into b and whether there is any change
+func diffValue(path string, a, b interface{}) (interface{}, bool, error) {
+	switch b := b.(type) {
+	case map[string]interface{}:
+		a, _ := a.(map[string]interface{})
+		if _, ok := b["valuetype"]; ok {
+			if a != nil && reflect.DeepEqual(a["value"], b["value"]) {
+				return nil, false, nil
+			}
+			return map[string]interface{}{"value": b["value"]}, true, nil
+		}
+		patch := map[string]interface{}{}
+		for key, value := range b {
+			if key == "id" {
+				continue
+			}
+			diff, changed, err := diffValue(path+"/"+key, a[key], value)
+			if err != nil {
+				return nil, false, err
+			}
+			if changed {
+				patch[key] = diff
+			}
+		}
+		return patch, len(patch) > 0, nil
+	case []interface{}:
+		a, _ := a.([]interface{})
+		if len(a) != len(b) {
+			return nil, false, fmt.Errorf("cannot patch %s: the number of items changed from %d to %d", path, len(a), len(b))
+		}
+		patch := make([]interface{}, len(b))
+		var changed bool
+		for i := range b {
+			diff, itemChanged, err := diffValue(fmt.Sprintf("%s/%d", path, i+1), a[i], b[i])
+			if err != nil {
+				return nil, false, err
+			}
+			if !itemChanged {
+				diff = map[string]interface{}{}
+			}
+			patch[i] = diff
+			changed = changed || itemChanged
+		}
+		return patch, changed, nil
+	default:
+		return b, !reflect.DeepEqual(a, b), nil
+	}
+}
+
+// CompositionPatch is a partial update of the composition
+type CompositionPatch Patch
+
+// PatchComposition starts an empty composition patch
+func PatchComposition() CompositionPatch {
+	return CompositionPatch{}
+}
+
+// DiffComposition returns a patch with the changes from before to after
+func DiffComposition(before, after *Composition) (CompositionPatch, error) {
+	patch, err := DiffPatch(before, after)
+	return CompositionPatch(patch), err
+}
+
+// Set sets the value of the parameter at path, e.g. "crossfader/phase"
+func (p CompositionPatch) Set(path string, value interface{}) CompositionPatch {
+	Patch(p).Set(path, value)
+	return p
+}
+
+// Name sets the composition name
+func (p CompositionPatch) Name(name string) CompositionPatch { return p.Set("name", name) }
+
+// Master sets the master fader
+func (p CompositionPatch) Master(master float64) CompositionPatch { return p.Set("master", master) }
+
+// Speed sets the composition speed
+func (p CompositionPatch) Speed(speed float64) CompositionPatch { return p.Set("speed", speed) }
+
+// Opacity sets the video opacity
+func (p CompositionPatch) Opacity(opacity float64) CompositionPatch {
+	return p.Set("video/opacity", opacity)
+}
+
+// Volume sets the audio volume
+func (p CompositionPatch) Volume(volume float64) CompositionPatch {
+	return p.Set("audio/volume", volume)
+}
+
+// Bypassed sets whether the composition is bypassed
+func (p CompositionPatch) Bypassed(bypassed bool) CompositionPatch {
+	return p.Set("bypassed", bypassed)
+}
+
+// Tempo sets the tempo in BPM
+func (p CompositionPatch) Tempo(bpm float64) CompositionPatch {
+	return p.Set("tempo_controller/tempo", bpm)
+}
+
+// LayerPatch is a partial update of a layer
+type LayerPatch Patch
+
+// PatchLayer starts an empty layer patch
+func PatchLayer() LayerPatch {
+	return LayerPatch{}
+}
+
+// DiffLayer returns a patch with the changes from before to after
+func DiffLayer(before, after *Layer) (LayerPatch, error) {
+	patch, err := DiffPatch(before, after)
+	return LayerPatch(patch), err
+}
+
+// Set sets the value of the parameter at path, e.g. "transition/duration"
+func (p LayerPatch) Set(path string, value interface{}) LayerPatch {
+	Patch(p).Set(path, value)
+	return p
+}
+
+// Name sets the layer name
+func (p LayerPatch) Name(name string) LayerPatch { return p.Set("name", name) }
+
+// Master sets the layer master fader
+func (p LayerPatch) Master(master float64) LayerPatch { return p.Set("master", master) }
+
+// Opacity sets the video opacity
+func (p LayerPatch) Opacity(opacity float64) LayerPatch { return p.Set("video/opacity", opacity) }
+
+// Volume sets the audio volume
+func (p LayerPatch) Volume(volume float64) LayerPatch { return p.Set("audio/volume", volume) }
+
+// Bypassed sets whether the layer is bypassed
+func (p LayerPatch) Bypassed(bypassed bool) LayerPatch { return p.Set("bypassed", bypassed) }
+
+// Solo sets whether the layer is soloed
+func (p LayerPatch) Solo(solo bool) LayerPatch { return p.Set("solo", solo) }
+
+// ClipPatch is a partial update of a clip
+type ClipPatch Patch
+
+// PatchClip starts an empty clip patch
+func PatchClip() ClipPatch {
+	return ClipPatch{}
+}
+
+// DiffClip returns a patch with the changes from before to after
+func DiffClip(before, after *Clip) (ClipPatch, error) {
+	patch, err := DiffPatch(before, after)
+	return ClipPatch(patch), err
+}
+
+// Set sets the value of the parameter at path, e.g. "video/resize"
+func (p ClipPatch) Set(path string, value interface{}) ClipPatch {
+	Patch(p).Set(path, value)
+	return p
+}
+
+// Name sets the clip name
+func (p ClipPatch) Name(name string) ClipPatch { return p.Set("name", name) }
+
+// Opacity sets the video opacity
+func (p ClipPatch) Opacity(opacity float64) ClipPatch { return p.Set("video/opacity", opacity) }
+
+// Volume sets the audio volume
+func (p ClipPatch) Volume(volume float64) ClipPatch { return p.Set("audio/volume", volume) }
+
+// Speed sets the transport speed
+func (p ClipPatch) Speed(speed float64) ClipPatch { return p.Set("transport/controls/speed", speed) }
+
+// ColumnPatch is a partial update of a column
+type ColumnPatch Patch
+
+// PatchColumn starts an empty column patch
+func PatchColumn() ColumnPatch {
+	return ColumnPatch{}
+}
+
+// Set sets the value of the parameter at path
+func (p ColumnPatch) Set(path string, value interface{}) ColumnPatch {
+	Patch(p).Set(path, value)
+	return p
+}
+
+// Name sets the column name
+func (p ColumnPatch) Name(name string) ColumnPatch { return p.Set("name", name) }
+
+// DeckPatch is a partial update of a deck
+type DeckPatch Patch
+
+// PatchDeck starts an empty deck patch
+func PatchDeck() DeckPatch {
+	return DeckPatch{}
+}
+
+// Set sets the value of the parameter at path
+func (p DeckPatch) Set(path string, value interface{}) DeckPatch {
+	Patch(p).Set(path, value)
+	return p
+}
+
+// Name sets the deck name
+func (p DeckPatch) Name(name string) DeckPatch { return p.Set("name", name) }
+
+// LayerGroupPatch is a partial update of a layer group
+type LayerGroupPatch Patch
+
+// PatchLayerGroup starts an empty layer group patch
+func PatchLayerGroup() LayerGroupPatch {
+	return LayerGroupPatch{}
+}
+
+// Set sets the value of the parameter at path
+func (p LayerGroupPatch) Set(path string, value interface{}) LayerGroupPatch {
+	Patch(p).Set(path, value)
+	return p
+}
+
+// Name sets the layer group name
+func (p LayerGroupPatch) Name(name string) LayerGroupPatch { return p.Set("name", name) }
+
+// Master sets the layer group master fader
+func (p LayerGroupPatch) Master(master float64) LayerGroupPatch { return p.Set("master", master) }
+
+// Speed sets the layer group speed
+func (p LayerGroupPatch) Speed(speed float64) LayerGroupPatch { return p.Set("speed", speed) }
+
+// Opacity sets the video opacity
+func (p LayerGroupPatch) Opacity(opacity float64) LayerGroupPatch {
+	return p.Set("video/opacity", opacity)
+}
+
+// Bypassed sets whether the layer group is bypassed
+func (p LayerGroupPatch) Bypassed(bypassed bool) LayerGroupPatch {
+	return p.Set("bypassed", bypassed)
+}
+
+// Solo sets whether the layer group is soloed
+func (p LayerGroupPatch) Solo(solo bool) LayerGroupPatch { return p.Set("solo", solo) }
+
+// UpdateComposition applies a partial update to the composition
+func (c *Client) UpdateComposition(patch CompositionPatch) error {
+	return c.UpdateCompositionContext(context.Background(), patch)
+}
+
+// UpdateCompositionContext is like UpdateComposition but with a context
+func (c *Client) UpdateCompositionContext(ctx context.Context, patch CompositionPatch) error {
+	return c.put(ctx, "/composition", patch, nil)
+}
+
+// UpdateLayer applies a partial update to a layer by 1-based index
+func (c *Client) UpdateLayer(layerIndex int64, patch LayerPatch) error {
+	return c.UpdateLayerContext(context.Background(), layerIndex, patch)
+}
+
+// UpdateLayerContext is like UpdateLayer but with a context
+func (c *Client) UpdateLayerContext(ctx context.Context, layerIndex int64, patch LayerPatch) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d", layerIndex)
+	return c.put(ctx, endpoint, patch, nil)
+}
+
+// UpdateLayerByID applies a partial update to a layer by id
+func (c *Client) UpdateLayerByID(layerID int64, patch LayerPatch) error {
+	return c.UpdateLayerByIDContext(context.Background(), layerID, patch)
+}
+
+// UpdateLayerByIDContext is like UpdateLayerByID but with a context
+func (c *Client) UpdateLayerByIDContext(ctx context.Context, layerID int64, patch LayerPatch) error {
+	endpoint := fmt.Sprintf("/composition/layers/by-id/%d", layerID)
+	return c.put(ctx, endpoint, patch, nil)
+}
+
+// UpdateClip applies a partial update to a clip by 1-based layer and clip index
+func (c *Client) UpdateClip(layerIndex, clipIndex int64, patch ClipPatch) error {
+	return c.UpdateClipContext(context.Background(), layerIndex, clipIndex, patch)
+}
+
+// UpdateClipContext is like UpdateClip but with a context
+func (c *Client) UpdateClipContext(ctx context.Context, layerIndex, clipIndex int64, patch ClipPatch) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d", layerIndex, clipIndex)
+	return c.put(ctx, endpoint, patch, nil)
+}
+
+// UpdateClipByID applies a partial update to a clip by id
+func (c *Client) UpdateClipByID(clipID int64, patch ClipPatch) error {
+	return c.UpdateClipByIDContext(context.Background(), clipID, patch)
+}
+
+// UpdateClipByIDContext is like UpdateClipByID but with a context
+func (c *Client) UpdateClipByIDContext(ctx context.Context, clipID int64, patch ClipPatch) error {
+	endpoint := fmt.Sprintf("/composition/clips/by-id/%d", clipID)
+	return c.put(ctx, endpoint, patch, nil)
+}
+
+// UpdateColumn applies a partial update to a column by 1-based index
+func (c *Client) UpdateColumn(columnIndex int64, patch ColumnPatch) error {
+	return c.UpdateColumnContext(context.Background(), columnIndex, patch)
+}
+
+// UpdateColumnContext is like UpdateColumn but with a context
+func (c *Client) UpdateColumnContext(ctx context.Context, columnIndex int64, patch ColumnPatch) error {
+	endpoint := fmt.Sprintf("/composition/columns/%d", columnIndex)
+	return c.put(ctx, endpoint, patch, nil)
+}
+
+// UpdateDeck applies a partial update to a deck by 1-based index
+func (c *Client) UpdateDeck(deckIndex int64, patch DeckPatch) error {
+	return c.UpdateDeckContext(context.Background(), deckIndex, patch)
+}
+
+// UpdateDeckContext is like UpdateDeck but with a context
+func (c *Client) UpdateDeckContext(ctx context.Context, deckIndex int64, patch DeckPatch) error {
+	endpoint := fmt.Sprintf("/composition/decks/%d", deckIndex)
+	return c.put(ctx, endpoint, patch, nil)
+}
+
+// UpdateLayerGroup applies a partial update to a layer group by 1-based index
+func (c *Client) UpdateLayerGroup(layerGroupIndex int64, patch LayerGroupPatch) error {
+	return c.UpdateLayerGroupContext(context.Background(), layerGroupIndex, patch)
+}
+
+// UpdateLayerGroupContext is like UpdateLayerGroup but with a context
+func (c *Client) UpdateLayerGroupContext(ctx context.Context, layerGroupIndex int64, patch LayerGroupPatch) error {
+	endpoint := fmt.Sprintf("/composition/layergroups/%d", layerGroupIndex)
+	return c.put(ctx, endpoint, patch, nil)
+}
@@ -0,0 +1,78 @@
+package resolume
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPatchBuilder(t *testing.T) {
+	patch := PatchLayer().Opacity(0.5).Name("Intro").Set("transition/duration", 2.0)
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := `{"name":{"value":"Intro"},"transition":{"duration":{"value":2}},"video":{"opacity":{"value":0.5}}}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+}
+
+func TestDiffLayer(t *testing.T) {
+	before := decodeTestComposition(t).Layer(2)
+	after := decodeTestComposition(t).Layer(2)
+	after.Video.Opacity.Value = 1
+	after.Clips[1].Name = &StringParameter{ID: 300, ValueType: ParamString, Value: "Outro"}
+	params := after.Video.Effects[0].Params
+	scale, _ := params.Range("Scale")
+	scale.Value = 50
+
+	patch, err := DiffLayer(before, after)
+	if err != nil {
+		t.Fatalf("DiffLayer() error = %v", err)
+	}
+	data, _ := json.Marshal(patch)
+	want := `{"clips":[{},{"name":{"value":"Outro"}}],"video":{"effects":[{"params":{"Scale":{"value":50}}},{}],"opacity":{"value":1}}}`
+	if string(data) != want {
+		t.Errorf("DiffLayer() = %s, want %s", data, want)
+	}
+
+	if patch, err := DiffLayer(before, before); err != nil || len(patch) != 0 {
+		t.Errorf("DiffLayer() of identical layers = %v, %v", patch, err)
+	}
+
+	after.Clips = after.Clips[:1]
+	if _, err := DiffLayer(before, after); err == nil {
+		t.Error("DiffLayer() with a removed clip error = nil")
+	}
+}
+
+func TestUpdateEndpoints(t *testing.T) {
+	client, requests, closeServer := newRecordingServer(t)
+	defer closeServer()
+
+	tests := []struct {
+		name string
+		call func() error
+		path string
+		body string
+	}{
+		{"UpdateComposition", func() error { return client.UpdateComposition(PatchComposition().Tempo(128)) }, "/api/v1/composition", `{"tempo_controller":{"tempo":{"value":128}}}`},
+		{"UpdateLayer", func() error { return client.UpdateLayer(2, PatchLayer().Master(0.5)) }, "/api/v1/composition/layers/2", `{"master":{"value":0.5}}`},
+		{"UpdateClipByID", func() error { return client.UpdateClipByID(9, PatchClip().Speed(2)) }, "/api/v1/composition/clips/by-id/9", `{"transport":{"controls":{"speed":{"value":2}}}}`},
+		{"UpdateColumn", func() error { return client.UpdateColumn(3, PatchColumn().Name("Verse")) }, "/api/v1/composition/columns/3", `{"name":{"value":"Verse"}}`},
+	}
+	for _, tt := range tests {
+		*requests = nil
+		if err := tt.call(); err != nil {
+			t.Errorf("%s() error = %v", tt.name, err)
+			continue
+		}
+		req := (*requests)[0]
+		if req.method != http.MethodPut || req.path != tt.path || strings.TrimSpace(req.body) != tt.body {
+			t.Errorf("%s() sent %s %s %s, want PUT %s %s", tt.name, req.method, req.path, req.body, tt.path, tt.body)
+		}
+	}
+}
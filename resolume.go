@@ -1,7 +1,10 @@
 package resolume
 
+//go:generate go run ./cmd/resolume-gen
+
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,33 +13,67 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"strings"
+	"time"
 )
 
 // Client represents a Resolume API client
 type Client struct {
 	baseURL    *url.URL
 	httpClient *http.Client
+	transport  http.RoundTripper
+	timeout    time.Duration
+	retry      *RetryPolicy
 }
 
 // NewClient creates a new Resolume API client
-func NewClient(host, port string) (*Client, error) {
+func NewClient(host, port string, opts ...Option) (*Client, error) {
 	baseURL := &url.URL{
 		Host:   net.JoinHostPort(host, port),
 		Scheme: "http",
 		Path:   "/api/v1",
 	}
 
-	return &Client{
+	c := &Client{
+		baseURL:    baseURL,
+		httpClient: &http.Client{},
+	}
+	c.applyOptions(opts)
+	return c, nil
+}
+
+// NewClientFromURL creates a new Resolume API client from a base URL such as
+// "https://resolume.local/api/v1". If the URL has no path, "/api/v1" is used.
+func NewClientFromURL(rawURL string, opts ...Option) (*Client, error) {
+	baseURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse base URL: %w", err)
+	}
+	if baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("invalid base URL: %s", rawURL)
+	}
+	if baseURL.Path == "" || baseURL.Path == "/" {
+		baseURL.Path = "/api/v1"
+	}
+
+	c := &Client{
 		baseURL:    baseURL,
 		httpClient: &http.Client{},
-	}, nil
+	}
+	c.applyOptions(opts)
+	return c, nil
 }
 
 // GetProduct retrieves product information
 func (c *Client) GetProduct() (*ProductInfo, error) {
+	return c.GetProductContext(context.Background())
+}
+
+// GetProductContext is like GetProduct but with a context
+func (c *Client) GetProductContext(ctx context.Context) (*ProductInfo, error) {
 	endpoint := "/product"
 	var product ProductInfo
-	if err := c.get(endpoint, &product); err != nil {
+	if err := c.get(ctx, endpoint, &product); err != nil {
 		return nil, err
 	}
 	return &product, nil
@@ -44,9 +81,14 @@ func (c *Client) GetProduct() (*ProductInfo, error) {
 
 // GetEffects retrieves available effects
 func (c *Client) GetEffects() (*Effects, error) {
+	return c.GetEffectsContext(context.Background())
+}
+
+// GetEffectsContext is like GetEffects but with a context
+func (c *Client) GetEffectsContext(ctx context.Context) (*Effects, error) {
 	endpoint := "/effects"
 	var effects Effects
-	if err := c.get(endpoint, &effects); err != nil {
+	if err := c.get(ctx, endpoint, &effects); err != nil {
 		return nil, err
 	}
 	return &effects, nil
@@ -54,9 +96,14 @@ func (c *Client) GetEffects() (*Effects, error) {
 
 // GetSources retrieves available sources
 func (c *Client) GetSources() (*Sources, error) {
+	return c.GetSourcesContext(context.Background())
+}
+
+// GetSourcesContext is like GetSources but with a context
+func (c *Client) GetSourcesContext(ctx context.Context) (*Sources, error) {
 	endpoint := "/sources"
 	var sources Sources
-	if err := c.get(endpoint, &sources); err != nil {
+	if err := c.get(ctx, endpoint, &sources); err != nil {
 		return nil, err
 	}
 	return &sources, nil
@@ -64,8 +111,13 @@ func (c *Client) GetSources() (*Sources, error) {
 
 // GetDummyThumbnail retrieves the dummy thumbnail used for clips without a thumbnail
 func (c *Client) GetDummyThumbnail() (io.ReadCloser, error) {
+	return c.GetDummyThumbnailContext(context.Background())
+}
+
+// GetDummyThumbnailContext is like GetDummyThumbnail but with a context
+func (c *Client) GetDummyThumbnailContext(ctx context.Context) (io.ReadCloser, error) {
 	endpoint := "/composition/thumbnail/dummy"
-	resp, err := c.getRaw(endpoint)
+	resp, err := c.getRaw(ctx, endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -74,8 +126,13 @@ func (c *Client) GetDummyThumbnail() (io.ReadCloser, error) {
 
 // GetClipThumbnail retrieves the thumbnail for a specific clip
 func (c *Client) GetClipThumbnail(layerIndex, clipIndex int) (io.ReadCloser, error) {
+	return c.GetClipThumbnailContext(context.Background(), layerIndex, clipIndex)
+}
+
+// GetClipThumbnailContext is like GetClipThumbnail but with a context
+func (c *Client) GetClipThumbnailContext(ctx context.Context, layerIndex, clipIndex int) (io.ReadCloser, error) {
 	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d/thumbnail", layerIndex, clipIndex)
-	resp, err := c.getRaw(endpoint)
+	resp, err := c.getRaw(ctx, endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -84,149 +141,142 @@ func (c *Client) GetClipThumbnail(layerIndex, clipIndex int) (io.ReadCloser, err
 
 // SetClipThumbnail sets a custom thumbnail for a specific clip
 func (c *Client) SetClipThumbnail(layerIndex, clipIndex int, thumbnail io.Reader) error {
-	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d/thumbnail", layerIndex, clipIndex)
-
-	// Create multipart form
-	body := &bytes.Buffer{}
-	writer := c.createMultipartForm(body, thumbnail)
-
-	// Set content type
-	contentType := fmt.Sprintf("multipart/form-data; boundary=%s", writer.Boundary())
-
-	// Send request
-	req, err := http.NewRequest(http.MethodPost, c.url(endpoint), body)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %v", err)
-	}
-
-	req.Header.Set("Content-Type", contentType)
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to send request: %v", err)
-	}
-	defer resp.Body.Close()
+	return c.SetClipThumbnailContext(context.Background(), layerIndex, clipIndex, thumbnail)
+}
 
-	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
+// SetClipThumbnailContext is like SetClipThumbnail but with a context
+func (c *Client) SetClipThumbnailContext(ctx context.Context, layerIndex, clipIndex int, thumbnail io.Reader) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d/thumbnail", layerIndex, clipIndex)
 
-	return nil
+	body := multipartFile{field: "file", filename: "thumbnail", content: thumbnail}
+	return c.post(ctx, endpoint, body, nil)
 }
 
 // ResetClipThumbnail resets a clip's thumbnail to the default
 func (c *Client) ResetClipThumbnail(layerIndex, clipIndex int) error {
-	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d/thumbnail", layerIndex, clipIndex)
-	return c.delete(endpoint)
-}
-
-// Error represents an API error response
-type Error struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+	return c.ResetClipThumbnailContext(context.Background(), layerIndex, clipIndex)
 }
 
-func (e *Error) Error() string {
-	return fmt.Sprintf("API error: %d - %s", e.Code, e.Message)
+// ResetClipThumbnailContext is like ResetClipThumbnail but with a context
+func (c *Client) ResetClipThumbnailContext(ctx context.Context, layerIndex, clipIndex int) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d/thumbnail", layerIndex, clipIndex)
+	return c.delete(ctx, endpoint)
 }
 
 // get performs a GET request to the specified endpoint and decodes the JSON response
-func (c *Client) get(endpoint string, v interface{}) error {
-	resp, err := c.getRaw(endpoint)
+func (c *Client) get(ctx context.Context, endpoint string, v interface{}) error {
+	resp, err := c.getRaw(ctx, endpoint)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
-		return fmt.Errorf("failed to decode response: %v", err)
+		return fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	return nil
 }
 
 // getRaw performs a GET request and returns the raw response
-func (c *Client) getRaw(endpoint string) (*http.Response, error) {
-	req, err := http.NewRequest(http.MethodGet, c.url(endpoint), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %v", err)
-	}
-
-	if resp.StatusCode >= 400 {
-		defer resp.Body.Close()
-		var apiErr Error
-		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		return nil, &apiErr
-	}
-
-	return resp, nil
+func (c *Client) getRaw(ctx context.Context, endpoint string) (*http.Response, error) {
+	return c.send(ctx, http.MethodGet, endpoint, nil)
 }
 
 // post performs a POST request to the specified endpoint
-func (c *Client) post(endpoint string, body interface{}, v interface{}) error {
-	return c.doRequest(http.MethodPost, endpoint, body, v)
+func (c *Client) post(ctx context.Context, endpoint string, body interface{}, v interface{}) error {
+	return c.doRequest(ctx, http.MethodPost, endpoint, body, v)
 }
 
 // put performs a PUT request to the specified endpoint
-func (c *Client) put(endpoint string, body interface{}, v interface{}) error {
-	return c.doRequest(http.MethodPut, endpoint, body, v)
+func (c *Client) put(ctx context.Context, endpoint string, body interface{}, v interface{}) error {
+	return c.doRequest(ctx, http.MethodPut, endpoint, body, v)
 }
 
 // delete performs a DELETE request to the specified endpoint
-func (c *Client) delete(endpoint string) error {
-	return c.doRequest(http.MethodDelete, endpoint, nil, nil)
+func (c *Client) delete(ctx context.Context, endpoint string) error {
+	return c.doRequest(ctx, http.MethodDelete, endpoint, nil, nil)
 }
 
-// doRequest performs an HTTP request
-func (c *Client) doRequest(method, endpoint string, body, v interface{}) error {
-	url := c.url(endpoint)
+// doRequest performs an HTTP request and decodes the JSON response into v if not nil
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, body, v interface{}) error {
+	resp, err := c.send(ctx, method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
 
-	var bodyReader io.Reader
-	if body != nil {
-		bodyBytes, err := json.Marshal(body)
-		if err != nil {
-			return fmt.Errorf("failed to marshal request body: %v", err)
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
 		}
-		bodyReader = bytes.NewReader(bodyBytes)
 	}
 
-	req, err := http.NewRequest(method, url, bodyReader)
+	return nil
+}
+
+// send performs an HTTP request, retrying it according to the retry policy,
+// and returns the response if its status is below 400
+func (c *Client) send(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
+	bodyReader, contentType, err := encodeBody(body)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %v", err)
+		return nil, err
 	}
 
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	req, err := http.NewRequestWithContext(ctx, method, c.url(endpoint), bodyReader)
+	if err != nil {
+		// Stop the writer of a streamed body
+		if closer, ok := bodyReader.(io.Closer); ok {
+			closer.Close()
+		}
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
 	}
 
+	retry := c.retry.retries(ctx, method) && (bodyReader == nil || req.GetBody != nil)
+	for attempt := 1; ; attempt++ {
+		resp, err := c.sendOnce(req, endpoint, body)
+		if err == nil {
+			return resp, nil
+		}
+		if !retry || attempt >= c.retry.MaxAttempts || !isRetryable(err) {
+			return nil, err
+		}
+
+		backoff := c.retry.backoff(attempt)
+		if c.retry.OnRetry != nil {
+			c.retry.OnRetry(RetryEvent{Method: method, Endpoint: endpoint, Attempt: attempt, Err: err, Backoff: backoff})
+		}
+		if err := sleep(ctx, backoff); err != nil {
+			return nil, err
+		}
+
+		req = req.Clone(ctx)
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+		}
+	}
+}
+
+// sendOnce performs a single attempt of a request
+func (c *Client) sendOnce(req *http.Request, endpoint string, body interface{}) (*http.Response, error) {
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %v", err)
+		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
-		var apiErr Error
-		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil {
-			return fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		return &apiErr
-	}
-
-	if v != nil {
-		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
-			return fmt.Errorf("failed to decode response: %v", err)
-		}
+		defer resp.Body.Close()
+		apiErr := newAPIError(req.Method, endpoint, resp)
+		_, apiErr.sentURI = body.(uriText)
+		return nil, apiErr
 	}
 
-	return nil
+	return resp, nil
 }
 
 // url builds the full URL for an endpoint
@@ -236,15 +286,78 @@ func (c *Client) url(endpoint string) string {
 	return u.String()
 }
 
-// createMultipartForm creates a multipart form writer
-func (c *Client) createMultipartForm(body *bytes.Buffer, thumbnail io.Reader) *multipart.Writer {
-	writer := multipart.NewWriter(body)
-	part, err := writer.CreateFormFile("file", "thumbnail")
-	if err != nil {
-		return nil
+// plainText is a request body sent verbatim as text/plain, used by endpoints
+// taking a name or an action instead of JSON
+type plainText string
+
+// uriText is a plainText body that is a resource URI, such as
+// "effect:///video/Blur" or "/composition/layers/3". A 400 response to it
+// matches ErrInvalidURI.
+type uriText string
+
+// multipartFile is a request body sent as multipart/form-data with a single
+// file, read from content or else written by write
+type multipartFile struct {
+	field    string
+	filename string
+	content  io.Reader
+	write    func(io.Writer) error
+}
+
+// encodeBody encodes a request body and returns it with its content type.
+// plainText, uriText and multipartFile are sent as is, anything else is
+// encoded as JSON.
+func encodeBody(body interface{}) (io.Reader, string, error) {
+	switch body := body.(type) {
+	case nil:
+		return nil, "", nil
+	case plainText:
+		if body == "" {
+			return nil, "", nil
+		}
+		return strings.NewReader(string(body)), "text/plain", nil
+	case uriText:
+		return encodeBody(plainText(body))
+	case multipartFile:
+		return encodeMultipart(body)
+	default:
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		return bytes.NewReader(bodyBytes), "application/json", nil
 	}
+}
 
-	io.Copy(part, thumbnail)
-	writer.Close()
-	return writer
+// encodeMultipart streams a multipart form containing the file through a
+// pipe, so the file is never held in memory. The form is written while the
+// request is sent, and an error writing it fails the request. The body has no
+// GetBody, so the request is not retried.
+func encodeMultipart(file multipartFile) (io.Reader, string, error) {
+	reader, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	go func() {
+		writer.CloseWithError(writeMultipart(form, file))
+	}()
+	return reader, form.FormDataContentType(), nil
+}
+
+// writeMultipart writes the multipart form of a file
+func writeMultipart(form *multipart.Writer, file multipartFile) error {
+	part, err := form.CreateFormFile(file.field, file.filename)
+	if err != nil {
+		return fmt.Errorf("failed to create multipart form: %w", err)
+	}
+	if file.write != nil {
+		err = file.write(part)
+	} else {
+		_, err = io.Copy(part, file.content)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write multipart form: %w", err)
+	}
+	if err := form.Close(); err != nil {
+		return fmt.Errorf("failed to close multipart form: %w", err)
+	}
+	return nil
 }
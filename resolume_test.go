@@ -1,9 +1,10 @@
 package resolume
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
-	"net/url"
 	"testing"
 )
 
@@ -36,10 +37,9 @@ func TestGetProduct(t *testing.T) {
 	defer server.Close()
 
 	// Create client using test server URL
-	serverURL, _ := url.Parse(server.URL + "/api/v1")
-	client := &Client{
-		baseURL:    serverURL,
-		httpClient: server.Client(),
+	client, err := NewClientFromURL(server.URL+"/api/v1", WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewClientFromURL() error = %v", err)
 	}
 
 	// Test GetProduct
@@ -83,10 +83,9 @@ func TestGetEffects(t *testing.T) {
 	defer server.Close()
 
 	// Create client using test server URL
-	serverURL, _ := url.Parse(server.URL + "/api/v1")
-	client := &Client{
-		baseURL:    serverURL,
-		httpClient: server.Client(),
+	client, err := NewClientFromURL(server.URL+"/api/v1", WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewClientFromURL() error = %v", err)
 	}
 
 	// Test GetEffects
@@ -131,10 +130,9 @@ func TestGetSources(t *testing.T) {
 	defer server.Close()
 
 	// Create client using test server URL
-	serverURL, _ := url.Parse(server.URL + "/api/v1")
-	client := &Client{
-		baseURL:    serverURL,
-		httpClient: server.Client(),
+	client, err := NewClientFromURL(server.URL+"/api/v1", WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewClientFromURL() error = %v", err)
 	}
 
 	// Test GetSources
@@ -153,3 +151,26 @@ func TestGetSources(t *testing.T) {
 		t.Errorf("Expected source name Gradient, got %s", sources.Video[0].Name)
 	}
 }
+
+func TestGetProductContextCanceled(t *testing.T) {
+	// Create test server that never answers before the context is canceled
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	// Create client using test server URL
+	client, err := NewClientFromURL(server.URL+"/api/v1", WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewClientFromURL() error = %v", err)
+	}
+
+	// Test GetProductContext with a canceled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.GetProductContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetProductContext() error = %v, want context.Canceled", err)
+	}
+}
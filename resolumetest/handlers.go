@@ -0,0 +1,682 @@
+package resolumetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FlowingSPDG/resolume-go"
+	"github.com/FlowingSPDG/resolume-go/uri"
+)
+
+// defaultThumbnail is served for clips without a thumbnail of their own
+var defaultThumbnail = func() []byte {
+	img := image.NewGray(image.Rect(0, 0, 16, 9))
+	for i := range img.Pix {
+		img.Pix[i] = 0x40
+	}
+	img.SetGray(0, 0, color.Gray{Y: 0x80})
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	return buf.Bytes()
+}()
+
+var errMethodNotAllowed = &httpError{status: http.StatusMethodNotAllowed, message: "method not allowed"}
+
+// route handles a request with mu held. It returns nil for 204, []byte for a
+// PNG image, or a value answered as JSON.
+func (s *Server) route(r *http.Request, segments []string, body []byte) (interface{}, error) {
+	get := r.Method == http.MethodGet
+	switch {
+	case len(segments) == 1 && segments[0] == "product" && get:
+		return s.product, nil
+	case len(segments) == 1 && segments[0] == "effects" && get:
+		return s.effects, nil
+	case len(segments) == 1 && segments[0] == "sources" && get:
+		return s.sources, nil
+	case len(segments) == 3 && segments[0] == "parameter" && segments[1] == "by-id":
+		return s.parameter(r.Method, segments[2], body)
+	case len(segments) == 0 || segments[0] != "composition":
+		return nil, errNotImplemented
+	}
+
+	segments = segments[1:]
+	switch {
+	case len(segments) == 0 && get:
+		return s.composition, nil
+	case len(segments) == 0 && r.Method == http.MethodPut:
+		return nil, s.put(s.composition, body)
+	case len(segments) == 0:
+		return nil, errMethodNotAllowed
+	case len(segments) == 1 && segments[0] == "action" && r.Method == http.MethodPost:
+		switch string(bytes.TrimSpace(body)) {
+		case "undo":
+			s.undoRedo(&s.undo, &s.redo)
+		case "redo":
+			s.undoRedo(&s.redo, &s.undo)
+		default:
+			return nil, badRequest("the action must be undo or redo")
+		}
+		return nil, nil
+	case len(segments) == 1 && segments[0] == "disconnect-all" && r.Method == http.MethodPost:
+		s.disconnectAll()
+		return nil, nil
+	case len(segments) == 2 && segments[0] == "thumbnail" && segments[1] == "dummy" && get:
+		return defaultThumbnail, nil
+	case len(segments) == 2 && segments[1] == "add" && r.Method == http.MethodPost:
+		return nil, s.add(segments[0], string(body))
+	}
+
+	n, rest, err := s.resolve(segments)
+	if err != nil {
+		return nil, err
+	}
+	action := ""
+	if len(rest) > 0 {
+		action = rest[0]
+	}
+	post := r.Method == http.MethodPost
+	switch {
+	case len(rest) == 0 && get:
+		return s.entity(n), nil
+	case len(rest) == 0 && r.Method == http.MethodPut:
+		return nil, s.put(s.entity(n), body)
+	case len(rest) == 0 && r.Method == http.MethodDelete && n.kind != "clips":
+		s.remove(n)
+		return nil, nil
+	case len(rest) == 0:
+		return nil, errMethodNotAllowed
+	case action == "thumbnail" && n.kind == "clips" && len(rest) <= 2:
+		return s.thumbnail(r, n, rest, body)
+	case len(rest) > 1 || !post:
+		return nil, errNotImplemented
+	case action == "select":
+		s.selectNode(n)
+	case action == "duplicate" && n.kind != "clips":
+		s.duplicate(n)
+	case action == "connect" && (n.kind == "clips" || n.kind == "columns"):
+		return nil, s.connect(n, body)
+	case action == "clear" && n.kind == "clips":
+		s.clearClip(s.clip(n))
+	case action == "clear" && n.kind == "layers":
+		disconnectLayer(&s.composition.Layers[n.index])
+	case action == "clear" && n.kind == "layergroups":
+		for i := range s.composition.LayerGroups[n.index].Layers {
+			disconnectLayer(&s.composition.LayerGroups[n.index].Layers[i])
+		}
+	case action == "clearclips" && n.kind == "layers":
+		for i := range s.composition.Layers[n.index].Clips {
+			s.clearClip(&s.composition.Layers[n.index].Clips[i])
+		}
+	case (action == "open" || action == "openfile") && n.kind == "clips":
+		return nil, s.open(s.clip(n), string(body), action == "openfile")
+	case (action == "open" || action == "close") && n.kind == "decks":
+		s.composition.Decks[n.index].Closed = action == "close"
+	default:
+		return nil, errNotImplemented
+	}
+	return nil, nil
+}
+
+// node is a layer, column, deck, layer group or clip of the composition
+type node struct {
+	// kind is the name of its list: "layers", "columns", "decks",
+	// "layergroups" or "clips"
+	kind string
+	// index is the 0-based index in the list, or of the layer of a clip
+	index int
+	// column is the 0-based index of a clip in its layer
+	column int
+}
+
+// kinds are the kinds of nodes
+var kinds = []string{"layers", "columns", "decks", "layergroups", "clips"}
+
+// nodes returns the nodes of a kind
+func (s *Server) nodes(kind string) []node {
+	c := s.composition
+	var count int
+	switch kind {
+	case "layers":
+		count = len(c.Layers)
+	case "columns":
+		count = len(c.Columns)
+	case "decks":
+		count = len(c.Decks)
+	case "layergroups":
+		count = len(c.LayerGroups)
+	case "clips":
+		var nodes []node
+		for i, layer := range c.Layers {
+			for j := range layer.Clips {
+				nodes = append(nodes, node{kind: kind, index: i, column: j})
+			}
+		}
+		return nodes
+	}
+	nodes := make([]node, count)
+	for i := range nodes {
+		nodes[i] = node{kind: kind, index: i}
+	}
+	return nodes
+}
+
+// resolve returns the node addressed by the segments of a path below
+// /composition, such as layers/2/clips/3 or clips/by-id/12, and the
+// remaining segments
+func (s *Server) resolve(segments []string) (node, []string, error) {
+	if len(segments) < 2 {
+		return node{}, nil, errNotImplemented
+	}
+	kind, key := segments[0], segments[1]
+	if !slices.Contains(kinds, kind) {
+		return node{}, nil, errNotImplemented
+	}
+	nodes := s.nodes(kind)
+	what := strings.TrimSuffix(kind, "s")
+
+	switch {
+	case key == "selected":
+		for _, n := range nodes {
+			if p := selectedParam(s.entity(n)); p != nil && p.Value {
+				return n, segments[2:], nil
+			}
+		}
+		return node{}, nil, notFound("selected " + what)
+	case key == "by-id" && len(segments) > 2:
+		id, err := strconv.ParseInt(segments[2], 10, 64)
+		if err != nil {
+			return node{}, nil, notFound(what + " " + segments[2])
+		}
+		for _, n := range nodes {
+			if entityID(s.entity(n)) == id {
+				return n, segments[3:], nil
+			}
+		}
+		return node{}, nil, notFound(what + " " + segments[2])
+	case kind == "clips":
+		return node{}, nil, errNotImplemented
+	}
+
+	index, err := strconv.Atoi(key)
+	if err != nil || index < 1 || index > len(nodes) {
+		return node{}, nil, notFound(what + " " + key)
+	}
+	n, rest := nodes[index-1], segments[2:]
+	if kind == "layers" && len(rest) >= 2 && rest[0] == "clips" {
+		column, err := strconv.Atoi(rest[1])
+		if err != nil || column < 1 || column > len(s.composition.Layers[n.index].Clips) {
+			return node{}, nil, notFound("clip " + rest[1])
+		}
+		n, rest = node{kind: "clips", index: n.index, column: column - 1}, rest[2:]
+	}
+	return n, rest, nil
+}
+
+// entity returns a pointer to the struct of a node
+func (s *Server) entity(n node) interface{} {
+	c := s.composition
+	switch n.kind {
+	case "layers":
+		return &c.Layers[n.index]
+	case "columns":
+		return &c.Columns[n.index]
+	case "decks":
+		return &c.Decks[n.index]
+	case "layergroups":
+		return &c.LayerGroups[n.index]
+	default:
+		return s.clip(n)
+	}
+}
+
+func (s *Server) clip(n node) *resolume.Clip {
+	return &s.composition.Layers[n.index].Clips[n.column]
+}
+
+func entityID(v interface{}) int64 {
+	switch v := v.(type) {
+	case *resolume.Layer:
+		return v.ID
+	case *resolume.Column:
+		return v.ID
+	case *resolume.Deck:
+		return v.ID
+	case *resolume.LayerGroup:
+		return v.ID
+	case *resolume.Clip:
+		return v.ID
+	}
+	return 0
+}
+
+func selectedParam(v interface{}) *resolume.BooleanParameter {
+	switch v := v.(type) {
+	case *resolume.Layer:
+		return v.Selected
+	case *resolume.Column:
+		return v.Selected
+	case *resolume.Deck:
+		return v.Selected
+	case *resolume.LayerGroup:
+		return v.Selected
+	case *resolume.Clip:
+		return v.Selected
+	}
+	return nil
+}
+
+// parameter gets or sets a parameter by id
+func (s *Server) parameter(method, id string, body []byte) (interface{}, error) {
+	parameterID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, notFound("parameter " + id)
+	}
+	var found resolume.Parameter
+	s.composition.Walk(func(_ resolume.Path, node interface{}) error {
+		if p, ok := node.(resolume.Parameter); ok && p.ParameterID() == parameterID {
+			found = p
+			return errStop
+		}
+		return nil
+	})
+	if found == nil {
+		return nil, notFound("parameter " + id)
+	}
+	switch method {
+	case http.MethodGet:
+		return found, nil
+	case http.MethodPut:
+		return nil, s.put(found, body)
+	}
+	return nil, errMethodNotAllowed
+}
+
+var errStop = errors.New("stop")
+
+// put merges a partial JSON body into the struct pointed to by target
+func (s *Server) put(target interface{}, body []byte) error {
+	var patch, current interface{}
+	if err := json.Unmarshal(body, &patch); err != nil {
+		return badRequest("invalid JSON: " + err.Error())
+	}
+	copyJSON(target, &current)
+	data, err := json.Marshal(merge(current, patch))
+	if err != nil {
+		return badRequest(err.Error())
+	}
+	updated := reflect.New(reflect.TypeOf(target).Elem())
+	if err := json.Unmarshal(data, updated.Interface()); err != nil {
+		return badRequest(err.Error())
+	}
+	reflect.ValueOf(target).Elem().Set(updated.Elem())
+	s.normalize()
+	s.assignIDs()
+	return nil
+}
+
+// add adds a layer, column, deck or layer group at the end, or before the
+// one identified by a URI such as /composition/layers/2
+func (s *Server) add(kind, before string) error {
+	c := s.composition
+	index := len(s.nodes(kind))
+	if before = strings.TrimSpace(before); before != "" {
+		segments := strings.Split(strings.Trim(before, "/"), "/")
+		if len(segments) < 3 || segments[0] != "composition" {
+			return badRequest("invalid URI: " + before)
+		}
+		n, rest, err := s.resolve(segments[1:])
+		if err != nil || n.kind != kind || len(rest) > 0 {
+			return badRequest("invalid URI: " + before)
+		}
+		index = n.index
+	}
+
+	switch kind {
+	case "layers":
+		c.Layers = slices.Insert(c.Layers, index, newLayer(len(c.Layers)+1, len(c.Columns)))
+	case "columns":
+		c.Columns = slices.Insert(c.Columns, index, newColumn(len(c.Columns)+1))
+		for i := range c.Layers {
+			c.Layers[i].Clips = slices.Insert(c.Layers[i].Clips, index, newClip())
+		}
+	case "decks":
+		c.Decks = slices.Insert(c.Decks, index, newDeck(len(c.Decks)+1))
+	case "layergroups":
+		group := resolume.LayerGroup{Name: str("Group #" + strconv.Itoa(len(c.LayerGroups)+1)), Selected: boolean(false)}
+		c.LayerGroups = slices.Insert(c.LayerGroups, index, group)
+	default:
+		return errNotImplemented
+	}
+	s.assignIDs()
+	return nil
+}
+
+// duplicate inserts a copy of a node with new ids after it. The clips of a
+// copy are disconnected and keep their thumbnails.
+func (s *Server) duplicate(n node) {
+	c := s.composition
+	i := n.index
+	switch n.kind {
+	case "layers":
+		var layer resolume.Layer
+		withoutIDs(&c.Layers[i], &layer)
+		layer.Selected = boolean(false)
+		for j := range layer.Clips {
+			disconnectClip(&layer.Clips[j])
+		}
+		c.Layers = slices.Insert(c.Layers, i+1, layer)
+	case "columns":
+		var column resolume.Column
+		withoutIDs(&c.Columns[i], &column)
+		column.Selected = boolean(false)
+		c.Columns = slices.Insert(c.Columns, i+1, column)
+		for j := range c.Layers {
+			var clip resolume.Clip
+			withoutIDs(&c.Layers[j].Clips[i], &clip)
+			disconnectClip(&clip)
+			c.Layers[j].Clips = slices.Insert(c.Layers[j].Clips, i+1, clip)
+		}
+	case "decks":
+		var deck resolume.Deck
+		withoutIDs(&c.Decks[i], &deck)
+		deck.Selected = boolean(false)
+		c.Decks = slices.Insert(c.Decks, i+1, deck)
+	case "layergroups":
+		var group resolume.LayerGroup
+		withoutIDs(&c.LayerGroups[i], &group)
+		group.Selected = boolean(false)
+		c.LayerGroups = slices.Insert(c.LayerGroups, i+1, group)
+	}
+	s.assignIDs()
+
+	// Copy the thumbnails of the duplicated clips
+	c = s.composition
+	switch n.kind {
+	case "layers":
+		for j, clip := range c.Layers[i].Clips {
+			s.copyThumbnail(clip.ID, c.Layers[i+1].Clips[j].ID)
+		}
+	case "columns":
+		for _, layer := range c.Layers {
+			s.copyThumbnail(layer.Clips[i].ID, layer.Clips[i+1].ID)
+		}
+	}
+}
+
+func (s *Server) copyThumbnail(from, to int64) {
+	if data, ok := s.thumbnails[from]; ok {
+		s.thumbnails[to] = data
+	}
+}
+
+// remove deletes a layer, column, deck or layer group. Deleting a column
+// deletes its clips.
+func (s *Server) remove(n node) {
+	c := s.composition
+	i := n.index
+	switch n.kind {
+	case "layers":
+		for _, clip := range c.Layers[i].Clips {
+			delete(s.thumbnails, clip.ID)
+		}
+		c.Layers = slices.Delete(c.Layers, i, i+1)
+	case "columns":
+		c.Columns = slices.Delete(c.Columns, i, i+1)
+		for j := range c.Layers {
+			if i < len(c.Layers[j].Clips) {
+				delete(s.thumbnails, c.Layers[j].Clips[i].ID)
+				c.Layers[j].Clips = slices.Delete(c.Layers[j].Clips, i, i+1)
+			}
+		}
+	case "decks":
+		c.Decks = slices.Delete(c.Decks, i, i+1)
+	case "layergroups":
+		c.LayerGroups = slices.Delete(c.LayerGroups, i, i+1)
+	}
+}
+
+// selectNode selects a node and deselects the others of its kind
+func (s *Server) selectNode(n node) {
+	for _, other := range s.nodes(n.kind) {
+		if p := selectedParam(s.entity(other)); p != nil {
+			p.Value = other == n
+		}
+	}
+}
+
+// connect connects or disconnects a clip or column. The body is true, false
+// or null, null and an empty body meaning true.
+func (s *Server) connect(n node, body []byte) error {
+	var on *bool
+	if body = bytes.TrimSpace(body); len(body) > 0 {
+		if err := json.Unmarshal(body, &on); err != nil {
+			return badRequest("the body must be true, false or null")
+		}
+	}
+	disconnect := on != nil && !*on
+	c := s.composition
+
+	if n.kind == "clips" {
+		if disconnect {
+			disconnectClip(s.clip(n))
+		} else {
+			connectClip(&c.Layers[n.index], n.column)
+		}
+		return nil
+	}
+
+	for i := range c.Layers {
+		layer := &c.Layers[i]
+		if n.index >= len(layer.Clips) {
+			continue
+		}
+		if disconnect {
+			disconnectClip(&layer.Clips[n.index])
+		} else if layer.IgnoreColumnTrigger == nil || !layer.IgnoreColumnTrigger.Value {
+			connectClip(layer, n.index)
+		}
+	}
+	for i := range c.Columns {
+		if p := c.Columns[i].Connected; p != nil && (i == n.index || p.Value == connected) {
+			state := disconnected
+			if i == n.index && !disconnect {
+				state = connected
+			}
+			setChoice(p, state)
+		}
+	}
+	return nil
+}
+
+// connectClip connects a clip of a layer and disconnects the others.
+// Connecting an empty clip disconnects the layer, as in Resolume.
+func connectClip(layer *resolume.Layer, column int) {
+	disconnectLayer(layer)
+	if clip := &layer.Clips[column]; clip.Connected != nil && clip.Connected.Value != empty {
+		setChoice(clip.Connected, connected)
+	}
+}
+
+func disconnectLayer(layer *resolume.Layer) {
+	for i := range layer.Clips {
+		disconnectClip(&layer.Clips[i])
+	}
+}
+
+func disconnectClip(clip *resolume.Clip) {
+	if clip.Connected != nil && clip.Connected.Value != empty {
+		setChoice(clip.Connected, disconnected)
+	}
+}
+
+func (s *Server) disconnectAll() {
+	c := s.composition
+	for i := range c.Layers {
+		disconnectLayer(&c.Layers[i])
+	}
+	for i := range c.Columns {
+		if p := c.Columns[i].Connected; p != nil && p.Value != empty {
+			setChoice(p, disconnected)
+		}
+	}
+}
+
+// clearClip empties a clip
+func (s *Server) clearClip(clip *resolume.Clip) {
+	if clip.Name != nil {
+		clip.Name.Value = ""
+	}
+	if clip.Connected != nil {
+		setChoice(clip.Connected, empty)
+	}
+	if clip.Video != nil {
+		clip.Video.Description = ""
+		clip.Video.FileInfo = nil
+	}
+	delete(s.thumbnails, clip.ID)
+	clip.Thumbnail = noThumbnail()
+}
+
+// open opens a file or source URI into a clip, naming the clip after it
+func (s *Server) open(clip *resolume.Clip, body string, fileOnly bool) error {
+	u, err := uri.Parse(strings.TrimSpace(body))
+	if err != nil {
+		return badRequest(err.Error())
+	}
+	var name string
+	var fileInfo *resolume.VideoFileInfo
+	switch u := u.(type) {
+	case uri.File:
+		name = strings.TrimSuffix(path.Base(u.Path), path.Ext(u.Path))
+		fileInfo = &resolume.VideoFileInfo{Path: u.Path, Exists: true}
+	case uri.Source:
+		if fileOnly {
+			return badRequest("not a file URI: " + body)
+		}
+		name = u.Name
+	default:
+		return badRequest("not a file or source URI: " + body)
+	}
+
+	if clip.Name == nil {
+		clip.Name = str("")
+	}
+	clip.Name.Value = name
+	if clip.Connected == nil || clip.Connected.Value == empty {
+		clip.Connected = choice(disconnected, connectedOptions)
+	}
+	if clip.Video == nil {
+		clip.Video = &resolume.VideoTrackClip{}
+	}
+	clip.Video.Description = name
+	clip.Video.FileInfo = fileInfo
+	delete(s.thumbnails, clip.ID)
+	clip.Thumbnail = &clipThumbnail{LastUpdate: s.lastUpdate()}
+	s.assignIDs()
+	return nil
+}
+
+// thumbnail gets, sets or resets the thumbnail of a clip. A trailing
+// timestamp segment must match the last update of the thumbnail.
+func (s *Server) thumbnail(r *http.Request, n node, rest []string, body []byte) (interface{}, error) {
+	clip := s.clip(n)
+	if len(rest) == 2 && (clip.Thumbnail == nil || rest[1] != clip.Thumbnail.LastUpdate) {
+		return nil, notFound("thumbnail " + rest[1])
+	}
+	switch r.Method {
+	case http.MethodGet:
+		if data, ok := s.thumbnails[clip.ID]; ok {
+			return data, nil
+		}
+		return defaultThumbnail, nil
+	case http.MethodPost:
+		data, err := thumbnailFile(r, body)
+		if err != nil {
+			return nil, err
+		}
+		s.thumbnails[clip.ID] = data
+		clip.Thumbnail = &clipThumbnail{Size: int64(len(data)), LastUpdate: s.lastUpdate()}
+		return nil, nil
+	case http.MethodDelete:
+		delete(s.thumbnails, clip.ID)
+		clip.Thumbnail = &clipThumbnail{LastUpdate: s.lastUpdate()}
+		if clip.Connected == nil || clip.Connected.Value == empty {
+			clip.Thumbnail = noThumbnail()
+		}
+		return nil, nil
+	}
+	return nil, errMethodNotAllowed
+}
+
+// thumbnailFile returns the image of a thumbnail POST: the "file" field of a
+// multipart body, or the file of a text/plain file URI read from the local
+// disk, as Resolume reads its own. Sources get the default thumbnail.
+func thumbnailFile(r *http.Request, body []byte) ([]byte, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "text/plain" {
+		data, err := multipartFile(r, body)
+		if err != nil {
+			return nil, badRequest(err.Error())
+		}
+		return data, nil
+	}
+	u, err := uri.Parse(strings.TrimSpace(string(body)))
+	if err != nil {
+		return nil, badRequest(err.Error())
+	}
+	switch u := u.(type) {
+	case uri.File:
+		data, err := os.ReadFile(filepath.FromSlash(u.LocalPath()))
+		if err != nil {
+			return nil, badRequest(err.Error())
+		}
+		return data, nil
+	case uri.Source:
+		return defaultThumbnail, nil
+	}
+	return nil, badRequest("not a file or source URI: " + string(body))
+}
+
+// multipartFile returns the content of the "file" field of a multipart body
+func multipartFile(r *http.Request, body []byte) ([]byte, error) {
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return nil, badRequest("the body must be multipart/form-data")
+	}
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, badRequest(`the body has no "file" field`)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == "file" {
+			return io.ReadAll(part)
+		}
+	}
+}
+
+// lastUpdate returns the current time in milliseconds for the last_update of
+// a thumbnail, later than the previous one so every update has its own
+func (s *Server) lastUpdate() string {
+	s.lastThumbnail = max(time.Now().UnixMilli(), s.lastThumbnail+1)
+	return strconv.FormatInt(s.lastThumbnail, 10)
+}
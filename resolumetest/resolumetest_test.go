@@ -0,0 +1,304 @@
+package resolumetest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FlowingSPDG/resolume-go"
+	"github.com/FlowingSPDG/resolume-go/uri"
+)
+
+// ids returns the ids of the composition, failing on duplicates
+func ids(t *testing.T, c *resolume.Composition) map[int64]bool {
+	t.Helper()
+	seen := map[int64]bool{}
+	c.Walk(func(path resolume.Path, node interface{}) error {
+		var id int64
+		switch n := node.(type) {
+		case resolume.Parameter:
+			id = n.ParameterID()
+		case *resolume.Layer:
+			id = n.ID
+		case *resolume.Column:
+			id = n.ID
+		case *resolume.Clip:
+			id = n.ID
+		default:
+			return nil
+		}
+		if id == 0 || seen[id] {
+			t.Errorf("%s has id %d, want a unique id", path, id)
+		}
+		seen[id] = true
+		return nil
+	})
+	return seen
+}
+
+func state(c *resolume.Composition, layer, column int64) string {
+	return c.Clip(layer, column).Connected.Value
+}
+
+func TestComposition(t *testing.T) {
+	server := NewServer(WithSize(2, 3))
+	defer server.Close()
+	client := server.Client()
+
+	composition, err := client.GetComposition()
+	if err != nil {
+		t.Fatalf("GetComposition() error = %v", err)
+	}
+	if len(composition.Layers) != 2 || len(composition.Columns) != 3 || len(composition.Layers[1].Clips) != 3 {
+		t.Fatalf("GetComposition() has %d layers and %d columns", len(composition.Layers), len(composition.Columns))
+	}
+	ids(t, composition)
+
+	product, err := client.GetProduct()
+	if err != nil || product.Name != "Arena" {
+		t.Errorf("GetProduct() = %+v, %v", product, err)
+	}
+	if _, err := client.GetLayer(3); !errors.Is(err, resolume.ErrNotFound) {
+		t.Errorf("GetLayer(3) error = %v, want ErrNotFound", err)
+	}
+	if _, err := client.GetSelectedClip(); !errors.Is(err, resolume.ErrNotFound) {
+		t.Errorf("GetSelectedClip() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestConnectAndSelect(t *testing.T) {
+	server := NewServer(WithSize(2, 3))
+	defer server.Close()
+	client := server.Client()
+
+	for _, clip := range [][2]int64{{1, 1}, {1, 2}, {2, 2}} {
+		if err := client.OpenClip(clip[0], clip[1], "file:///C:/Videos/clip%201.mov"); err != nil {
+			t.Fatalf("OpenClip(%v) error = %v", clip, err)
+		}
+	}
+	if err := client.OpenClip(1, 3, "effect:///video/Blur"); !errors.Is(err, resolume.ErrInvalidURI) {
+		t.Errorf("OpenClip() with an invalid URI error = %v, want ErrInvalidURI", err)
+	}
+	c := server.Composition()
+	if c.Clip(1, 1).Name.Value != "clip 1" || c.Clip(1, 1).Video.FileInfo.Path != "/C:/Videos/clip 1.mov" || state(c, 1, 1) != "Disconnected" {
+		t.Errorf("Opened clip = %+v", c.Clip(1, 1))
+	}
+
+	if err := client.ConnectClip(1, 1, nil); err != nil {
+		t.Fatalf("ConnectClip() error = %v", err)
+	}
+	if err := client.ConnectClip(1, 2, nil); err != nil {
+		t.Fatalf("ConnectClip() error = %v", err)
+	}
+	c = server.Composition()
+	if state(c, 1, 1) != "Disconnected" || state(c, 1, 2) != "Connected" || state(c, 1, 3) != "Empty" {
+		t.Errorf("Connecting a clip did not disconnect the others of its layer")
+	}
+
+	if err := client.ConnectColumn(2, nil); err != nil {
+		t.Fatalf("ConnectColumn() error = %v", err)
+	}
+	c = server.Composition()
+	if state(c, 2, 2) != "Connected" || c.Columns[1].Connected.Value != "Connected" {
+		t.Errorf("ConnectColumn() did not connect the clips of the column")
+	}
+	if err := client.ConnectColumn(3, nil); err != nil {
+		t.Fatalf("ConnectColumn() error = %v", err)
+	}
+	c = server.Composition()
+	if state(c, 1, 2) != "Disconnected" || c.Columns[1].Connected.Value != "Disconnected" {
+		t.Errorf("Connecting an empty column did not clear the layers")
+	}
+
+	client.ConnectClip(1, 1, nil)
+	if err := client.DisconnectAllClips(); err != nil {
+		t.Fatalf("DisconnectAllClips() error = %v", err)
+	}
+	if state(server.Composition(), 1, 1) != "Disconnected" {
+		t.Errorf("DisconnectAllClips() did not disconnect the clip")
+	}
+
+	clipID := c.Clip(2, 2).ID
+	if err := client.SelectClipByID(clipID); err != nil {
+		t.Fatalf("SelectClipByID() error = %v", err)
+	}
+	client.SelectClip(1, 1)
+	client.SelectClipByID(clipID)
+	clip, err := client.GetSelectedClip()
+	if err != nil || clip.ID != clipID {
+		t.Errorf("GetSelectedClip() = %v, %v, want clip %d", clip, err, clipID)
+	}
+	if c := server.Composition(); c.Clip(1, 1).Selected.Value {
+		t.Errorf("Selecting a clip did not deselect the others")
+	}
+}
+
+func TestAddDuplicateDelete(t *testing.T) {
+	server := NewServer(WithSize(2, 2))
+	defer server.Close()
+	client := server.Client()
+	client.OpenClip(1, 2, "source:///video/Checkered")
+
+	if err := client.AddLayer(""); err != nil {
+		t.Fatalf("AddLayer() error = %v", err)
+	}
+	if err := client.AddColumn("/composition/columns/1"); err != nil {
+		t.Fatalf("AddColumn() error = %v", err)
+	}
+	if err := client.DuplicateColumn(3); err != nil {
+		t.Fatalf("DuplicateColumn() error = %v", err)
+	}
+	c := server.Composition()
+	if len(c.Layers) != 3 || len(c.Columns) != 4 || len(c.Layers[2].Clips) != 4 {
+		t.Fatalf("Composition has %d layers and %d columns, want 3 and 4", len(c.Layers), len(c.Columns))
+	}
+	if c.Clip(1, 3).Name.Value != "Checkered" || c.Clip(1, 4).Name.Value != "Checkered" || c.Clip(1, 1).Name.Value != "" {
+		t.Errorf("Column 3 should be the clip column and column 4 its copy")
+	}
+	ids(t, c)
+
+	if err := client.DeleteLayer(1); err != nil {
+		t.Fatalf("DeleteLayer() error = %v", err)
+	}
+	if err := client.DeleteColumn(4); err != nil {
+		t.Fatalf("DeleteColumn() error = %v", err)
+	}
+	c = server.Composition()
+	if len(c.Layers) != 2 || len(c.Layers[0].Clips) != 3 || c.Layers[1].Name.Value != "Layer #3" {
+		t.Errorf("Composition after deleting has %d layers of %d clips", len(c.Layers), len(c.Layers[0].Clips))
+	}
+	var apiErr *resolume.APIError
+	if err := client.AddLayer("/composition/layers/9"); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("AddLayer() before a missing layer error = %v, want 400", err)
+	}
+}
+
+func TestParametersAndUndo(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	master := server.Composition().Layers[0].Master
+
+	if err := client.SetParameterByID(master.ID, map[string]interface{}{"value": 0.25}); err != nil {
+		t.Fatalf("SetParameterByID() error = %v", err)
+	}
+	p, err := client.GetRangeParameter(master.ID)
+	if err != nil || p.Value != 0.25 {
+		t.Errorf("GetRangeParameter() = %+v, %v, want 0.25", p, err)
+	}
+	client.SetParameterByID(master.ID, map[string]interface{}{"value": 3})
+	if p, _ := client.GetRangeParameter(master.ID); p.Value != 1 {
+		t.Errorf("Value out of range = %v, want it clamped to 1", p.Value)
+	}
+	if err := client.UpdateLayer(1, resolume.PatchLayer().Name("Background")); err != nil {
+		t.Fatalf("UpdateLayer() error = %v", err)
+	}
+	if c := server.Composition(); c.Layers[0].Name.Value != "Background" || c.Layers[0].Name.ID == 0 {
+		t.Errorf("UpdateLayer() set the name to %+v", c.Layers[0].Name)
+	}
+
+	client.CompositionAction("undo")
+	client.CompositionAction("undo")
+	if p, _ := client.GetRangeParameter(master.ID); p.Value != 0.25 || server.Composition().Layers[0].Name.Value != "Layer #1" {
+		t.Errorf("Undo() did not restore the previous state")
+	}
+	client.CompositionAction("redo")
+	if p, _ := client.GetRangeParameter(master.ID); p.Value != 1 {
+		t.Errorf("Redo() value = %v, want 1", p.Value)
+	}
+	if _, err := client.GetParameter(999999); !errors.Is(err, resolume.ErrNotFound) {
+		t.Errorf("GetParameter() of a missing id error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestThumbnails(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	if err := client.SetClipThumbnail(1, 1, strings.NewReader("png data")); err != nil {
+		t.Fatalf("SetClipThumbnail() error = %v", err)
+	}
+	clip := server.Composition().Clip(1, 1)
+	if clip.Thumbnail.IsDefault || clip.Thumbnail.Size != 8 {
+		t.Errorf("Thumbnail = %+v", clip.Thumbnail)
+	}
+	rc, err := client.GetClipThumbnail(1, 1)
+	if err != nil {
+		t.Fatalf("GetClipThumbnail() error = %v", err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(data) != "png data" {
+		t.Errorf("GetClipThumbnail() = %q", data)
+	}
+
+	if err := client.ResetClipThumbnail(1, 1); err != nil {
+		t.Fatalf("ResetClipThumbnail() error = %v", err)
+	}
+	if _, ok := server.Thumbnail(clip.ID); ok {
+		t.Error("ResetClipThumbnail() kept the thumbnail")
+	}
+	rc, _ = client.GetClipThumbnail(1, 1)
+	data, _ = io.ReadAll(rc)
+	rc.Close()
+	if !bytes.Equal(data, defaultThumbnail) {
+		t.Error("Expected the default thumbnail after a reset")
+	}
+
+	// A file URI is read from the disk
+	file := filepath.Join(t.TempDir(), "thumbnail.png")
+	os.WriteFile(file, []byte("file data"), 0o644)
+	if err := client.SetClipThumbnailFromFile(1, 1, uri.FileURI(file).String()); err != nil {
+		t.Fatalf("SetClipThumbnailFromFile() error = %v", err)
+	}
+	if data, _ := server.Thumbnail(clip.ID); string(data) != "file data" {
+		t.Errorf("Thumbnail after SetClipThumbnailFromFile() = %q", data)
+	}
+	if err := client.SetClipThumbnailFromFile(1, 1, "file:///missing.png"); !errors.Is(err, resolume.ErrInvalidURI) {
+		t.Errorf("SetClipThumbnailFromFile() of a missing file error = %v, want ErrInvalidURI", err)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	server.FailNext(http.StatusPreconditionFailed, "the clip cannot be changed currently")
+	err := client.SelectClip(1, 1)
+	var apiErr *resolume.APIError
+	if !errors.Is(err, resolume.ErrPreconditionFailed) || !errors.As(err, &apiErr) || apiErr.Message != "the clip cannot be changed currently" {
+		t.Errorf("SelectClip() error = %v, want the injected error", err)
+	}
+	if server.Composition().Clip(1, 1).Selected.Value {
+		t.Error("A failed request changed the composition")
+	}
+
+	server.SetHook(func(r Request) *Fault {
+		if strings.HasSuffix(r.Path, "/select") {
+			return &Fault{Delay: time.Second}
+		}
+		return nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := client.SelectClipContext(ctx, 1, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SelectClipContext() error = %v, want a timeout", err)
+	}
+	if _, err := client.GetProduct(); err != nil {
+		t.Errorf("GetProduct() error = %v", err)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 3 || requests[1].Method != "POST" || requests[1].Path != "/composition/layers/1/clips/1/select" {
+		t.Errorf("Requests() = %+v", requests)
+	}
+}
@@ -0,0 +1,365 @@
+// Package resolumetest provides an in-process fake of the Resolume REST API
+// for tests, so automation built on the client can run without Resolume.
+//
+// The Server keeps a mutable composition of layers, columns, clips and decks
+// where every parameter has an id, and implements the endpoints changing it:
+// PUT of partial JSON, select and connect, add, duplicate and delete, opening
+// files and sources into clips, clip thumbnails, parameters by id and
+// undo/redo. Latency and errors can be injected with Faults.
+//
+//	server := resolumetest.NewServer(resolumetest.WithSize(2, 3))
+//	defer server.Close()
+//	client := server.Client()
+//
+// Endpoints the Server does not implement, such as effects, answer 404 with
+// "not implemented by resolumetest".
+package resolumetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/FlowingSPDG/resolume-go"
+)
+
+// BasePath is the path of the API on the Server
+const BasePath = "/api/v1"
+
+// Request is a request received by the Server
+type Request struct {
+	Method string
+	// Path is the endpoint without BasePath, e.g. "/composition/layers/1/select"
+	Path string
+	Body []byte
+}
+
+// Fault is a delay or an error injected into a request
+type Fault struct {
+	// Delay is waited before answering the request
+	Delay time.Duration
+	// Status, if not zero, is answered instead of handling the request
+	Status int
+	// Message is the plain-text body of the error response
+	Message string
+}
+
+// Server is a fake Resolume web server
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	composition *resolume.Composition
+	lastID      int64
+	thumbnails  map[int64][]byte
+	// lastThumbnail is the last update of the latest thumbnail change
+	lastThumbnail int64
+	undo, redo    []snapshot
+	product       resolume.ProductInfo
+	effects       resolume.Effects
+	sources       resolume.Sources
+	latency       time.Duration
+	faults        []Fault
+	hook          func(Request) *Fault
+	requests      []Request
+
+	layers, columns int
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithSize sets the number of layers and columns of the default composition,
+// 3 layers of 4 columns by default
+func WithSize(layers, columns int) Option {
+	return func(s *Server) {
+		s.layers, s.columns = layers, columns
+	}
+}
+
+// WithComposition starts the Server with a copy of a composition instead of
+// the default one. Parameters without an id are given one.
+func WithComposition(composition *resolume.Composition) Option {
+	return func(s *Server) {
+		s.composition = composition
+	}
+}
+
+// WithProduct sets the product served by /product
+func WithProduct(product resolume.ProductInfo) Option {
+	return func(s *Server) {
+		s.product = product
+	}
+}
+
+// WithEffects sets the effects served by /effects
+func WithEffects(effects resolume.Effects) Option {
+	return func(s *Server) {
+		s.effects = effects
+	}
+}
+
+// WithSources sets the sources served by /sources
+func WithSources(sources resolume.Sources) Option {
+	return func(s *Server) {
+		s.sources = sources
+	}
+}
+
+// WithLatency delays every response
+func WithLatency(latency time.Duration) Option {
+	return func(s *Server) {
+		s.latency = latency
+	}
+}
+
+// WithHook sets a function called with every request before it is handled.
+// A non-nil Fault it returns is applied to the request.
+func WithHook(hook func(Request) *Fault) Option {
+	return func(s *Server) {
+		s.hook = hook
+	}
+}
+
+// NewServer starts a Server. Close it when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		thumbnails: map[int64][]byte{},
+		product:    resolume.ProductInfo{Name: "Arena", Major: 7, Minor: 20},
+		layers:     3,
+		columns:    4,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.composition == nil {
+		s.composition = s.newComposition(s.layers, s.columns)
+	} else {
+		var c resolume.Composition
+		copyJSON(s.composition, &c)
+		s.composition = &c
+		s.assignIDs()
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns a Client of the Server. It panics if the client cannot be
+// created, which only happens with invalid options.
+func (s *Server) Client(opts ...resolume.Option) *resolume.Client {
+	opts = append([]resolume.Option{resolume.WithHTTPClient(s.Server.Client())}, opts...)
+	client, err := resolume.NewClientFromURL(s.URL+BasePath, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// Composition returns a copy of the current composition
+func (s *Server) Composition() *resolume.Composition {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var c resolume.Composition
+	copyJSON(s.composition, &c)
+	return &c
+}
+
+// Update changes the composition in place, e.g. to set up a test. New
+// parameters without an id are given one. The change cannot be undone.
+func (s *Server) Update(fn func(*resolume.Composition)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.composition)
+	s.assignIDs()
+}
+
+// SetLatency delays every following response
+func (s *Server) SetLatency(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = latency
+}
+
+// SetHook replaces the function set by WithHook, nil removes it
+func (s *Server) SetHook(hook func(Request) *Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hook = hook
+}
+
+// FailNext makes the next request fail with a status and plain-text message.
+// Calls add up, failing as many following requests.
+func (s *Server) FailNext(status int, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, Fault{Status: status, Message: message})
+}
+
+// Requests returns the requests received since the previous call
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requests := s.requests
+	s.requests = nil
+	return requests
+}
+
+// Thumbnail returns the thumbnail set on a clip, and false if it has the default one
+func (s *Server) Thumbnail(clipID int64) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.thumbnails[clipID]
+	return data, ok
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req := Request{Method: r.Method, Path: strings.TrimPrefix(r.URL.Path, BasePath), Body: body}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	fault := Fault{Delay: s.latency}
+	if len(s.faults) > 0 {
+		fault.Status, fault.Message = s.faults[0].Status, s.faults[0].Message
+		s.faults = s.faults[1:]
+	} else if s.hook != nil {
+		hook := s.hook
+		s.mu.Unlock()
+		if f := hook(req); f != nil {
+			fault.Delay += f.Delay
+			fault.Status, fault.Message = f.Status, f.Message
+		}
+		s.mu.Lock()
+	}
+	s.mu.Unlock()
+
+	if fault.Delay > 0 {
+		select {
+		case <-time.After(fault.Delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if fault.Status != 0 {
+		http.Error(w, fault.Message, fault.Status)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, BasePath+"/") {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	// Encode the response before unlocking, it may point into the composition
+	s.mu.Lock()
+	resp, err := s.handle(r, req)
+	contentType := "image/png"
+	data, isImage := resp.([]byte)
+	if err == nil && resp != nil && !isImage {
+		contentType = "application/json"
+		data, err = json.Marshal(resp)
+	}
+	s.mu.Unlock()
+
+	var e *httpError
+	switch {
+	case err == nil && resp == nil:
+		w.WriteHeader(http.StatusNoContent)
+	case err == nil:
+		w.Header().Set("Content-Type", contentType)
+		w.Write(data)
+	case errors.As(err, &e):
+		http.Error(w, e.message, e.status)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// handle handles a request with mu held. Requests changing the composition
+// are recorded for undo.
+func (s *Server) handle(r *http.Request, req Request) (interface{}, error) {
+	segments := strings.Split(strings.Trim(req.Path, "/"), "/")
+	if r.Method == http.MethodGet || req.Path == "/composition/action" {
+		return s.route(r, segments, req.Body)
+	}
+	before := s.snapshot()
+	resp, err := s.route(r, segments, req.Body)
+	if err == nil && !bytes.Equal(before.composition, s.snapshot().composition) {
+		s.undo = append(s.undo, before)
+		s.redo = nil
+	}
+	return resp, err
+}
+
+// snapshot is a state of the Server for undo and redo
+type snapshot struct {
+	composition []byte
+	thumbnails  map[int64][]byte
+}
+
+func (s *Server) snapshot() snapshot {
+	data, _ := json.Marshal(s.composition)
+	thumbnails := make(map[int64][]byte, len(s.thumbnails))
+	for id, t := range s.thumbnails {
+		thumbnails[id] = t
+	}
+	return snapshot{composition: data, thumbnails: thumbnails}
+}
+
+func (s *Server) restore(snap snapshot) {
+	var c resolume.Composition
+	json.Unmarshal(snap.composition, &c)
+	s.composition = &c
+	s.thumbnails = snap.thumbnails
+}
+
+// undoRedo moves the state from one stack to the other, and does nothing if
+// the first one is empty
+func (s *Server) undoRedo(from, to *[]snapshot) {
+	if len(*from) == 0 {
+		return
+	}
+	*to = append(*to, s.snapshot())
+	s.restore((*from)[len(*from)-1])
+	*from = (*from)[:len(*from)-1]
+}
+
+// httpError is an error answered with a status
+type httpError struct {
+	status  int
+	message string
+}
+
+func (e *httpError) Error() string {
+	return e.message
+}
+
+func notFound(what string) error {
+	return &httpError{status: http.StatusNotFound, message: what + " not found"}
+}
+
+func badRequest(message string) error {
+	return &httpError{status: http.StatusBadRequest, message: message}
+}
+
+var errNotImplemented = &httpError{status: http.StatusNotFound, message: "not implemented by resolumetest"}
+
+// copyJSON copies src into dst through JSON
+func copyJSON(src, dst interface{}) {
+	data, err := json.Marshal(src)
+	if err != nil {
+		panic(err)
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		panic(err)
+	}
+}
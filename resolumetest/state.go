@@ -0,0 +1,285 @@
+package resolumetest
+
+import (
+	"fmt"
+
+	"github.com/FlowingSPDG/resolume-go"
+)
+
+// States of the connected parameter of clips and columns
+const (
+	empty        = "Empty"
+	disconnected = "Disconnected"
+	connected    = "Connected"
+)
+
+var connectedOptions = []string{empty, disconnected, "Previewing", connected, "Connected & previewing"}
+
+// newComposition creates a composition of empty clips, with a deck and tempo.
+// Ids are assigned by assignIDs.
+func (s *Server) newComposition(layers, columns int) *resolume.Composition {
+	c := &resolume.Composition{
+		Name:     str("Composition"),
+		Selected: boolean(false),
+		Bypassed: boolean(false),
+		Master:   rangeParam(0, 1, 1),
+		Speed:    rangeParam(0, 10, 1),
+		Video:    &resolume.VideoTrack{Opacity: rangeParam(0, 1, 1)},
+		CrossFader: &resolume.CrossFader{
+			Phase: rangeParam(-1, 1, 0),
+		},
+		TempoController: &resolume.TempoController{
+			Tempo: rangeParam(20, 500, 120),
+		},
+		Decks: []resolume.Deck{newDeck(1)},
+	}
+	c.Decks[0].Selected.Value = true
+	for i := 0; i < columns; i++ {
+		c.Columns = append(c.Columns, newColumn(i+1))
+	}
+	for i := 0; i < layers; i++ {
+		c.Layers = append(c.Layers, newLayer(i+1, columns))
+	}
+	s.composition = c
+	s.assignIDs()
+	return s.composition
+}
+
+func newLayer(n, columns int) resolume.Layer {
+	layer := resolume.Layer{
+		Name:                str(fmt.Sprintf("Layer #%d", n)),
+		Selected:            boolean(false),
+		Bypassed:            boolean(false),
+		Solo:                boolean(false),
+		Master:              rangeParam(0, 1, 1),
+		IgnoreColumnTrigger: boolean(false),
+		Video: &resolume.VideoTrackLayer{
+			VideoTrack: resolume.VideoTrack{Opacity: rangeParam(0, 1, 1)},
+		},
+		Transition: &resolume.LayerTransition{Duration: rangeParam(0, 10, 0)},
+	}
+	for i := 0; i < columns; i++ {
+		layer.Clips = append(layer.Clips, newClip())
+	}
+	return layer
+}
+
+func newColumn(n int) resolume.Column {
+	return resolume.Column{
+		Name:      str(fmt.Sprintf("Column #%d", n)),
+		Selected:  boolean(false),
+		Connected: choice(empty, connectedOptions),
+	}
+}
+
+func newDeck(n int) resolume.Deck {
+	return resolume.Deck{
+		Name:     str(fmt.Sprintf("Deck #%d", n)),
+		Selected: boolean(false),
+	}
+}
+
+func newClip() resolume.Clip {
+	return resolume.Clip{
+		Name:      str(""),
+		Selected:  boolean(false),
+		Connected: choice(empty, connectedOptions),
+		Video: &resolume.VideoTrackClip{
+			VideoTrack: resolume.VideoTrack{Opacity: rangeParam(0, 1, 1)},
+		},
+		Thumbnail: noThumbnail(),
+	}
+}
+
+// clipThumbnail is the type of resolume.Clip.Thumbnail
+type clipThumbnail = struct {
+	Size       int64  `json:"size"`
+	LastUpdate string `json:"last_update"`
+	IsDefault  bool   `json:"is_default"`
+}
+
+// noThumbnail is the thumbnail of an empty clip, served by the dummy endpoint
+func noThumbnail() *clipThumbnail {
+	return &clipThumbnail{IsDefault: true, LastUpdate: "0"}
+}
+
+func str(value string) *resolume.StringParameter {
+	return &resolume.StringParameter{ValueType: resolume.ParamString, Value: value}
+}
+
+func boolean(value bool) *resolume.BooleanParameter {
+	return &resolume.BooleanParameter{ValueType: resolume.ParamBoolean, Value: value}
+}
+
+func rangeParam(min, max, value float64) *resolume.RangeParameter {
+	return &resolume.RangeParameter{ValueType: resolume.ParamRange, Min: min, Max: max, Value: value}
+}
+
+func choice(value string, options []string) *resolume.ChoiceParameter {
+	p := &resolume.ChoiceParameter{ValueType: resolume.ParamChoice, Options: options}
+	setChoice(p, value)
+	return p
+}
+
+// setChoice sets the value of a choice parameter and its index in the options
+func setChoice(p *resolume.ChoiceParameter, value string) {
+	p.Value = value
+	for i, option := range p.Options {
+		if option == value {
+			p.Index = int32(i)
+		}
+	}
+}
+
+// assignIDs gives an id to every parameter, layer, column, deck, layer group,
+// clip and effect of the composition without one
+func (s *Server) assignIDs() {
+	var v interface{}
+	copyJSON(s.composition, &v)
+	if max := maxID(v); max > s.lastID {
+		s.lastID = max
+	}
+	s.assign(v, false)
+	var c resolume.Composition
+	copyJSON(v, &c)
+	s.composition = &c
+}
+
+// idLists are the lists whose elements have ids
+var idLists = map[string]bool{
+	"layers": true, "columns": true, "decks": true, "layergroups": true, "clips": true, "effects": true,
+}
+
+// assign gives ids to the JSON objects below v. element is whether v is an
+// element of one of the idLists.
+func (s *Server) assign(v interface{}, element bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		_, isParameter := v["valuetype"]
+		if id, _ := v["id"].(float64); id == 0 && (element || isParameter) {
+			s.lastID++
+			v["id"] = s.lastID
+		}
+		for key, child := range v {
+			if list, ok := child.([]interface{}); ok && idLists[key] {
+				for _, e := range list {
+					s.assign(e, true)
+				}
+				continue
+			}
+			s.assign(child, false)
+		}
+	case []interface{}:
+		for _, e := range v {
+			s.assign(e, false)
+		}
+	}
+}
+
+// maxID returns the largest id below v
+func maxID(v interface{}) int64 {
+	var max int64
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if id, ok := v["id"].(float64); ok && int64(id) > max {
+			max = int64(id)
+		}
+		for _, child := range v {
+			if id := maxID(child); id > max {
+				max = id
+			}
+		}
+	case []interface{}:
+		for _, e := range v {
+			if id := maxID(e); id > max {
+				max = id
+			}
+		}
+	}
+	return max
+}
+
+// withoutIDs copies src into dst without its ids, so assignIDs gives the copy new ones
+func withoutIDs(src, dst interface{}) {
+	var v interface{}
+	copyJSON(src, &v)
+	removeIDs(v)
+	copyJSON(v, dst)
+}
+
+func removeIDs(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		delete(v, "id")
+		for _, child := range v {
+			removeIDs(child)
+		}
+	case []interface{}:
+		for _, e := range v {
+			removeIDs(e)
+		}
+	}
+}
+
+// merge merges a partial JSON object into a full one, as the PUT endpoints
+// do: objects are merged by key and lists by position, ids are ignored
+func merge(dst, src interface{}) interface{} {
+	switch src := src.(type) {
+	case map[string]interface{}:
+		d, ok := dst.(map[string]interface{})
+		if !ok {
+			return dst
+		}
+		for key, value := range src {
+			if key == "id" || key == "valuetype" {
+				continue
+			}
+			if current, ok := d[key]; ok {
+				d[key] = merge(current, value)
+			} else {
+				d[key] = value
+			}
+		}
+		return d
+	case []interface{}:
+		d, ok := dst.([]interface{})
+		if !ok {
+			return dst
+		}
+		for i := 0; i < len(src) && i < len(d); i++ {
+			d[i] = merge(d[i], src[i])
+		}
+		return d
+	default:
+		if _, ok := dst.(map[string]interface{}); ok {
+			return dst
+		}
+		return src
+	}
+}
+
+// normalize keeps the parameters consistent after a change: range values
+// within Min and Max, and choice indexes matching the values
+func (s *Server) normalize() {
+	s.composition.Walk(func(path resolume.Path, node interface{}) error {
+		switch p := node.(type) {
+		case *resolume.RangeParameter:
+			if p.Max > p.Min {
+				p.Value = clamp(p.Value, p.Min, p.Max)
+			}
+		case *resolume.ChoiceParameter:
+			setChoice(p, p.Value)
+		}
+		return nil
+	})
+}
+
+func clamp(v, min, max float64) float64 {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
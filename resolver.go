@@ -0,0 +1,157 @@
+package resolume
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Resolver finds the parameters of a composition by path or by name, and reads
+// and updates them through the parameter endpoints by id.
+//
+// Paths are relative to the composition, e.g. "layers/2/video/opacity", and
+// video effects may be named instead of indexed, e.g.
+// "layers/2/video/effects/transform/params/scale". Paths are case-insensitive.
+//
+// Parameter ids change when layers, clips or effects are added or removed, so
+// create a new Resolver from a fresh composition after such changes.
+type Resolver struct {
+	client *Client
+	params map[string]resolved
+	names  map[string][]Path
+}
+
+// resolved is an indexed parameter
+type resolved struct {
+	path  Path
+	param Parameter
+}
+
+// NewResolver indexes every parameter of the composition
+func NewResolver(client *Client, composition *Composition) *Resolver {
+	r := &Resolver{
+		client: client,
+		params: map[string]resolved{},
+		names:  map[string][]Path{},
+	}
+
+	// aliases maps the path of each effect to its path by name
+	aliases := map[Path][]string{}
+	composition.Walk(func(path Path, node interface{}) error {
+		switch node := node.(type) {
+		case *VideoEffect:
+			aliases[path] = effectAliases(path, node.DisplayName, node.Name)
+		case *AudioEffect:
+			aliases[path] = effectAliases(path, node.Name)
+		case Parameter:
+			r.add(path, node, aliases)
+		}
+		return nil
+	})
+	return r
+}
+
+// effectAliases returns the paths naming an effect instead of indexing it
+func effectAliases(path Path, names ...string) []string {
+	var aliases []string
+	for _, name := range names {
+		if name != "" {
+			aliases = append(aliases, string(path.Parent().Child(name)))
+		}
+	}
+	return aliases
+}
+
+// add indexes a parameter under its path and under the paths naming its effect
+func (r *Resolver) add(path Path, param Parameter, aliases map[Path][]string) {
+	keys := []string{string(path)}
+	for effect, names := range aliases {
+		prefix := string(effect) + "/"
+		if !strings.HasPrefix(string(path), prefix) {
+			continue
+		}
+		for _, name := range names {
+			keys = append(keys, name+"/"+strings.TrimPrefix(string(path), prefix))
+		}
+	}
+	for _, key := range keys {
+		key = normalizePath(key)
+		if _, ok := r.params[key]; !ok {
+			r.params[key] = resolved{path: path, param: param}
+		}
+	}
+
+	segments := path.Segments()
+	name := strings.ToLower(segments[len(segments)-1])
+	r.names[name] = append(r.names[name], path)
+}
+
+// normalizePath makes a path relative to the composition and lowercase
+func normalizePath(path string) string {
+	path = strings.ToLower(strings.Trim(path, "/"))
+	if path == "composition" {
+		return ""
+	}
+	return strings.TrimPrefix(path, "composition/")
+}
+
+// Lookup returns the parameter at path as it was in the composition, with its canonical path.
+// It returns an error matching ErrNotFound if there is no parameter at path.
+func (r *Resolver) Lookup(path string) (Parameter, Path, error) {
+	p, ok := r.params[normalizePath(path)]
+	if !ok {
+		return nil, "", fmt.Errorf("%w: no parameter at %s", ErrNotFound, path)
+	}
+	return p.param, p.path, nil
+}
+
+// FindByName returns the paths of all parameters with the given name, e.g.
+// "Scale" or "opacity", in composition order
+func (r *Resolver) FindByName(name string) []Path {
+	return r.names[strings.ToLower(name)]
+}
+
+// GetByPath retrieves the current state of the parameter at path
+func (r *Resolver) GetByPath(ctx context.Context, path string) (Parameter, error) {
+	p, _, err := r.Lookup(path)
+	if err != nil {
+		return nil, err
+	}
+	return r.client.GetParameterContext(ctx, p.ParameterID())
+}
+
+// SetByPath sets the value of the parameter at path. The value must suit the
+// parameter type: a number for range and integer parameters, a bool for
+// boolean and event parameters and a string for the others.
+func (r *Resolver) SetByPath(ctx context.Context, path string, value interface{}) error {
+	p, canonical, err := r.Lookup(path)
+	if err != nil {
+		return err
+	}
+	if !validValue(p, value) {
+		return fmt.Errorf("%s: %T is not a valid value for a %s", canonical, value, p.ParameterType())
+	}
+	return r.client.SetParameterByIDContext(ctx, p.ParameterID(), map[string]interface{}{"value": value})
+}
+
+// validValue reports whether value can be sent as the value of p
+func validValue(p Parameter, value interface{}) bool {
+	kind := reflect.ValueOf(value).Kind()
+	switch p.(type) {
+	case *RangeParameter, *IntegerParameter:
+		switch kind {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return true
+		}
+		return false
+	case *BooleanParameter, *EventParameter:
+		return kind == reflect.Bool
+	case *ChoiceParameter, *StringParameter, *TextParameter, *ColorParameter:
+		return kind == reflect.String
+	default:
+		return true
+	}
+}
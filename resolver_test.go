@@ -0,0 +1,95 @@
+package resolume
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolverLookup(t *testing.T) {
+	r := NewResolver(nil, decodeTestComposition(t))
+
+	tests := []struct {
+		path string
+		id   int64
+		want Path
+	}{
+		{"layers/2/video/opacity", 22, "/composition/layers/2/video/opacity"},
+		{"/composition/layers/2/video/opacity", 22, "/composition/layers/2/video/opacity"},
+		{"layers/2/video/effects/1/params/Scale", 31, "/composition/layers/2/video/effects/1/params/Scale"},
+		{"layers/2/video/effects/transform/params/scale", 31, "/composition/layers/2/video/effects/1/params/Scale"},
+		{"Layers/2/Video/Effects/Transform/Params/Scale", 31, "/composition/layers/2/video/effects/1/params/Scale"},
+		{"layers/2/clips/2/transport/position", 203, "/composition/layers/2/clips/2/transport/position"},
+		{"name", 1, "/composition/name"},
+	}
+	for _, tt := range tests {
+		p, path, err := r.Lookup(tt.path)
+		if err != nil {
+			t.Errorf("Lookup(%q) error = %v", tt.path, err)
+			continue
+		}
+		if p.ParameterID() != tt.id || path != tt.want {
+			t.Errorf("Lookup(%q) = %d at %s, want %d at %s", tt.path, p.ParameterID(), path, tt.id, tt.want)
+		}
+	}
+
+	if _, _, err := r.Lookup("layers/2/video/effects/blur/params/scale"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Lookup() error = %v, want ErrNotFound", err)
+	}
+
+	want := []Path{"/composition/name", "/composition/layers/1/name", "/composition/layers/1/clips/1/name", "/composition/layers/2/name"}
+	if got := r.FindByName("Name"); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindByName() = %v, want %v", got, want)
+	}
+}
+
+func TestResolverSetAndGet(t *testing.T) {
+	client, requests, closeServer := newRecordingServer(t)
+	defer closeServer()
+	r := NewResolver(client, decodeTestComposition(t))
+
+	ctx := context.Background()
+	if err := r.SetByPath(ctx, "layers/2/video/effects/transform/params/scale", 1.5); err != nil {
+		t.Fatalf("SetByPath() error = %v", err)
+	}
+	req := (*requests)[0]
+	if req.method != http.MethodPut || req.path != "/api/v1/parameter/by-id/31" || strings.TrimSpace(req.body) != `{"value":1.5}` {
+		t.Errorf("SetByPath() sent %s %s %s", req.method, req.path, req.body)
+	}
+
+	if err := r.SetByPath(ctx, "layers/2/video/opacity", "half"); err == nil {
+		t.Error("SetByPath() with a string for a range parameter error = nil")
+	}
+	if err := r.SetByPath(ctx, "layers/9/video/opacity", 0.5); !errors.Is(err, ErrNotFound) {
+		t.Errorf("SetByPath() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestResolverGetByPath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/parameter/by-id/22" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 22, "valuetype": "ParamRange", "value": 0.75, "min": 0, "max": 1}`))
+	}))
+	defer server.Close()
+	client, err := NewClientFromURL(server.URL+"/api/v1", WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewClientFromURL() error = %v", err)
+	}
+
+	r := NewResolver(client, decodeTestComposition(t))
+	p, err := r.GetByPath(context.Background(), "layers/2/video/opacity")
+	if err != nil {
+		t.Fatalf("GetByPath() error = %v", err)
+	}
+	if opacity, ok := p.(*RangeParameter); !ok || opacity.Value != 0.75 {
+		t.Errorf("GetByPath() = %#v", p)
+	}
+}
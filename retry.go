@@ -0,0 +1,126 @@
+package resolume
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy configures how failed requests are retried. GET and PUT requests
+// are retried automatically; POST and DELETE requests only when their context
+// is marked with AllowRetry, since calls like DuplicateLayer or AddColumn
+// change the composition each time they succeed.
+//
+// Connection errors and 5xx responses are retried, anything else is returned
+// immediately.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// A value of 1 or less disables retries.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts
+	MaxBackoff time.Duration
+	// Multiplier grows the wait after each attempt, 2 if zero
+	Multiplier float64
+	// Jitter randomizes each wait by up to this fraction, e.g. 0.2 for ±20%
+	Jitter float64
+	// OnRetry is called before waiting for the next attempt
+	OnRetry func(RetryEvent)
+}
+
+// RetryEvent describes a failed attempt that is about to be retried
+type RetryEvent struct {
+	Method   string
+	Endpoint string
+	// Attempt is the number of the failed attempt, starting at 1
+	Attempt int
+	// Err is the error of the failed attempt
+	Err error
+	// Backoff is the wait before the next attempt
+	Backoff time.Duration
+}
+
+// DefaultRetryPolicy returns a policy making up to 3 attempts, waiting 100ms
+// then 200ms with 20% jitter
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// WithRetryPolicy sets the policy for retrying failed requests
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = &policy
+	}
+}
+
+type allowRetryKey struct{}
+
+// AllowRetry returns a context under which POST and DELETE requests are
+// retried as well, for calls that are safe to repeat
+func AllowRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allowRetryKey{}, true)
+}
+
+// retries reports whether the policy applies to a request
+func (p *RetryPolicy) retries(ctx context.Context, method string) bool {
+	if p == nil || p.MaxAttempts <= 1 {
+		return false
+	}
+	if method == http.MethodGet || method == http.MethodPut {
+		return true
+	}
+	allowed, _ := ctx.Value(allowRetryKey{}).(bool)
+	return allowed
+}
+
+// backoff returns the wait after the given failed attempt
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier == 0 {
+		multiplier = 2
+	}
+	wait := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		wait *= multiplier
+	}
+	if p.MaxBackoff > 0 && wait > float64(p.MaxBackoff) {
+		wait = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		wait += wait * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(wait)
+}
+
+// isRetryable reports whether a failed attempt may succeed when repeated
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+	return true
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
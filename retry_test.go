@@ -0,0 +1,162 @@
+package resolume
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newFlakyServer fails the first failures requests with status, then answers 200 with an empty JSON object
+func newFlakyServer(t *testing.T, failures, status int, policy RetryPolicy) (*Client, *[]string) {
+	t.Helper()
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClientFromURL(server.URL+"/api/v1", WithHTTPClient(server.Client()), WithRetryPolicy(policy))
+	if err != nil {
+		t.Fatalf("NewClientFromURL() error = %v", err)
+	}
+	return client, &bodies
+}
+
+func testPolicy(events *[]RetryEvent) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		OnRetry: func(e RetryEvent) {
+			*events = append(*events, e)
+		},
+	}
+}
+
+func TestRetryGet(t *testing.T) {
+	var events []RetryEvent
+	client, bodies := newFlakyServer(t, 2, http.StatusServiceUnavailable, testPolicy(&events))
+
+	if _, err := client.GetComposition(); err != nil {
+		t.Fatalf("GetComposition() error = %v", err)
+	}
+	if len(*bodies) != 3 {
+		t.Errorf("Expected 3 attempts, got %d", len(*bodies))
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 retry events, got %d", len(events))
+	}
+	if events[1].Attempt != 2 || events[1].Method != http.MethodGet || events[1].Endpoint != "/composition" {
+		t.Errorf("Unexpected retry event %+v", events[1])
+	}
+	var apiErr *APIError
+	if !errors.As(events[0].Err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected the retry event to carry the 503, got %v", events[0].Err)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	var events []RetryEvent
+	client, bodies := newFlakyServer(t, 5, http.StatusInternalServerError, testPolicy(&events))
+
+	_, err := client.GetComposition()
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("GetComposition() error = %v, want 500 *APIError", err)
+	}
+	if len(*bodies) != 3 {
+		t.Errorf("Expected 3 attempts, got %d", len(*bodies))
+	}
+}
+
+func TestRetrySkipsClientErrors(t *testing.T) {
+	var events []RetryEvent
+	client, bodies := newFlakyServer(t, 1, http.StatusPreconditionFailed, testPolicy(&events))
+
+	if err := client.SetParameterByID(1, map[string]interface{}{"value": 1}); !errors.Is(err, ErrPreconditionFailed) {
+		t.Fatalf("SetParameterByID() error = %v, want ErrPreconditionFailed", err)
+	}
+	if len(*bodies) != 1 || len(events) != 0 {
+		t.Errorf("Expected a single attempt, got %d", len(*bodies))
+	}
+}
+
+func TestRetryPutReplaysBody(t *testing.T) {
+	var events []RetryEvent
+	client, bodies := newFlakyServer(t, 1, http.StatusBadGateway, testPolicy(&events))
+
+	if err := client.SetParameterByID(1, map[string]interface{}{"value": 1}); err != nil {
+		t.Fatalf("SetParameterByID() error = %v", err)
+	}
+	if len(*bodies) != 2 || (*bodies)[0] != (*bodies)[1] || (*bodies)[1] == "" {
+		t.Errorf("Expected the body to be sent twice, got %q", *bodies)
+	}
+}
+
+func TestRetryPostOptIn(t *testing.T) {
+	var events []RetryEvent
+	client, bodies := newFlakyServer(t, 1, http.StatusServiceUnavailable, testPolicy(&events))
+
+	if err := client.AddColumn(""); err == nil {
+		t.Fatal("AddColumn() error = nil, want the 503")
+	}
+	if len(*bodies) != 1 || len(events) != 0 {
+		t.Errorf("Expected POST not to be retried, got %d attempts", len(*bodies))
+	}
+}
+
+func TestRetryPostAllowed(t *testing.T) {
+	var events []RetryEvent
+	client, bodies := newFlakyServer(t, 1, http.StatusServiceUnavailable, testPolicy(&events))
+
+	if err := client.DuplicateLayerContext(AllowRetry(context.Background()), 1); err != nil {
+		t.Fatalf("DuplicateLayerContext() error = %v", err)
+	}
+	if len(*bodies) != 2 || len(events) != 1 {
+		t.Errorf("Expected 2 attempts, got %d", len(*bodies))
+	}
+}
+
+func TestRetryStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	policy := RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: time.Hour,
+		OnRetry:        func(RetryEvent) { cancel() },
+	}
+	client, bodies := newFlakyServer(t, 5, http.StatusServiceUnavailable, policy)
+
+	if _, err := client.GetCompositionContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("GetCompositionContext() error = %v, want context.Canceled", err)
+	}
+	if len(*bodies) != 1 {
+		t.Errorf("Expected 1 attempt, got %d", len(*bodies))
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond}
+	for i, w := range want {
+		if got := policy.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %s, want %s", i+1, got, w)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := policy.backoff(1); got < 50*time.Millisecond || got > 150*time.Millisecond {
+			t.Fatalf("backoff(1) = %s, want within 50%% of 100ms", got)
+		}
+	}
+}
@@ -0,0 +1,307 @@
+// Package server exposes a small, stable HTTP/JSON API in front of a Resolume
+// Client, for web apps that cannot reach Resolume directly:
+//
+//	GET  /composition                   the composition, cached briefly
+//	GET  /params/{id}                   a parameter by id
+//	PUT  /params/{id}                   set a parameter, with a {"value": ...} body
+//	POST /clips/{id}/trigger            connect a clip by id
+//	POST /columns/{index}/trigger       connect a column by 1-based index
+//	POST /composition/disconnect-all    disconnect all clips
+//
+// The Server handles CORS, authenticates requests with API keys and only
+// allows the configured actions. Errors are returned as {"error": "..."} with
+// the status of the Resolume response, or 502 if Resolume could not be reached.
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/FlowingSPDG/resolume-go"
+)
+
+// Action is an operation of the API, used in the allow-list
+type Action string
+
+// Actions of the API
+const (
+	ReadComposition Action = "read_composition"
+	ReadParameter   Action = "read_parameter"
+	SetParameter    Action = "set_parameter"
+	TriggerClip     Action = "trigger_clip"
+	TriggerColumn   Action = "trigger_column"
+	DisconnectAll   Action = "disconnect_all"
+)
+
+// AllActions are the actions allowed by default
+var AllActions = []Action{ReadComposition, ReadParameter, SetParameter, TriggerClip, TriggerColumn, DisconnectAll}
+
+// maxBodySize limits the size of request bodies
+const maxBodySize = 64 << 10
+
+// Server is an http.Handler serving the API
+type Server struct {
+	client   *resolume.Client
+	origins  map[string]bool
+	keys     [][]byte
+	allowed  map[Action]bool
+	cacheTTL time.Duration
+
+	mu       sync.Mutex
+	cached   []byte
+	cachedAt time.Time
+	// generation is bumped by invalidate, so a fetch that started before a
+	// change does not cache its result
+	generation uint64
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithAllowedOrigins sets the origins allowed to call the API from a browser,
+// or "*" for any origin. By default no cross-origin request is allowed.
+func WithAllowedOrigins(origins ...string) Option {
+	return func(s *Server) {
+		for _, origin := range origins {
+			s.origins[origin] = true
+		}
+	}
+}
+
+// WithAPIKeys requires every request to carry one of the keys, in an
+// X-API-Key header or as a bearer token. By default no key is required.
+func WithAPIKeys(keys ...string) Option {
+	return func(s *Server) {
+		for _, key := range keys {
+			s.keys = append(s.keys, []byte(key))
+		}
+	}
+}
+
+// WithActions allows only the given actions, the others are answered with 403
+func WithActions(actions ...Action) Option {
+	return func(s *Server) {
+		s.allowed = map[Action]bool{}
+		for _, action := range actions {
+			s.allowed[action] = true
+		}
+	}
+}
+
+// WithCacheTTL sets how long the composition is cached, 1 second by default.
+// Zero disables the cache. The cache is also dropped by every change made
+// through the Server.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(s *Server) {
+		s.cacheTTL = ttl
+	}
+}
+
+// New creates a Server
+func New(client *resolume.Client, opts ...Option) *Server {
+	s := &Server{
+		client:   client,
+		origins:  map[string]bool{},
+		cacheTTL: time.Second,
+	}
+	WithActions(AllActions...)(s)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.cors(w, r)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, errors.New("missing or invalid API key"))
+		return
+	}
+
+	action, id, err := route(r)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if !s.allowed[action] {
+		writeError(w, http.StatusForbidden, fmt.Errorf("%s is not allowed", action))
+		return
+	}
+
+	ctx := r.Context()
+	switch action {
+	case ReadComposition:
+		data, err := s.composition(r)
+		if err != nil {
+			writeClientError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+		return
+	case ReadParameter:
+		p, err := s.client.GetParameterContext(ctx, id)
+		if err != nil {
+			writeClientError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, p)
+		return
+	case SetParameter:
+		var body struct {
+			Value interface{} `json:"value"`
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Value == nil {
+			writeError(w, http.StatusBadRequest, errors.New(`the body must be {"value": ...}`))
+			return
+		}
+		err = s.client.SetParameterByIDContext(ctx, id, map[string]interface{}{"value": body.Value})
+	case TriggerClip:
+		err = s.client.ConnectClipByIDContext(ctx, id, nil)
+	case TriggerColumn:
+		err = s.client.ConnectColumnContext(ctx, id, nil)
+	case DisconnectAll:
+		err = s.client.DisconnectAllClipsContext(ctx)
+	}
+	s.invalidate()
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// route returns the action of a request and the id or index in its path
+func route(r *http.Request) (Action, int64, error) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	notFound := fmt.Errorf("no route for %s %s", r.Method, r.URL.Path)
+
+	var action Action
+	switch {
+	case len(segments) == 1 && segments[0] == "composition" && r.Method == http.MethodGet:
+		return ReadComposition, 0, nil
+	case len(segments) == 2 && segments[0] == "composition" && segments[1] == "disconnect-all" && r.Method == http.MethodPost:
+		return DisconnectAll, 0, nil
+	case len(segments) == 2 && segments[0] == "params" && r.Method == http.MethodGet:
+		action = ReadParameter
+	case len(segments) == 2 && segments[0] == "params" && r.Method == http.MethodPut:
+		action = SetParameter
+	case len(segments) == 3 && segments[0] == "clips" && segments[2] == "trigger" && r.Method == http.MethodPost:
+		action = TriggerClip
+	case len(segments) == 3 && segments[0] == "columns" && segments[2] == "trigger" && r.Method == http.MethodPost:
+		action = TriggerColumn
+	default:
+		return "", 0, notFound
+	}
+	id, err := strconv.ParseInt(segments[1], 10, 64)
+	if err != nil {
+		return "", 0, notFound
+	}
+	return action, id, nil
+}
+
+// cors sets the CORS headers for an allowed origin
+func (s *Server) cors(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	if origin == "" || !(s.origins[origin] || s.origins["*"]) {
+		return
+	}
+	h := w.Header()
+	h.Set("Access-Control-Allow-Origin", origin)
+	h.Add("Vary", "Origin")
+	if r.Method == http.MethodOptions {
+		h.Set("Access-Control-Allow-Methods", "GET, PUT, POST, OPTIONS")
+		h.Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+		h.Set("Access-Control-Max-Age", "600")
+	}
+}
+
+// authorized reports whether the request carries a valid API key
+func (s *Server) authorized(r *http.Request) bool {
+	if len(s.keys) == 0 {
+		return true
+	}
+	key := r.Header.Get("X-API-Key")
+	if key == "" {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			return false
+		}
+		key = token
+	}
+	for _, k := range s.keys {
+		if subtle.ConstantTimeCompare([]byte(key), k) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// composition returns the JSON of the composition, from the cache if it is fresh
+func (s *Server) composition(r *http.Request) ([]byte, error) {
+	s.mu.Lock()
+	if s.cached != nil && time.Since(s.cachedAt) < s.cacheTTL {
+		data := s.cached
+		s.mu.Unlock()
+		return data, nil
+	}
+	generation := s.generation
+	s.mu.Unlock()
+
+	composition, err := s.client.GetCompositionContext(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(composition)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	if s.generation == generation {
+		s.cached, s.cachedAt = data, time.Now()
+	}
+	s.mu.Unlock()
+	return data, nil
+}
+
+// invalidate drops the cached composition
+func (s *Server) invalidate() {
+	s.mu.Lock()
+	s.cached = nil
+	s.generation++
+	s.mu.Unlock()
+}
+
+// writeClientError writes an error of the Client with the status of the
+// Resolume response, or 502 if there was none
+func writeClientError(w http.ResponseWriter, err error) {
+	status := http.StatusBadGateway
+	var apiErr *resolume.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode < 500 {
+		status = apiErr.StatusCode
+	}
+	writeError(w, status, err)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,190 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/FlowingSPDG/resolume-go"
+)
+
+// fakeResolume serves a composition, parameter 5 and 404 for anything else it
+// is asked for, and records the requests
+type fakeResolume struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+func (f *fakeResolume) sent() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sent := f.requests
+	f.requests = nil
+	return sent
+}
+
+func newProxy(t *testing.T, opts ...Option) (*Server, *fakeResolume) {
+	t.Helper()
+	f := &fakeResolume{}
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		f.mu.Lock()
+		f.requests = append(f.requests, strings.TrimSpace(r.Method+" "+strings.TrimPrefix(r.URL.Path, "/api/v1")+" "+string(body)))
+		f.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/composition":
+			io.WriteString(w, `{"name": {"id": 1, "valuetype": "ParamString", "value": "Show"}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/parameter/by-id/5":
+			io.WriteString(w, `{"id": 5, "valuetype": "ParamRange", "value": 0.5, "min": 0, "max": 1}`)
+		case r.URL.Path == "/api/v1/composition/clips/by-id/404/connect":
+			http.NotFound(w, r)
+		case r.Method == http.MethodGet:
+			http.NotFound(w, r)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(api.Close)
+
+	client, err := resolume.NewClientFromURL(api.URL+"/api/v1", resolume.WithHTTPClient(api.Client()))
+	if err != nil {
+		t.Fatalf("NewClientFromURL() error = %v", err)
+	}
+	return New(client, opts...), f
+}
+
+// do sends a request to the proxy
+func do(s *Server, method, path, body string, header http.Header) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	for k, v := range header {
+		r.Header[k] = v
+	}
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	return w
+}
+
+func TestRoutes(t *testing.T) {
+	s, f := newProxy(t)
+
+	tests := []struct {
+		method, path, body string
+		status             int
+		// response is a part of the expected response body
+		response string
+		sent     string
+	}{
+		{"POST", "/clips/9/trigger", "", 204, "", "POST /composition/clips/by-id/9/connect null"},
+		{"POST", "/columns/3/trigger", "", 204, "", "POST /composition/columns/3/connect null"},
+		{"PUT", "/params/5", `{"value": 0.25}`, 204, "", `PUT /parameter/by-id/5 {"value":0.25}`},
+		{"POST", "/composition/disconnect-all", "", 204, "", "POST /composition/disconnect-all"},
+		{"GET", "/params/5", "", 200, `"value":0.5`, "GET /parameter/by-id/5"},
+		{"PUT", "/params/5", `{"val": 1}`, 400, `{"error":"the body must be {\"value\": ...}"}`, ""},
+		{"PUT", "/params/5", `{"value": "` + strings.Repeat("x", maxBodySize) + `"}`, 400, "", ""},
+		{"POST", "/clips/404/trigger", "", 404, "", "POST /composition/clips/by-id/404/connect null"},
+		{"GET", "/clips/9/trigger", "", 404, "", ""},
+		{"POST", "/clips/x/trigger", "", 404, "", ""},
+	}
+	for _, tt := range tests {
+		w := do(s, tt.method, tt.path, tt.body, nil)
+		if w.Code != tt.status {
+			t.Errorf("%s %s = %d %s, want %d", tt.method, tt.path, w.Code, w.Body, tt.status)
+		}
+		if !strings.Contains(w.Body.String(), tt.response) {
+			t.Errorf("%s %s = %s, want it to contain %s", tt.method, tt.path, w.Body, tt.response)
+		}
+		if got := strings.Join(f.sent(), "\n"); got != tt.sent {
+			t.Errorf("%s %s sent %q, want %q", tt.method, tt.path, got, tt.sent)
+		}
+	}
+}
+
+func TestCompositionCache(t *testing.T) {
+	s, f := newProxy(t, WithCacheTTL(time.Hour))
+
+	for i := 0; i < 2; i++ {
+		w := do(s, "GET", "/composition", "", nil)
+		if w.Code != 200 || !strings.Contains(w.Body.String(), `"Show"`) {
+			t.Fatalf("GET /composition = %d %s", w.Code, w.Body)
+		}
+	}
+	if got := f.sent(); len(got) != 1 {
+		t.Errorf("Expected a single request to Resolume, got %v", got)
+	}
+
+	do(s, "POST", "/clips/9/trigger", "", nil)
+	do(s, "GET", "/composition", "", nil)
+	if got := f.sent(); len(got) != 2 || got[1] != "GET /composition" {
+		t.Errorf("Expected a change to drop the cache, got %v", got)
+	}
+}
+
+func TestCompositionCacheRace(t *testing.T) {
+	// A change made while the composition is being fetched drops the result
+	var s *Server
+	fetches := 0
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		if fetches == 1 {
+			s.invalidate()
+		}
+		io.WriteString(w, `{}`)
+	}))
+	defer api.Close()
+	client, _ := resolume.NewClientFromURL(api.URL+"/api/v1", resolume.WithHTTPClient(api.Client()))
+	s = New(client, WithCacheTTL(time.Hour))
+
+	do(s, "GET", "/composition", "", nil)
+	do(s, "GET", "/composition", "", nil)
+	do(s, "GET", "/composition", "", nil)
+	if fetches != 2 {
+		t.Errorf("Fetched %d times, want the composition fetched during a change not cached", fetches)
+	}
+}
+
+func TestAuthAndAllowList(t *testing.T) {
+	s, f := newProxy(t, WithAPIKeys("secret"), WithActions(ReadParameter, TriggerClip))
+
+	if w := do(s, "POST", "/clips/9/trigger", "", nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("Request without a key = %d, want 401", w.Code)
+	}
+	if w := do(s, "POST", "/clips/9/trigger", "", http.Header{"X-Api-Key": {"wrong"}}); w.Code != http.StatusUnauthorized {
+		t.Errorf("Request with a wrong key = %d, want 401", w.Code)
+	}
+	if w := do(s, "POST", "/clips/9/trigger", "", http.Header{"X-Api-Key": {"secret"}}); w.Code != http.StatusNoContent {
+		t.Errorf("Request with a key = %d, want 204", w.Code)
+	}
+	if w := do(s, "GET", "/params/5", "", http.Header{"Authorization": {"Bearer secret"}}); w.Code != http.StatusOK {
+		t.Errorf("Request with a bearer token = %d, want 200", w.Code)
+	}
+	if w := do(s, "GET", "/params/5", "", http.Header{"Authorization": {"secret"}}); w.Code != http.StatusUnauthorized {
+		t.Errorf("Request with a key without the Bearer scheme = %d, want 401", w.Code)
+	}
+	if w := do(s, "PUT", "/params/5", `{"value": 1}`, http.Header{"X-Api-Key": {"secret"}}); w.Code != http.StatusForbidden {
+		t.Errorf("Request for an action not allowed = %d, want 403", w.Code)
+	}
+	if got := f.sent(); len(got) != 2 {
+		t.Errorf("Expected only the allowed requests to reach Resolume, got %v", got)
+	}
+}
+
+func TestCORS(t *testing.T) {
+	s, _ := newProxy(t, WithAllowedOrigins("https://app.example.com"), WithAPIKeys("secret"))
+
+	w := do(s, "OPTIONS", "/params/5", "", http.Header{"Origin": {"https://app.example.com"}})
+	if w.Code != http.StatusNoContent || w.Header().Get("Access-Control-Allow-Origin") != "https://app.example.com" ||
+		!strings.Contains(w.Header().Get("Access-Control-Allow-Headers"), "X-API-Key") {
+		t.Errorf("Preflight = %d %v", w.Code, w.Header())
+	}
+
+	w = do(s, "GET", "/params/5", "", http.Header{"Origin": {"https://evil.example.com"}, "X-Api-Key": {"secret"}})
+	if w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("Expected no CORS headers for another origin, got %v", w.Header())
+	}
+}
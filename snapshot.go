@@ -0,0 +1,227 @@
+package resolume
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Snapshot is the value of every parameter of a composition at one point in
+// time. It can be stored as JSON or YAML and restored with Client.Restore.
+type Snapshot struct {
+	TakenAt    time.Time           `json:"taken_at" yaml:"taken_at"`
+	Parameters []SnapshotParameter `json:"parameters" yaml:"parameters"`
+}
+
+// SnapshotParameter is the value of a parameter in a Snapshot
+type SnapshotParameter struct {
+	ID    int64       `json:"id" yaml:"id"`
+	Path  Path        `json:"path" yaml:"path"`
+	Type  string      `json:"valuetype" yaml:"valuetype"`
+	Value interface{} `json:"value" yaml:"value"`
+}
+
+// Snapshot retrieves the composition and captures the value of every parameter
+func (c *Client) Snapshot(ctx context.Context) (*Snapshot, error) {
+	composition, err := c.GetCompositionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewSnapshot(composition), nil
+}
+
+// NewSnapshot captures the value of every parameter of a composition.
+// Parameters without a value, such as events, are left out.
+func NewSnapshot(composition *Composition) *Snapshot {
+	s := &Snapshot{TakenAt: time.Now()}
+	composition.Walk(func(path Path, node interface{}) error {
+		p, ok := node.(Parameter)
+		if !ok || p.ParameterID() == 0 {
+			return nil
+		}
+		if value, ok := ParameterValue(p); ok {
+			s.Parameters = append(s.Parameters, SnapshotParameter{ID: p.ParameterID(), Path: path, Type: p.ParameterType(), Value: value})
+		}
+		return nil
+	})
+	return s
+}
+
+// Change is a parameter whose value differs between two snapshots. Old is nil
+// for a parameter only in the second snapshot, New for one only in the first.
+type Change struct {
+	ID   int64
+	Path Path
+	Type string
+	Old  interface{}
+	New  interface{}
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s (%d): %v -> %v", c.Path, c.ID, c.Old, c.New)
+}
+
+// Diff lists the parameters whose value differs from a to b, matched by id, in
+// the order of b followed by the parameters only in a
+func Diff(a, b *Snapshot) []Change {
+	old := make(map[int64]SnapshotParameter, len(a.Parameters))
+	for _, p := range a.Parameters {
+		old[p.ID] = p
+	}
+
+	var changes []Change
+	for _, p := range b.Parameters {
+		before, ok := old[p.ID]
+		delete(old, p.ID)
+		if !ok {
+			changes = append(changes, Change{ID: p.ID, Path: p.Path, Type: p.Type, New: p.Value})
+			continue
+		}
+		if !sameValue(before.Value, p.Value) {
+			changes = append(changes, Change{ID: p.ID, Path: p.Path, Type: p.Type, Old: before.Value, New: p.Value})
+		}
+	}
+	for _, p := range a.Parameters {
+		if _, ok := old[p.ID]; ok {
+			changes = append(changes, Change{ID: p.ID, Path: p.Path, Type: p.Type, Old: p.Value})
+		}
+	}
+	return changes
+}
+
+// sameValue compares parameter values, treating numbers decoded from JSON or
+// YAML as equal to the int64 and float64 values of the parameter types
+func sameValue(a, b interface{}) bool {
+	if x, ok := toFloat(a); ok {
+		y, ok := toFloat(b)
+		return ok && x == y
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// RestoreOptions configures Client.Restore
+type RestoreOptions struct {
+	// DryRun only writes the plan to Plan without sending any request
+	DryRun bool
+	// Grouped sends the changes of each layer, clip, column, deck, layer group
+	// or the composition in a single PUT to its endpoint, as the Replace* methods
+	// do, instead of one SetParameterByID call per parameter. Changes inside
+	// effects are always sent by id.
+	Grouped bool
+	// Filter skips the changes it returns false for, e.g. transport positions
+	Filter func(Change) bool
+	// Plan receives a line for each request
+	Plan io.Writer
+}
+
+// Restore sets every parameter that differs from the snapshot back to its
+// value in the snapshot, and returns the changes it made or, in dry-run mode,
+// would make. Parameters of objects removed since the snapshot, or added after
+// it, are skipped.
+func (c *Client) Restore(ctx context.Context, snapshot *Snapshot, opts RestoreOptions) ([]Change, error) {
+	current, err := c.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	for _, change := range Diff(current, snapshot) {
+		if change.Old == nil || change.New == nil {
+			continue
+		}
+		if opts.Filter != nil && !opts.Filter(change) {
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	plan := opts.Plan
+	if plan == nil {
+		plan = io.Discard
+	}
+
+	byID := changes
+	if opts.Grouped {
+		var groups []restoreGroup
+		groups, byID = groupChanges(changes)
+		for _, g := range groups {
+			fmt.Fprintf(plan, "PUT %s %s\n", g.endpoint, strings.Join(g.fields, ", "))
+			if opts.DryRun {
+				continue
+			}
+			if err := c.put(ctx, g.endpoint, g.body, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, change := range byID {
+		fmt.Fprintf(plan, "PUT /parameter/by-id/%d %s\n", change.ID, change)
+		if opts.DryRun {
+			continue
+		}
+		if err := c.SetParameterByIDContext(ctx, change.ID, map[string]interface{}{"value": change.New}); err != nil {
+			return nil, fmt.Errorf("failed to restore %s: %w", change.Path, err)
+		}
+	}
+	return changes, nil
+}
+
+// restoreGroup is a partial update of one object
+type restoreGroup struct {
+	endpoint string
+	body     Patch
+	fields   []string
+}
+
+// groupChanges groups the changes by the endpoint of their object. Changes
+// inside effects or lists cannot be expressed as a partial update and are
+// returned separately.
+func groupChanges(changes []Change) ([]restoreGroup, []Change) {
+	var groups []restoreGroup
+	index := map[string]int{}
+	var rest []Change
+	for _, change := range changes {
+		endpoint, parameter := change.Path.split()
+		segments := strings.Split(parameter, "/")
+		if strings.Contains(endpoint, "/effects/") || parameter == "" || hasIndex(segments) {
+			rest = append(rest, change)
+			continue
+		}
+
+		i, ok := index[endpoint]
+		if !ok {
+			i = len(groups)
+			index[endpoint] = i
+			groups = append(groups, restoreGroup{endpoint: endpoint, body: Patch{}})
+		}
+		g := &groups[i]
+		g.body.Set(parameter, change.New)
+		g.fields = append(g.fields, fmt.Sprintf("%s: %v -> %v", parameter, change.Old, change.New))
+	}
+	return groups, rest
+}
+
+func hasIndex(segments []string) bool {
+	for _, s := range segments {
+		if isIndex(s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package resolume
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// newCompositionServer serves composition for GET /composition and records every other request
+func newCompositionServer(t *testing.T, composition string) (*Client, *[]recordedRequest) {
+	t.Helper()
+	var requests []recordedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/api/v1/composition" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(composition))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, recordedRequest{method: r.Method, path: r.URL.Path, body: string(body)})
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClientFromURL(server.URL+"/api/v1", WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewClientFromURL() error = %v", err)
+	}
+	return client, &requests
+}
+
+// changedComposition is testComposition with the opacity, the Scale effect
+// parameter and the composition name changed
+var changedComposition = strings.NewReplacer(
+	`"value": 0.5}`, `"value": 1}`,
+	`"value": 100}`, `"value": 50}`,
+	`"value": "Show"}`, `"value": "Rehearsal"}`,
+).Replace(testComposition)
+
+func TestSnapshot(t *testing.T) {
+	s := NewSnapshot(decodeTestComposition(t))
+
+	want := SnapshotParameter{ID: 22, Path: "/composition/layers/2/video/opacity", Type: ParamRange, Value: 0.5}
+	var found bool
+	for _, p := range s.Parameters {
+		if p.ID == want.ID {
+			found = reflect.DeepEqual(p, want)
+		}
+	}
+	if !found {
+		t.Errorf("Expected %+v in the snapshot, got %+v", want, s.Parameters)
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	s := NewSnapshot(decodeTestComposition(t))
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var fromJSON Snapshot
+	if err := json.Unmarshal(data, &fromJSON); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if changes := Diff(s, &fromJSON); len(changes) != 0 {
+		t.Errorf("Diff() after a JSON round trip = %v", changes)
+	}
+
+	data, err = yaml.Marshal(s)
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+	var fromYAML Snapshot
+	if err := yaml.Unmarshal(data, &fromYAML); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	if changes := Diff(s, &fromYAML); len(changes) != 0 {
+		t.Errorf("Diff() after a YAML round trip = %v", changes)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := &Snapshot{Parameters: []SnapshotParameter{
+		{ID: 1, Path: "/composition/master", Value: 1.0},
+		{ID: 2, Path: "/composition/name", Value: "Show"},
+		{ID: 3, Path: "/composition/layers/3/master", Value: 1.0},
+	}}
+	b := &Snapshot{Parameters: []SnapshotParameter{
+		{ID: 1, Path: "/composition/master", Value: 0.5},
+		{ID: 2, Path: "/composition/name", Value: "Show"},
+		{ID: 4, Path: "/composition/layers/4/master", Value: 1},
+	}}
+
+	want := []Change{
+		{ID: 1, Path: "/composition/master", Old: 1.0, New: 0.5},
+		{ID: 4, Path: "/composition/layers/4/master", New: 1},
+		{ID: 3, Path: "/composition/layers/3/master", Old: 1.0},
+	}
+	if got := Diff(a, b); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %v, want %v", got, want)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	snapshot := NewSnapshot(decodeTestComposition(t))
+	client, requests := newCompositionServer(t, changedComposition)
+
+	var plan bytes.Buffer
+	changes, err := client.Restore(context.Background(), snapshot, RestoreOptions{DryRun: true, Plan: &plan})
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if len(changes) != 3 || len(*requests) != 0 {
+		t.Fatalf("Restore() in dry-run = %v, sent %d requests", changes, len(*requests))
+	}
+	if !strings.Contains(plan.String(), "PUT /parameter/by-id/22 /composition/layers/2/video/opacity (22): 1 -> 0.5") {
+		t.Errorf("Unexpected plan:\n%s", plan.String())
+	}
+
+	if _, err := client.Restore(context.Background(), snapshot, RestoreOptions{}); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	got := map[string]string{}
+	for _, req := range *requests {
+		got[req.path] = strings.TrimSpace(req.body)
+	}
+	want := map[string]string{
+		"/api/v1/parameter/by-id/1":  `{"value":"Show"}`,
+		"/api/v1/parameter/by-id/22": `{"value":0.5}`,
+		"/api/v1/parameter/by-id/31": `{"value":100}`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Restore() sent %v, want %v", got, want)
+	}
+}
+
+func TestRestoreGrouped(t *testing.T) {
+	snapshot := NewSnapshot(decodeTestComposition(t))
+	client, requests := newCompositionServer(t, changedComposition)
+
+	opts := RestoreOptions{
+		Grouped: true,
+		Filter:  func(c Change) bool { return c.ID != 1 },
+	}
+	if _, err := client.Restore(context.Background(), snapshot, opts); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	got := map[string]string{}
+	for _, req := range *requests {
+		got[req.method+" "+req.path] = strings.TrimSpace(req.body)
+	}
+	want := map[string]string{
+		"PUT /api/v1/composition/layers/2": `{"video":{"opacity":{"value":0.5}}}`,
+		"PUT /api/v1/parameter/by-id/31":   `{"value":100}`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Restore() sent %v, want %v", got, want)
+	}
+}
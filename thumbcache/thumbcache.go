@@ -0,0 +1,348 @@
+// Package thumbcache caches clip thumbnails so a UI redrawing the clip grid
+// only downloads the images that changed.
+//
+// Thumbnails are keyed on the clip id and Clip.Thumbnail.LastUpdate, and
+// fetched with the /thumbnail/{last-updated} endpoints, so a cached image is
+// valid until the composition reports a new LastUpdate. Clips without a
+// thumbnail of their own share the dummy thumbnail, fetched once. Images are
+// kept in a memory LRU and optionally in a directory surviving restarts.
+package thumbcache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/FlowingSPDG/resolume-go"
+)
+
+// Cache fetches and caches clip thumbnails
+type Cache struct {
+	client      *resolume.Client
+	maxEntries  int
+	dir         string
+	concurrency int
+	onError     func(error)
+
+	mu       sync.Mutex
+	lru      *list.List
+	entries  map[int64]*list.Element
+	dummy    []byte
+	inflight map[key]*call
+}
+
+// key identifies a version of the thumbnail of a clip. The dummy thumbnail
+// has a zero key.
+type key struct {
+	clipID     int64
+	lastUpdate string
+}
+
+// entry is the cached version of the thumbnail of a clip
+type entry struct {
+	key  key
+	data []byte
+}
+
+// call is a fetch in progress, shared by the callers asking for the same key
+type call struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+// Option configures a Cache
+type Option func(*Cache)
+
+// WithMaxEntries sets how many thumbnails the memory LRU keeps, 1024 by default
+func WithMaxEntries(n int) Option {
+	return func(c *Cache) {
+		c.maxEntries = n
+	}
+}
+
+// WithDir also stores the thumbnails as files in a directory, created if
+// needed, so they survive restarts
+func WithDir(dir string) Option {
+	return func(c *Cache) {
+		c.dir = dir
+	}
+}
+
+// WithConcurrency sets how many thumbnails the Prefetch methods download at
+// once, 4 by default
+func WithConcurrency(n int) Option {
+	return func(c *Cache) {
+		c.concurrency = n
+	}
+}
+
+// WithErrorHandler sets a callback for errors writing to the directory, which
+// do not fail reads as the thumbnail is still returned. By default they are
+// ignored.
+func WithErrorHandler(fn func(error)) Option {
+	return func(c *Cache) {
+		c.onError = fn
+	}
+}
+
+// New creates a Cache
+func New(client *resolume.Client, opts ...Option) *Cache {
+	c := &Cache{
+		client:      client,
+		maxEntries:  1024,
+		concurrency: 4,
+		lru:         list.New(),
+		entries:     map[int64]*list.Element{},
+		inflight:    map[key]*call{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Dummy returns the dummy thumbnail used for clips without a thumbnail. It is
+// fetched once and cached for the life of the Cache, as the API allows.
+func (c *Cache) Dummy(ctx context.Context) ([]byte, error) {
+	if data, ok := c.lookup(key{}); ok {
+		return data, nil
+	}
+	return c.fetch(ctx, key{}, func(ctx context.Context) (io.ReadCloser, error) {
+		return c.client.GetDummyThumbnailContext(ctx)
+	})
+}
+
+// Clip returns the thumbnail of a clip of the composition, downloading it
+// only if its LastUpdate is not cached yet
+func (c *Cache) Clip(ctx context.Context, clip *resolume.Clip) ([]byte, error) {
+	t := clip.Thumbnail
+	if t == nil || t.IsDefault || t.LastUpdate == "" || t.LastUpdate == "0" {
+		return c.Dummy(ctx)
+	}
+	lastUpdate, err := strconv.ParseInt(t.LastUpdate, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid last update of clip %d: %q", clip.ID, t.LastUpdate)
+	}
+	k := key{clipID: clip.ID, lastUpdate: t.LastUpdate}
+	if data, ok := c.lookup(k); ok {
+		return data, nil
+	}
+	return c.fetch(ctx, k, func(ctx context.Context) (io.ReadCloser, error) {
+		return c.client.GetClipThumbnailByIDAndTimestampContext(ctx, clip.ID, lastUpdate)
+	})
+}
+
+// PrefetchLayer downloads the thumbnails of the clips of a layer that are not
+// cached yet
+func (c *Cache) PrefetchLayer(ctx context.Context, layer *resolume.Layer) error {
+	clips := make([]*resolume.Clip, len(layer.Clips))
+	for i := range layer.Clips {
+		clips[i] = &layer.Clips[i]
+	}
+	return c.Prefetch(ctx, clips...)
+}
+
+// PrefetchDeck downloads the thumbnails of all the clips of a composition,
+// which are the clips of its open deck, that are not cached yet
+func (c *Cache) PrefetchDeck(ctx context.Context, composition *resolume.Composition) error {
+	var clips []*resolume.Clip
+	for i := range composition.Layers {
+		for j := range composition.Layers[i].Clips {
+			clips = append(clips, &composition.Layers[i].Clips[j])
+		}
+	}
+	return c.Prefetch(ctx, clips...)
+}
+
+// Prefetch downloads the thumbnails of clips that are not cached yet, with at
+// most the concurrency of the Cache. It returns the errors of all the clips
+// that failed.
+func (c *Cache) Prefetch(ctx context.Context, clips ...*resolume.Clip) error {
+	concurrency := c.concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	errs := make([]error, len(clips))
+	var wg sync.WaitGroup
+	for i, clip := range clips {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		}
+		wg.Add(1)
+		go func(i int, clip *resolume.Clip) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if _, err := c.Clip(ctx, clip); err != nil {
+				errs[i] = fmt.Errorf("clip %d: %w", clip.ID, err)
+			}
+		}(i, clip)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// Len returns the number of thumbnails in memory, without the dummy thumbnail
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// lookup returns a cached thumbnail from memory or the directory
+func (c *Cache) lookup(k key) ([]byte, bool) {
+	c.mu.Lock()
+	if k == (key{}) && c.dummy != nil {
+		data := c.dummy
+		c.mu.Unlock()
+		return data, true
+	}
+	if e, ok := c.entries[k.clipID]; ok && e.Value.(*entry).key == k {
+		c.lru.MoveToFront(e)
+		data := e.Value.(*entry).data
+		c.mu.Unlock()
+		return data, true
+	}
+	c.mu.Unlock()
+
+	if c.dir == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(c.file(k))
+	if err != nil {
+		return nil, false
+	}
+	c.store(k, data)
+	return data, true
+}
+
+// fetch downloads a thumbnail once for all the callers asking for it at the
+// same time, and caches it. If the download is stopped by the context of the
+// caller doing it, the other callers try again with theirs.
+func (c *Cache) fetch(ctx context.Context, k key, get func(context.Context) (io.ReadCloser, error)) ([]byte, error) {
+	var cl *call
+	for {
+		c.mu.Lock()
+		running, ok := c.inflight[k]
+		if !ok {
+			cl = &call{done: make(chan struct{})}
+			c.inflight[k] = cl
+		}
+		c.mu.Unlock()
+		if !ok {
+			break
+		}
+		select {
+		case <-running.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if !isContextError(running.err) || ctx.Err() != nil {
+			return running.data, running.err
+		}
+		if data, ok := c.lookup(k); ok {
+			return data, nil
+		}
+	}
+
+	cl.data, cl.err = download(ctx, get)
+	if cl.err == nil {
+		c.store(k, cl.data)
+		if c.dir != "" {
+			if err := c.save(k, cl.data); err != nil && c.onError != nil {
+				c.onError(err)
+			}
+		}
+	}
+	c.mu.Lock()
+	delete(c.inflight, k)
+	c.mu.Unlock()
+	close(cl.done)
+	return cl.data, cl.err
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func download(ctx context.Context, get func(context.Context) (io.ReadCloser, error)) ([]byte, error) {
+	rc, err := get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// store keeps a thumbnail in memory, replacing the older version of the
+// thumbnail of the clip and evicting the least recently used ones
+func (c *Cache) store(k key, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if k == (key{}) {
+		c.dummy = data
+		return
+	}
+	if e, ok := c.entries[k.clipID]; ok {
+		e.Value = &entry{key: k, data: data}
+		c.lru.MoveToFront(e)
+		return
+	}
+	c.entries[k.clipID] = c.lru.PushFront(&entry{key: k, data: data})
+	for c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*entry).key.clipID)
+	}
+}
+
+// file returns the path of a thumbnail in the directory
+func (c *Cache) file(k key) string {
+	if k == (key{}) {
+		return filepath.Join(c.dir, "dummy.png")
+	}
+	return filepath.Join(c.dir, fmt.Sprintf("%d-%s.png", k.clipID, k.lastUpdate))
+}
+
+// save writes a thumbnail to the directory and removes the older versions of
+// the thumbnail of the clip
+func (c *Cache) save(k key, data []byte) error {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.dir, ".thumbnail-*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.file(k))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to save thumbnail: %w", err)
+	}
+
+	if k != (key{}) {
+		old, _ := filepath.Glob(filepath.Join(c.dir, fmt.Sprintf("%d-*.png", k.clipID)))
+		for _, name := range old {
+			if name != c.file(k) {
+				os.Remove(name)
+			}
+		}
+	}
+	return nil
+}
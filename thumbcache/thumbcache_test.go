@@ -0,0 +1,213 @@
+package thumbcache
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FlowingSPDG/resolume-go"
+	"github.com/FlowingSPDG/resolume-go/resolumetest"
+)
+
+// newServer returns a fake Resolume with 2 layers of 3 columns where clip 1 of
+// layer 1 has a custom thumbnail and clip 2 of both layers a file
+func newServer(t *testing.T) (*resolumetest.Server, *resolume.Client) {
+	t.Helper()
+	server := resolumetest.NewServer(resolumetest.WithSize(2, 3))
+	t.Cleanup(server.Close)
+	client := server.Client()
+	for _, err := range []error{
+		client.SetClipThumbnail(1, 1, strings.NewReader("custom")),
+		client.OpenClip(1, 2, "file:///C:/a.mov"),
+		client.OpenClip(2, 2, "file:///C:/b.mov"),
+	} {
+		if err != nil {
+			t.Fatalf("Setting up the composition failed: %v", err)
+		}
+	}
+	server.Requests()
+	return server, client
+}
+
+// thumbnailRequests returns the thumbnail requests received by the server
+func thumbnailRequests(server *resolumetest.Server) []string {
+	var paths []string
+	for _, r := range server.Requests() {
+		if strings.Contains(r.Path, "thumbnail") {
+			paths = append(paths, r.Path)
+		}
+	}
+	return paths
+}
+
+func TestClip(t *testing.T) {
+	server, client := newServer(t)
+	cache := New(client)
+	ctx := context.Background()
+	composition := server.Composition()
+
+	for i := 0; i < 2; i++ {
+		data, err := cache.Clip(ctx, composition.Clip(1, 1))
+		if err != nil || string(data) != "custom" {
+			t.Fatalf("Clip() = %q, %v", data, err)
+		}
+	}
+	clip := composition.Clip(1, 1)
+	want := []string{"/composition/clips/by-id/" + itoa(clip.ID) + "/thumbnail/" + clip.Thumbnail.LastUpdate}
+	if got := thumbnailRequests(server); !equal(got, want) {
+		t.Errorf("Requests = %v, want %v", got, want)
+	}
+
+	// Empty clips share the dummy thumbnail, fetched once
+	for _, clip := range []*resolume.Clip{composition.Clip(1, 3), composition.Clip(2, 1), composition.Clip(2, 3)} {
+		if _, err := cache.Clip(ctx, clip); err != nil {
+			t.Fatalf("Clip() of an empty clip error = %v", err)
+		}
+	}
+	if got := thumbnailRequests(server); !equal(got, []string{"/composition/thumbnail/dummy"}) {
+		t.Errorf("Requests = %v, want a single dummy request", got)
+	}
+
+	// A new LastUpdate is fetched again
+	client.SetClipThumbnail(1, 1, strings.NewReader("changed"))
+	server.Requests()
+	data, err := cache.Clip(ctx, server.Composition().Clip(1, 1))
+	if err != nil || string(data) != "changed" {
+		t.Errorf("Clip() after a change = %q, %v", data, err)
+	}
+	if cache.Len() != 1 {
+		t.Errorf("Len() = %d, want the older version replaced", cache.Len())
+	}
+
+	// A stale LastUpdate fails instead of returning another image
+	if _, err := cache.Clip(ctx, clip); err == nil {
+		t.Error("Clip() with a stale LastUpdate error = nil")
+	}
+}
+
+func TestPrefetchAndDir(t *testing.T) {
+	server, client := newServer(t)
+	dir := filepath.Join(t.TempDir(), "thumbnails")
+	ctx := context.Background()
+	composition := server.Composition()
+
+	cache := New(client, WithDir(dir), WithConcurrency(2))
+	if err := cache.PrefetchDeck(ctx, composition); err != nil {
+		t.Fatalf("PrefetchDeck() error = %v", err)
+	}
+	if got := thumbnailRequests(server); len(got) != 4 {
+		t.Errorf("PrefetchDeck() sent %v, want 3 clips and the dummy", got)
+	}
+	files, _ := os.ReadDir(dir)
+	if len(files) != 4 {
+		t.Errorf("Directory has %d files, want 4", len(files))
+	}
+
+	// A new Cache on the same directory downloads nothing
+	cache = New(client, WithDir(dir))
+	if err := cache.PrefetchLayer(ctx, &composition.Layers[0]); err != nil {
+		t.Fatalf("PrefetchLayer() error = %v", err)
+	}
+	if got := thumbnailRequests(server); len(got) != 0 {
+		t.Errorf("PrefetchLayer() with a warm directory sent %v", got)
+	}
+
+	// Only the latest version of a thumbnail is kept on disk
+	client.SetClipThumbnail(1, 1, strings.NewReader("changed"))
+	if _, err := cache.Clip(ctx, server.Composition().Clip(1, 1)); err != nil {
+		t.Fatalf("Clip() error = %v", err)
+	}
+	old, _ := filepath.Glob(filepath.Join(dir, itoa(composition.Clip(1, 1).ID)+"-*.png"))
+	if len(old) != 1 {
+		t.Errorf("Directory has %v for the clip, want a single version", old)
+	}
+}
+
+func TestCancelledFetch(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var blocked int32
+	server := resolumetest.NewServer(resolumetest.WithSize(1, 1), resolumetest.WithHook(func(r resolumetest.Request) *resolumetest.Fault {
+		if strings.Contains(r.Path, "thumbnail") && atomic.AddInt32(&blocked, 1) == 1 {
+			close(started)
+			<-release
+		}
+		return nil
+	}))
+	defer server.Close()
+	defer close(release)
+	cache := New(server.Client())
+	clip := server.Composition().Clip(1, 1)
+
+	// The first caller gives up while a second one waits for its download
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := cache.Clip(ctx, clip)
+		first <- err
+	}()
+	<-started
+	second := make(chan error, 1)
+	go func() {
+		_, err := cache.Clip(context.Background(), clip)
+		second <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("Clip() of the cancelled caller error = %v, want context.Canceled", err)
+	}
+	if err := <-second; err != nil {
+		t.Errorf("Clip() of the waiting caller error = %v", err)
+	}
+}
+
+func TestSaveError(t *testing.T) {
+	server, client := newServer(t)
+	file := filepath.Join(t.TempDir(), "file")
+	os.WriteFile(file, nil, 0o644)
+
+	var saveErr error
+	cache := New(client, WithDir(file), WithErrorHandler(func(err error) { saveErr = err }))
+	data, err := cache.Clip(context.Background(), server.Composition().Clip(1, 1))
+	if err != nil || string(data) != "custom" {
+		t.Errorf("Clip() with an unusable directory = %q, %v", data, err)
+	}
+	if saveErr == nil {
+		t.Error("Error handler was not called")
+	}
+}
+
+func TestMaxEntries(t *testing.T) {
+	server, client := newServer(t)
+	cache := New(client, WithMaxEntries(2))
+	ctx := context.Background()
+	composition := server.Composition()
+
+	for _, clip := range []*resolume.Clip{composition.Clip(1, 1), composition.Clip(1, 2), composition.Clip(2, 2), composition.Clip(1, 1)} {
+		if _, err := cache.Clip(ctx, clip); err != nil {
+			t.Fatalf("Clip() error = %v", err)
+		}
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", cache.Len())
+	}
+	if got := thumbnailRequests(server); len(got) != 4 {
+		t.Errorf("Requests = %v, want the evicted clip fetched again", got)
+	}
+}
+
+func itoa(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
+
+func equal(a, b []string) bool {
+	return strings.Join(a, "\n") == strings.Join(b, "\n")
+}
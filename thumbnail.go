@@ -0,0 +1,116 @@
+package resolume
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+
+	"golang.org/x/image/draw"
+)
+
+// Size images are resized to by the SetClipThumbnailImage methods when asked to
+const (
+	ThumbnailWidth  = 320
+	ThumbnailHeight = 180
+)
+
+// SetClipThumbnailFromFile sets the thumbnail of a clip to an image file on the
+// machine running Resolume, given as a URL such as
+// "file:///C:/Users/Resolume/thumbnail%201.png". The file must be a gif, png
+// or jpg.
+func (c *Client) SetClipThumbnailFromFile(layerIndex, clipIndex int, fileURL string) error {
+	return c.SetClipThumbnailFromFileContext(context.Background(), layerIndex, clipIndex, fileURL)
+}
+
+// SetClipThumbnailFromFileContext is like SetClipThumbnailFromFile but with a context
+func (c *Client) SetClipThumbnailFromFileContext(ctx context.Context, layerIndex, clipIndex int, fileURL string) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d/thumbnail", layerIndex, clipIndex)
+	return c.post(ctx, endpoint, uriText(fileURL), nil)
+}
+
+// SetClipThumbnailImage sets the thumbnail of a clip to an image, encoded as
+// PNG. If resize is true, the image is first scaled to fit ThumbnailWidth x
+// ThumbnailHeight, keeping its aspect ratio.
+func (c *Client) SetClipThumbnailImage(layerIndex, clipIndex int, img image.Image, resize bool) error {
+	return c.SetClipThumbnailImageContext(context.Background(), layerIndex, clipIndex, img, resize)
+}
+
+// SetClipThumbnailImageContext is like SetClipThumbnailImage but with a context
+func (c *Client) SetClipThumbnailImageContext(ctx context.Context, layerIndex, clipIndex int, img image.Image, resize bool) error {
+	endpoint := fmt.Sprintf("/composition/layers/%d/clips/%d/thumbnail", layerIndex, clipIndex)
+	return c.post(ctx, endpoint, imageFile(img, resize), nil)
+}
+
+// SetSelectedClipThumbnailFromFile is like SetClipThumbnailFromFile for the selected clip
+func (c *Client) SetSelectedClipThumbnailFromFile(fileURL string) error {
+	return c.SetSelectedClipThumbnailFromFileContext(context.Background(), fileURL)
+}
+
+// SetSelectedClipThumbnailFromFileContext is like SetSelectedClipThumbnailFromFile but with a context
+func (c *Client) SetSelectedClipThumbnailFromFileContext(ctx context.Context, fileURL string) error {
+	return c.post(ctx, "/composition/clips/selected/thumbnail", uriText(fileURL), nil)
+}
+
+// SetSelectedClipThumbnailImage is like SetClipThumbnailImage for the selected clip
+func (c *Client) SetSelectedClipThumbnailImage(img image.Image, resize bool) error {
+	return c.SetSelectedClipThumbnailImageContext(context.Background(), img, resize)
+}
+
+// SetSelectedClipThumbnailImageContext is like SetSelectedClipThumbnailImage but with a context
+func (c *Client) SetSelectedClipThumbnailImageContext(ctx context.Context, img image.Image, resize bool) error {
+	return c.post(ctx, "/composition/clips/selected/thumbnail", imageFile(img, resize), nil)
+}
+
+// SetClipThumbnailByIDFromFile is like SetClipThumbnailFromFile for the clip by id
+func (c *Client) SetClipThumbnailByIDFromFile(clipID int64, fileURL string) error {
+	return c.SetClipThumbnailByIDFromFileContext(context.Background(), clipID, fileURL)
+}
+
+// SetClipThumbnailByIDFromFileContext is like SetClipThumbnailByIDFromFile but with a context
+func (c *Client) SetClipThumbnailByIDFromFileContext(ctx context.Context, clipID int64, fileURL string) error {
+	endpoint := fmt.Sprintf("/composition/clips/by-id/%d/thumbnail", clipID)
+	return c.post(ctx, endpoint, uriText(fileURL), nil)
+}
+
+// SetClipThumbnailByIDImage is like SetClipThumbnailImage for the clip by id
+func (c *Client) SetClipThumbnailByIDImage(clipID int64, img image.Image, resize bool) error {
+	return c.SetClipThumbnailByIDImageContext(context.Background(), clipID, img, resize)
+}
+
+// SetClipThumbnailByIDImageContext is like SetClipThumbnailByIDImage but with a context
+func (c *Client) SetClipThumbnailByIDImageContext(ctx context.Context, clipID int64, img image.Image, resize bool) error {
+	endpoint := fmt.Sprintf("/composition/clips/by-id/%d/thumbnail", clipID)
+	return c.post(ctx, endpoint, imageFile(img, resize), nil)
+}
+
+// imageFile returns the multipart body of an image, encoded as PNG while
+// the request is sent
+func imageFile(img image.Image, resize bool) multipartFile {
+	if resize {
+		img = fitThumbnail(img)
+	}
+	return multipartFile{field: "file", filename: "thumbnail.png", write: func(w io.Writer) error {
+		return png.Encode(w, img)
+	}}
+}
+
+// fitThumbnail scales an image to fit ThumbnailWidth x ThumbnailHeight,
+// keeping its aspect ratio
+func fitThumbnail(img image.Image) image.Image {
+	b := img.Bounds()
+	if b.Dx() == 0 || b.Dy() == 0 {
+		return img
+	}
+	width, height := ThumbnailWidth, b.Dy()*ThumbnailWidth/b.Dx()
+	if height > ThumbnailHeight {
+		width, height = b.Dx()*ThumbnailHeight/b.Dy(), ThumbnailHeight
+	}
+	if width == b.Dx() && height == b.Dy() {
+		return img
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, max(width, 1), max(height, 1)))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
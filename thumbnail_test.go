@@ -0,0 +1,87 @@
+package resolume
+
+import (
+	"errors"
+	"image"
+	"image/png"
+	"mime"
+	"mime/multipart"
+	"strings"
+	"testing"
+)
+
+// postedImage decodes the image of a multipart thumbnail request
+func postedImage(t *testing.T, req recordedRequest) image.Image {
+	t.Helper()
+	_, params, err := mime.ParseMediaType(req.contentType)
+	if err != nil {
+		t.Fatalf("Content-Type = %s: %v", req.contentType, err)
+	}
+	part, err := multipart.NewReader(strings.NewReader(req.body), params["boundary"]).NextPart()
+	if err != nil {
+		t.Fatalf("NextPart() error = %v", err)
+	}
+	img, err := png.Decode(part)
+	if err != nil {
+		t.Fatalf("png.Decode() error = %v", err)
+	}
+	return img
+}
+
+func TestSetClipThumbnailImage(t *testing.T) {
+	client, requests, closeServer := newRecordingServer(t)
+	defer closeServer()
+	img := image.NewRGBA(image.Rect(0, 0, 640, 480))
+
+	tests := []struct {
+		name string
+		call func() error
+		path string
+		size image.Point
+	}{
+		{"SetClipThumbnailImage", func() error { return client.SetClipThumbnailImage(1, 2, img, true) }, "/api/v1/composition/layers/1/clips/2/thumbnail", image.Pt(240, 180)},
+		{"SetSelectedClipThumbnailImage", func() error { return client.SetSelectedClipThumbnailImage(img, false) }, "/api/v1/composition/clips/selected/thumbnail", image.Pt(640, 480)},
+		{"SetClipThumbnailByIDImage", func() error {
+			return client.SetClipThumbnailByIDImage(9, image.NewRGBA(image.Rect(0, 0, 1920, 1080)), true)
+		}, "/api/v1/composition/clips/by-id/9/thumbnail", image.Pt(ThumbnailWidth, ThumbnailHeight)},
+	}
+	for _, tt := range tests {
+		*requests = nil
+		if err := tt.call(); err != nil {
+			t.Errorf("%s() error = %v", tt.name, err)
+			continue
+		}
+		req := (*requests)[0]
+		if req.path != tt.path {
+			t.Errorf("%s() path = %s, want %s", tt.name, req.path, tt.path)
+		}
+		if size := postedImage(t, req).Bounds().Size(); size != tt.size {
+			t.Errorf("%s() image size = %v, want %v", tt.name, size, tt.size)
+		}
+	}
+}
+
+// failingReader returns an error after some data
+type failingReader struct {
+	sent bool
+}
+
+var errRead = errors.New("read failed")
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.sent {
+		return 0, errRead
+	}
+	r.sent = true
+	return copy(p, "PNG"), nil
+}
+
+func TestSetClipThumbnailReadError(t *testing.T) {
+	client, _, closeServer := newRecordingServer(t)
+	defer closeServer()
+
+	err := client.SetClipThumbnail(1, 1, &failingReader{})
+	if !errors.Is(err, errRead) {
+		t.Errorf("SetClipThumbnail() error = %v, want the read error", err)
+	}
+}
@@ -0,0 +1,115 @@
+package resolume
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Transport type names as reported by Clip.TransportType
+const (
+	TransportTypeTimeline = "Timeline"
+	TransportTypeBPMSync  = "BPM Sync"
+)
+
+// Transport is implemented by the transport types a clip may have
+type Transport interface {
+	// TransportPosition returns the playhead position, or nil if unknown
+	TransportPosition() *RangeParameter
+}
+
+// TransportPosition implements Transport
+func (t *TransportTimeline) TransportPosition() *RangeParameter { return t.Position }
+
+// TransportPosition implements Transport
+func (t *TransportBPMSync) TransportPosition() *RangeParameter { return t.Position }
+
+// UnknownTransport represents a transport type this package does not know.
+// It keeps the raw JSON so it is sent back unchanged.
+type UnknownTransport struct {
+	Raw json.RawMessage
+}
+
+// TransportPosition implements Transport
+func (t *UnknownTransport) TransportPosition() *RangeParameter { return nil }
+
+// MarshalJSON returns the raw JSON of the transport
+func (t *UnknownTransport) MarshalJSON() ([]byte, error) {
+	if t.Raw == nil {
+		return []byte("null"), nil
+	}
+	return t.Raw, nil
+}
+
+// UnmarshalJSON stores the raw JSON of the transport
+func (t *UnknownTransport) UnmarshalJSON(data []byte) error {
+	t.Raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// UnmarshalJSON decodes a clip, choosing the concrete transport type from
+// TransportType or, when that is missing, from the controls present
+func (c *Clip) UnmarshalJSON(data []byte) error {
+	type clip Clip
+	aux := struct {
+		*clip
+		Transport json.RawMessage `json:"transport,omitempty"`
+	}{clip: (*clip)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	c.Transport = nil
+	if len(aux.Transport) == 0 || string(aux.Transport) == "null" {
+		return nil
+	}
+	transport, err := decodeTransport(aux.Transport, c.TransportType)
+	if err != nil {
+		return err
+	}
+	c.Transport = transport
+	return nil
+}
+
+// decodeTransport decodes a transport into its concrete type
+func decodeTransport(data []byte, transportType *ChoiceParameter) (Transport, error) {
+	var t Transport
+	switch {
+	case transportType != nil && transportType.Value == TransportTypeTimeline:
+		t = &TransportTimeline{}
+	case transportType != nil && transportType.Value == TransportTypeBPMSync:
+		t = &TransportBPMSync{}
+	case transportType != nil && transportType.Value != "":
+		t = &UnknownTransport{}
+	default:
+		var probe struct {
+			Controls map[string]json.RawMessage `json:"controls"`
+		}
+		if err := json.Unmarshal(data, &probe); err != nil {
+			return nil, fmt.Errorf("failed to decode transport: %w", err)
+		}
+		_, hasBPM := probe.Controls["bpm"]
+		_, hasSyncMode := probe.Controls["syncmode"]
+		_, hasBeatLoop := probe.Controls["beatloop"]
+		if hasBPM || hasSyncMode || hasBeatLoop {
+			t = &TransportBPMSync{}
+		} else {
+			t = &TransportTimeline{}
+		}
+	}
+	if err := json.Unmarshal(data, t); err != nil {
+		return nil, fmt.Errorf("failed to decode transport: %w", err)
+	}
+	return t, nil
+}
+
+// TransportTimeline returns the clip transport if it is a timeline transport
+func (c *Clip) TransportTimeline() (*TransportTimeline, bool) {
+	t, ok := c.Transport.(*TransportTimeline)
+	return t, ok
+}
+
+// TransportBPMSync returns the clip transport if it is a BPM sync transport
+func (c *Clip) TransportBPMSync() (*TransportBPMSync, bool) {
+	t, ok := c.Transport.(*TransportBPMSync)
+	return t, ok
+}
@@ -0,0 +1,127 @@
+package resolume
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClipTransportDecoding(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "timeline by transport type",
+			data: `{"id": 1, "transporttype": {"value": "Timeline"}, "transport": {"position": {"id": 2, "value": 0.5}, "controls": {"playdirection": {"value": ">"}}}}`,
+			want: "*resolume.TransportTimeline",
+		},
+		{
+			name: "bpm sync by transport type",
+			data: `{"id": 1, "transporttype": {"value": "BPM Sync"}, "transport": {"controls": {"playdirection": {"value": ">"}}}}`,
+			want: "*resolume.TransportBPMSync",
+		},
+		{
+			name: "bpm sync by controls",
+			data: `{"id": 1, "transport": {"controls": {"bpm": {"id": 3, "value": 128}}}}`,
+			want: "*resolume.TransportBPMSync",
+		},
+		{
+			name: "timeline by controls",
+			data: `{"id": 1, "transport": {"controls": {"speed": {"id": 3, "value": 1}}}}`,
+			want: "*resolume.TransportTimeline",
+		},
+		{
+			name: "unknown transport type",
+			data: `{"id": 1, "transporttype": {"value": "SMPTE"}, "transport": {"offset": 10}}`,
+			want: "*resolume.UnknownTransport",
+		},
+		{
+			name: "no transport",
+			data: `{"id": 1}`,
+			want: "<nil>",
+		},
+	}
+
+	for _, tt := range tests {
+		var clip Clip
+		if err := json.Unmarshal([]byte(tt.data), &clip); err != nil {
+			t.Errorf("%s: Unmarshal() error = %v", tt.name, err)
+			continue
+		}
+		if got := fmt.Sprintf("%T", clip.Transport); got != tt.want {
+			t.Errorf("%s: transport = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestClipTransportAccessors(t *testing.T) {
+	var clip Clip
+	data := `{"id": 1, "transport": {"position": {"id": 2, "value": 0.5}, "controls": {"bpm": {"id": 3, "value": 128}}}}`
+	if err := json.Unmarshal([]byte(data), &clip); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if _, ok := clip.TransportTimeline(); ok {
+		t.Error("Expected no timeline transport")
+	}
+	bpmSync, ok := clip.TransportBPMSync()
+	if !ok {
+		t.Fatal("Expected a BPM sync transport")
+	}
+	if bpmSync.Controls.BPM.Value != 128 {
+		t.Errorf("Expected BPM 128, got %v", bpmSync.Controls.BPM.Value)
+	}
+	if clip.Transport.TransportPosition().Value != 0.5 {
+		t.Errorf("Expected position 0.5, got %v", clip.Transport.TransportPosition().Value)
+	}
+}
+
+func TestReplaceClipByPositionTransport(t *testing.T) {
+	// Create test server
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/composition/layers/1/clips/2" {
+			t.Errorf("Expected path /api/v1/composition/layers/1/clips/2, got %s", r.URL.Path)
+		}
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClientFromURL(server.URL+"/api/v1", WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewClientFromURL() error = %v", err)
+	}
+
+	clip := &Clip{
+		Transport: &TransportTimeline{
+			Controls: TransportTimelineControls{
+				PlayDirection: &ChoiceParameter{Value: "<"},
+			},
+		},
+	}
+	if err := client.ReplaceClipByPosition(1, 2, clip); err != nil {
+		t.Fatalf("ReplaceClipByPosition() error = %v", err)
+	}
+
+	var sent struct {
+		Transport struct {
+			Controls struct {
+				PlayDirection struct {
+					Value string `json:"value"`
+				} `json:"playdirection"`
+			} `json:"controls"`
+		} `json:"transport"`
+	}
+	if err := json.Unmarshal(body, &sent); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if sent.Transport.Controls.PlayDirection.Value != "<" {
+		t.Errorf("Expected play direction <, got body %s", body)
+	}
+}
@@ -1,6 +1,13 @@
+// Code generated by resolume-gen from swagger.yaml. DO NOT EDIT.
+
 package resolume
 
-// ProductInfo represents information about the Resolume product
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ProductInfo represents generic information about the product serving the api
 type ProductInfo struct {
 	Name     string `json:"name"`
 	Major    int64  `json:"major"`
@@ -9,37 +16,7 @@ type ProductInfo struct {
 	Revision int64  `json:"revision"`
 }
 
-// Effect represents an effect to be used on clips/layers/composition
-type Effect struct {
-	IDString string `json:"idstring"`
-	Name     string `json:"name"`
-	Presets  []struct {
-		ID   int64  `json:"id"`
-		Name string `json:"name"`
-	} `json:"presets,omitempty"`
-}
-
-// Effects represents available effects for clips/layers/composition
-type Effects struct {
-	Video []Effect `json:"video"`
-}
-
-// Source represents a source that can be used in clips
-type Source struct {
-	IDString string `json:"idstring"`
-	Name     string `json:"name"`
-	Presets  []struct {
-		ID   int64  `json:"id"`
-		Name string `json:"name"`
-	} `json:"presets,omitempty"`
-}
-
-// Sources represents available sources for clips
-type Sources struct {
-	Video []Source `json:"video"`
-}
-
-// ParameterView represents semantic information about how to display a parameter
+// ParameterView represents semantic information on the parameter, contains hints about how best to display the parameter
 type ParameterView struct {
 	Suffix       string  `json:"suffix,omitempty"`
 	Step         float64 `json:"step,omitempty"`
@@ -53,7 +30,7 @@ type BooleanParameter struct {
 	ID        int64         `json:"id"`
 	ValueType string        `json:"valuetype"`
 	Value     bool          `json:"value"`
-	View      ParameterView `json:"view,omitempty"`
+	View      ParameterView `json:"view"`
 }
 
 // ChoiceParameter represents a multiple-choice parameter
@@ -63,7 +40,7 @@ type ChoiceParameter struct {
 	Value     string        `json:"value"`
 	Index     int32         `json:"index"`
 	Options   []string      `json:"options"`
-	View      ParameterView `json:"view,omitempty"`
+	View      ParameterView `json:"view"`
 }
 
 // ColorParameter represents a parameter containing color data
@@ -72,14 +49,14 @@ type ColorParameter struct {
 	ValueType string        `json:"valuetype"`
 	Value     string        `json:"value"`
 	Palette   []string      `json:"palette,omitempty"`
-	View      ParameterView `json:"view,omitempty"`
+	View      ParameterView `json:"view"`
 }
 
-// EventParameter represents a parameter that handles events but does not contain a value
+// EventParameter represents a parameter that handles events, but does not contain a value
 type EventParameter struct {
 	ID        int64         `json:"id"`
 	ValueType string        `json:"valuetype"`
-	View      ParameterView `json:"view,omitempty"`
+	View      ParameterView `json:"view"`
 }
 
 // IntegerParameter represents a parameter containing numeric data
@@ -87,10 +64,10 @@ type IntegerParameter struct {
 	ID        int64         `json:"id"`
 	ValueType string        `json:"valuetype"`
 	Value     int64         `json:"value"`
-	View      ParameterView `json:"view,omitempty"`
+	View      ParameterView `json:"view"`
 }
 
-// RangeParameter represents a parameter containing a floating-point value with min/max
+// RangeParameter represents a parameter containing a floating-point value with a minimum and maximum allowed value
 type RangeParameter struct {
 	ID        int64         `json:"id"`
 	ValueType string        `json:"valuetype"`
@@ -99,7 +76,7 @@ type RangeParameter struct {
 	In        float64       `json:"in"`
 	Out       float64       `json:"out"`
 	Value     float64       `json:"value"`
-	View      ParameterView `json:"view,omitempty"`
+	View      ParameterView `json:"view"`
 }
 
 // StringParameter represents a parameter containing string data
@@ -107,24 +84,24 @@ type StringParameter struct {
 	ID        int64         `json:"id"`
 	ValueType string        `json:"valuetype"`
 	Value     string        `json:"value"`
-	View      ParameterView `json:"view,omitempty"`
+	View      ParameterView `json:"view"`
 }
 
-// TextParameter represents a parameter containing possibly multiline string data
+// TextParameter represents a parameter containing string data, possibly multiline
 type TextParameter struct {
 	ID        int64         `json:"id"`
 	ValueType string        `json:"valuetype"`
 	Value     string        `json:"value"`
-	View      ParameterView `json:"view,omitempty"`
+	View      ParameterView `json:"view"`
 }
 
-// ResetParameter represents options for resetting a parameter
+// ResetParameter represents options for resetting a parameter, should only the value be reset, or should animations also be reset
 type ResetParameter struct {
 	ResetAnimation bool `json:"resetanimation"`
 }
 
 // ParameterCollection represents an unstructured collection of parameters
-type ParameterCollection map[string]interface{}
+type ParameterCollection map[string]Parameter
 
 // AudioEffect represents a single audio effect in a chain
 type AudioEffect struct {
@@ -156,7 +133,7 @@ type AudioFileInfo struct {
 	BPM         float64 `json:"bpm"`
 }
 
-// AudioTrack represents an audio track as part of a clip/layer/group/composition
+// AudioTrack represents an audio track, as part of a clip,layer,group or a composition
 type AudioTrack struct {
 	Volume  *RangeParameter `json:"volume,omitempty"`
 	Pan     *RangeParameter `json:"pan,omitempty"`
@@ -177,83 +154,38 @@ type AutoPilot struct {
 
 // TransportBPMSync represents BPM sync transport controls
 type TransportBPMSync struct {
-	Position *RangeParameter `json:"position,omitempty"`
-	Controls struct {
-		PlayDirection *ChoiceParameter `json:"playdirection,omitempty"`
-		PlayMode      *ChoiceParameter `json:"playmode,omitempty"`
-		PlayModeAway  *ChoiceParameter `json:"playmodeaway,omitempty"`
-		Duration      *RangeParameter  `json:"duration,omitempty"`
-		Speed         *RangeParameter  `json:"speed,omitempty"`
-		BPM           *RangeParameter  `json:"bpm,omitempty"`
-		SyncMode      *ChoiceParameter `json:"syncmode,omitempty"`
-		BeatLoop      *ChoiceParameter `json:"beatloop,omitempty"`
-	} `json:"controls"`
+	Position *RangeParameter          `json:"position,omitempty"`
+	Controls TransportBPMSyncControls `json:"controls"`
 }
 
-// TransportTimeline represents timeline transport controls
-type TransportTimeline struct {
-	Position *RangeParameter `json:"position,omitempty"`
-	Controls struct {
-		PlayDirection *ChoiceParameter `json:"playdirection,omitempty"`
-		PlayMode      *ChoiceParameter `json:"playmode,omitempty"`
-		PlayModeAway  *ChoiceParameter `json:"playmodeaway,omitempty"`
-		Duration      *RangeParameter  `json:"duration,omitempty"`
-		Speed         *RangeParameter  `json:"speed,omitempty"`
-	} `json:"controls"`
+// TransportBPMSyncControls represents BPM Sync controls
+type TransportBPMSyncControls struct {
+	PlayDirection *ChoiceParameter `json:"playdirection,omitempty"`
+	PlayMode      *ChoiceParameter `json:"playmode,omitempty"`
+	PlayModeAway  *ChoiceParameter `json:"playmodeaway,omitempty"`
+	Duration      *RangeParameter  `json:"duration,omitempty"`
+	Speed         *RangeParameter  `json:"speed,omitempty"`
+	BPM           *RangeParameter  `json:"bpm,omitempty"`
+	SyncMode      *ChoiceParameter `json:"syncmode,omitempty"`
+	BeatLoop      *ChoiceParameter `json:"beatloop,omitempty"`
 }
 
-// FrameRate represents frame rate expressed as a ratio
-type FrameRate struct {
-	Num   int32 `json:"num"`
-	Denom int32 `json:"denom"`
-}
-
-// VideoFileInfo represents meta information for a video file
-type VideoFileInfo struct {
-	Path       string    `json:"path"`
-	Exists     bool      `json:"exists"`
-	Duration   string    `json:"duration"`
-	DurationMs float64   `json:"duration_ms"`
-	FrameRate  FrameRate `json:"framerate"`
-	Width      int32     `json:"width"`
-	Height     int32     `json:"height"`
-}
-
-// VideoTrack represents a video track as part of a clip/layer/group/composition
-type VideoTrack struct {
-	Width   *RangeParameter     `json:"width,omitempty"`
-	Height  *RangeParameter     `json:"height,omitempty"`
-	Opacity *RangeParameter     `json:"opacity,omitempty"`
-	Mixer   ParameterCollection `json:"mixer,omitempty"`
-	Effects []VideoEffect       `json:"effects,omitempty"`
-}
-
-// VideoTrackLayer represents a video track specifically for layers
-type VideoTrackLayer struct {
-	VideoTrack
-	AutoSize *ChoiceParameter `json:"autosize,omitempty"`
-}
-
-// VideoTrackClip represents a video track specifically for clips
-type VideoTrackClip struct {
-	VideoTrack
-	Description  string              `json:"description,omitempty"`
-	FileInfo     *VideoFileInfo      `json:"fileinfo,omitempty"`
-	Resize       *ChoiceParameter    `json:"resize,omitempty"`
-	R            *BooleanParameter   `json:"r,omitempty"`
-	G            *BooleanParameter   `json:"g,omitempty"`
-	B            *BooleanParameter   `json:"b,omitempty"`
-	A            *BooleanParameter   `json:"a,omitempty"`
-	SourceParams ParameterCollection `json:"sourceparams,omitempty"`
+// TransportTimeline represents timeline transport controls
+type TransportTimeline struct {
+	Position *RangeParameter           `json:"position,omitempty"`
+	Controls TransportTimelineControls `json:"controls"`
 }
 
-// LayerTransition describes the transition between clips within a layer
-type LayerTransition struct {
-	Duration  *RangeParameter  `json:"duration,omitempty"`
-	BlendMode *ChoiceParameter `json:"blend_mode,omitempty"`
+// TransportTimelineControls represents timeline controls
+type TransportTimelineControls struct {
+	PlayDirection *ChoiceParameter `json:"playdirection,omitempty"`
+	PlayMode      *ChoiceParameter `json:"playmode,omitempty"`
+	PlayModeAway  *ChoiceParameter `json:"playmodeaway,omitempty"`
+	Duration      *RangeParameter  `json:"duration,omitempty"`
+	Speed         *RangeParameter  `json:"speed,omitempty"`
 }
 
-// Clip represents a single clip in the composition
+// Clip represents a single clip in the composition, which may contain a video and/or audio track
 type Clip struct {
 	ID                  int64               `json:"id"`
 	Name                *StringParameter    `json:"name,omitempty"`
@@ -266,7 +198,7 @@ type Clip struct {
 	FaderStart          *ChoiceParameter    `json:"faderstart,omitempty"`
 	BeatSnap            *ChoiceParameter    `json:"beatsnap,omitempty"`
 	TransportType       *ChoiceParameter    `json:"transporttype,omitempty"`
-	Transport           interface{}         `json:"transport,omitempty"` // Can be TransportTimeline or TransportBPMSync
+	Transport           Transport           `json:"transport,omitempty"`
 	Dashboard           ParameterCollection `json:"dashboard,omitempty"`
 	Audio               *AudioTrackClip     `json:"audio,omitempty"`
 	Video               *VideoTrackClip     `json:"video,omitempty"`
@@ -297,7 +229,7 @@ type CrossFader struct {
 	Mixer     ParameterCollection `json:"mixer,omitempty"`
 }
 
-// Deck represents a deck containing layers and clips
+// Deck represents a deck containing a full set of layers and clips
 type Deck struct {
 	ID       int64             `json:"id"`
 	Closed   bool              `json:"closed"`
@@ -307,7 +239,97 @@ type Deck struct {
 	ScrollX  *IntegerParameter `json:"scrollx,omitempty"`
 }
 
-// Layer represents a container for clips
+// Effect represents an effect to be used on a clip
+type Effect struct {
+	IDString string `json:"idstring"`
+	Name     string `json:"name"`
+	Presets  []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"presets,omitempty"`
+}
+
+// Effects represents the available effects for clips, layer (group)s and the composition
+type Effects struct {
+	Video []Effect `json:"video"`
+}
+
+// FrameRate represents frame rate expressed as ratio
+type FrameRate struct {
+	Num   int32 `json:"num"`
+	Denom int32 `json:"denom"`
+}
+
+// Source represents a source to be used in a clip
+type Source struct {
+	IDString string `json:"idstring"`
+	Name     string `json:"name"`
+	Presets  []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"presets,omitempty"`
+}
+
+// Sources represents the available sources for clips
+type Sources struct {
+	Video []Source `json:"video"`
+}
+
+// VideoFileInfo represents meta information for a video file
+type VideoFileInfo struct {
+	Path       string    `json:"path"`
+	Exists     bool      `json:"exists"`
+	Duration   string    `json:"duration"`
+	DurationMs float64   `json:"duration_ms"`
+	FrameRate  FrameRate `json:"framerate"`
+	Width      int32     `json:"width"`
+	Height     int32     `json:"height"`
+}
+
+// LayerTransition describes the transition between clips within a layer
+type LayerTransition struct {
+	Duration  *RangeParameter  `json:"duration,omitempty"`
+	BlendMode *ChoiceParameter `json:"blend_mode,omitempty"`
+}
+
+// VideoTrack represents a video track, as part of a clip,layer,group or a composition
+type VideoTrack struct {
+	Width   *RangeParameter     `json:"width,omitempty"`
+	Height  *RangeParameter     `json:"height,omitempty"`
+	Opacity *RangeParameter     `json:"opacity,omitempty"`
+	Mixer   ParameterCollection `json:"mixer,omitempty"`
+	Effects []VideoEffect       `json:"effects,omitempty"`
+}
+
+// VideoTrackLayer represents a video track specifically for layers
+type VideoTrackLayer struct {
+	VideoTrack
+	AutoSize *ChoiceParameter `json:"autosize,omitempty"`
+}
+
+// VideoTrackClip represents a video track specifically for clips
+type VideoTrackClip struct {
+	VideoTrack
+	Description  string              `json:"description,omitempty"`
+	FileInfo     *VideoFileInfo      `json:"fileinfo,omitempty"`
+	Resize       *ChoiceParameter    `json:"resize,omitempty"`
+	R            *BooleanParameter   `json:"r,omitempty"`
+	G            *BooleanParameter   `json:"g,omitempty"`
+	B            *BooleanParameter   `json:"b,omitempty"`
+	A            *BooleanParameter   `json:"a,omitempty"`
+	SourceParams ParameterCollection `json:"sourceparams,omitempty"`
+}
+
+// TempoController represents the controller for various tempo-related aspects of the composition
+type TempoController struct {
+	Tempo     *RangeParameter `json:"tempo,omitempty"`
+	TempoPull *EventParameter `json:"tempo_pull,omitempty"`
+	TempoPush *EventParameter `json:"tempo_push,omitempty"`
+	TempoTap  *EventParameter `json:"tempo_tap,omitempty"`
+	Resync    *EventParameter `json:"resync,omitempty"`
+}
+
+// Layer represents a container for clips that has its own dashboard and can be selected
 type Layer struct {
 	ID                  int64               `json:"id"`
 	Name                *StringParameter    `json:"name,omitempty"`
@@ -328,7 +350,7 @@ type Layer struct {
 	AutoPilot           *AutoPilot          `json:"autopilot,omitempty"`
 }
 
-// LayerGroup represents a collection of layers
+// LayerGroup represents a collection of layers, allowing controlling of a group of layers as a single object
 type LayerGroup struct {
 	ID                  int64               `json:"id"`
 	Name                *StringParameter    `json:"name,omitempty"`
@@ -346,16 +368,7 @@ type LayerGroup struct {
 	Layers              []Layer             `json:"layers,omitempty"`
 }
 
-// TempoController represents the controller for tempo-related aspects
-type TempoController struct {
-	Tempo     *RangeParameter `json:"tempo,omitempty"`
-	TempoPull *EventParameter `json:"tempo_pull,omitempty"`
-	TempoPush *EventParameter `json:"tempo_push,omitempty"`
-	TempoTap  *EventParameter `json:"tempo_tap,omitempty"`
-	Resync    *EventParameter `json:"resync,omitempty"`
-}
-
-// Composition represents the complete composition
+// Composition represents the complete composition, containing all the decks, layers, clips and their effects
 type Composition struct {
 	Name             *StringParameter    `json:"name,omitempty"`
 	Selected         *BooleanParameter   `json:"selected,omitempty"`
@@ -375,3 +388,30 @@ type Composition struct {
 	LayerGroups      []LayerGroup        `json:"layergroups,omitempty"`
 	TempoController  *TempoController    `json:"tempo_controller,omitempty"`
 }
+
+// AnyEffect is one of *AudioEffect or *VideoEffect
+type AnyEffect interface {
+	isAnyEffect()
+}
+
+func (*AudioEffect) isAnyEffect() {}
+func (*VideoEffect) isAnyEffect() {}
+
+// decodeAnyEffect decodes AnyEffect from JSON, choosing *VideoEffect when any of display_name, mixer, effect is present
+func decodeAnyEffect(data []byte) (AnyEffect, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode AnyEffect: %w", err)
+	}
+	var v AnyEffect = &AudioEffect{}
+	for _, key := range []string{"display_name", "mixer", "effect"} {
+		if _, ok := fields[key]; ok {
+			v = &VideoEffect{}
+			break
+		}
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, fmt.Errorf("failed to decode AnyEffect: %w", err)
+	}
+	return v, nil
+}
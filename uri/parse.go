@@ -0,0 +1,171 @@
+package uri
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalid is returned when a string is not a valid resource URI
+var ErrInvalid = errors.New("invalid resource URI")
+
+// Parse parses a resource URI into Effect, Source, File, Layer, Column, Deck,
+// LayerGroup, Clip or EffectInstance
+func Parse(s string) (URI, error) {
+	switch {
+	case strings.HasPrefix(s, "effect:"):
+		segments, err := schemeSegments(s)
+		if err != nil || len(segments) < 2 || len(segments) > 3 {
+			return nil, invalid(s)
+		}
+		e := Effect{Kind: segments[0], Name: segments[1]}
+		if len(segments) == 3 {
+			e.Preset = segments[2]
+		}
+		return e, nil
+	case strings.HasPrefix(s, "source:"):
+		segments, err := schemeSegments(s)
+		if err != nil || len(segments) < 2 || len(segments) > 3 {
+			return nil, invalid(s)
+		}
+		src := Source{Kind: segments[0], Name: segments[1]}
+		if len(segments) == 3 {
+			id, err := strconv.ParseInt(segments[2], 10, 64)
+			if err != nil {
+				return nil, invalid(s)
+			}
+			src.PresetID = id
+		}
+		return src, nil
+	case strings.HasPrefix(s, "file:"):
+		u, err := url.Parse(s)
+		if err != nil || u.Path == "" {
+			return nil, invalid(s)
+		}
+		return File{Host: u.Host, Path: u.Path}, nil
+	case strings.HasPrefix(s, "/composition"):
+		return parsePath(s)
+	default:
+		return nil, invalid(s)
+	}
+}
+
+func invalid(s string) error {
+	return fmt.Errorf("%w: %q", ErrInvalid, s)
+}
+
+// schemeSegments returns the unescaped path segments of a scheme:///a/b/c URI
+func schemeSegments(s string) ([]string, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host != "" || !strings.HasPrefix(u.Path, "/") {
+		return nil, invalid(s)
+	}
+	segments := strings.Split(strings.TrimPrefix(u.EscapedPath(), "/"), "/")
+	for i, segment := range segments {
+		if segments[i], err = url.PathUnescape(segment); err != nil {
+			return nil, err
+		}
+		if segments[i] == "" {
+			return nil, invalid(s)
+		}
+	}
+	return segments, nil
+}
+
+// parsePath parses a "/composition/..." path
+func parsePath(s string) (URI, error) {
+	segments := strings.Split(strings.TrimPrefix(s, "/composition"), "/")
+	if segments[0] != "" {
+		return nil, invalid(s)
+	}
+	segments = segments[1:]
+
+	// Trailing "/effects/video/N" addresses an effect instance on the owner
+	if n := len(segments); n >= 3 && segments[n-3] == "effects" && segments[n-2] == "video" {
+		index, err := strconv.ParseInt(segments[n-1], 10, 64)
+		if err != nil || index <= 0 {
+			return nil, invalid(s)
+		}
+		owner := "/composition"
+		if n > 3 {
+			ownerURI, err := parsePath("/composition/" + strings.Join(segments[:n-3], "/"))
+			if err != nil {
+				return nil, err
+			}
+			switch ownerURI.(type) {
+			case Layer, LayerGroup, Clip:
+			default:
+				return nil, invalid(s)
+			}
+			owner = ownerURI.String()
+		}
+		return EffectInstance{Owner: owner, Index: index}, nil
+	}
+
+	if len(segments) < 2 {
+		return nil, invalid(s)
+	}
+	index, id, rest, err := indexOrID(segments[1:])
+	if err != nil {
+		return nil, invalid(s)
+	}
+
+	switch segments[0] {
+	case "layers":
+		layer := Layer{Index: index, ID: id}
+		if len(rest) == 0 {
+			return layer, nil
+		}
+		if len(rest) != 2 || rest[0] != "clips" || id != 0 {
+			return nil, invalid(s)
+		}
+		clipIndex, err := strconv.ParseInt(rest[1], 10, 64)
+		if err != nil || clipIndex <= 0 {
+			return nil, invalid(s)
+		}
+		return Clip{Layer: layer, Index: clipIndex}, nil
+	case "clips":
+		if id == 0 || len(rest) != 0 {
+			return nil, invalid(s)
+		}
+		return Clip{ID: id}, nil
+	}
+
+	if len(rest) != 0 {
+		return nil, invalid(s)
+	}
+	switch segments[0] {
+	case "columns":
+		return Column{Index: index, ID: id}, nil
+	case "decks":
+		return Deck{Index: index, ID: id}, nil
+	case "layergroups":
+		return LayerGroup{Index: index, ID: id}, nil
+	default:
+		return nil, invalid(s)
+	}
+}
+
+// indexOrID parses "N" or "by-id/N" and returns the remaining segments
+func indexOrID(segments []string) (index, id int64, rest []string, err error) {
+	if segments[0] == "by-id" {
+		if len(segments) < 2 {
+			return 0, 0, nil, ErrInvalid
+		}
+		id, err = strconv.ParseInt(segments[1], 10, 64)
+		if err != nil || id <= 0 {
+			return 0, 0, nil, ErrInvalid
+		}
+		return 0, id, segments[2:], nil
+	}
+	index, err = strconv.ParseInt(segments[0], 10, 64)
+	if err != nil || index <= 0 {
+		return 0, 0, nil, ErrInvalid
+	}
+	return index, 0, segments[1:], nil
+}
@@ -0,0 +1,288 @@
+// Package uri builds and parses the resource URIs taken by the Resolume API,
+// such as "effect:///video/Blur", "source:///video/Checkered/12345",
+// "file:///C:/Users/Resolume/file%201.mov" and "/composition/layers/3".
+package uri
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// URI is implemented by all resource URIs
+type URI interface {
+	String() string
+}
+
+// Media is implemented by URIs that can be opened into a clip
+type Media interface {
+	URI
+	media()
+}
+
+// VideoKind is the kind of effects and sources applied to video
+const VideoKind = "video"
+
+// Effect identifies an effect and optionally one of its presets, e.g. "effect:///video/Blow/Solid"
+type Effect struct {
+	Kind   string
+	Name   string
+	Preset string
+}
+
+// EffectURI creates a video effect URI. preset may be empty.
+func EffectURI(name, preset string) Effect {
+	return Effect{Kind: VideoKind, Name: name, Preset: preset}
+}
+
+func (e Effect) String() string {
+	s := "effect:///" + url.PathEscape(e.kind()) + "/" + url.PathEscape(e.Name)
+	if e.Preset != "" {
+		s += "/" + url.PathEscape(e.Preset)
+	}
+	return s
+}
+
+func (e Effect) kind() string {
+	if e.Kind == "" {
+		return VideoKind
+	}
+	return e.Kind
+}
+
+// Source identifies a source and optionally one of its presets, e.g. "source:///video/Checkered/12345"
+type Source struct {
+	Kind     string
+	Name     string
+	PresetID int64
+}
+
+// SourceURI creates a video source URI. presetID may be zero.
+func SourceURI(name string, presetID int64) Source {
+	return Source{Kind: VideoKind, Name: name, PresetID: presetID}
+}
+
+func (s Source) String() string {
+	kind := s.Kind
+	if kind == "" {
+		kind = VideoKind
+	}
+	str := "source:///" + url.PathEscape(kind) + "/" + url.PathEscape(s.Name)
+	if s.PresetID != 0 {
+		str += "/" + strconv.FormatInt(s.PresetID, 10)
+	}
+	return str
+}
+
+func (Source) media() {}
+
+// File identifies a file on the machine running Resolume or on a network share
+type File struct {
+	// Host is the network host for UNC paths, empty for local files
+	Host string
+	// Path is the slash-separated path, e.g. "/C:/Users/Resolume/file 1.mov"
+	Path string
+}
+
+// FileURI creates a file URI from a local path. Windows paths such as
+// `C:\Users\Resolume\file 1.mov` and UNC paths such as `\\nas\Resolume\file.mov`
+// are converted to slash-separated form.
+func FileURI(path string) File {
+	switch {
+	case strings.HasPrefix(path, `\\`):
+		rest := strings.ReplaceAll(path[2:], `\`, "/")
+		host, p, _ := strings.Cut(rest, "/")
+		return File{Host: host, Path: "/" + p}
+	case isWindowsDrivePath(path):
+		return File{Path: "/" + strings.ReplaceAll(path, `\`, "/")}
+	default:
+		return File{Path: path}
+	}
+}
+
+// isWindowsDrivePath reports whether path starts with a drive letter such as `C:\` or `C:/`
+func isWindowsDrivePath(path string) bool {
+	if len(path) < 3 || path[1] != ':' || (path[2] != '\\' && path[2] != '/') {
+		return false
+	}
+	c := path[0]
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func (f File) String() string {
+	path := f.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	u := url.URL{Scheme: "file", Host: f.Host, Path: path}
+	return u.String()
+}
+
+// LocalPath returns the path of the file as given to FileURI: a Windows path
+// such as `C:\Users\Resolume\file 1.mov` for a drive letter, a UNC path for a
+// network host and the slash-separated path otherwise.
+func (f File) LocalPath() string {
+	switch {
+	case f.Host != "":
+		return `\\` + f.Host + strings.ReplaceAll(f.Path, "/", `\`)
+	case isWindowsDrivePath(strings.TrimPrefix(f.Path, "/")):
+		return strings.ReplaceAll(strings.TrimPrefix(f.Path, "/"), "/", `\`)
+	default:
+		return f.Path
+	}
+}
+
+func (File) media() {}
+
+// Layer identifies a layer by 1-based index or by id.
+// The zero value is empty, which the add endpoints take as "at the end".
+type Layer struct {
+	Index int64
+	ID    int64
+}
+
+// LayerURI identifies a layer by its 1-based index
+func LayerURI(index int64) Layer {
+	return Layer{Index: index}
+}
+
+// LayerByIDURI identifies a layer by its unique id
+func LayerByIDURI(id int64) Layer {
+	return Layer{ID: id}
+}
+
+func (l Layer) String() string {
+	return entityPath("layers", l.Index, l.ID)
+}
+
+// Clip returns the URI of a clip in this layer by 1-based column index
+func (l Layer) Clip(index int64) Clip {
+	return Clip{Layer: l, Index: index}
+}
+
+// Effect returns the URI of a video effect instance on this layer by 1-based index
+func (l Layer) Effect(index int64) EffectInstance {
+	return EffectInstance{Owner: l.String(), Index: index}
+}
+
+// Column identifies a column by 1-based index or by id
+type Column struct {
+	Index int64
+	ID    int64
+}
+
+// ColumnURI identifies a column by its 1-based index
+func ColumnURI(index int64) Column {
+	return Column{Index: index}
+}
+
+// ColumnByIDURI identifies a column by its unique id
+func ColumnByIDURI(id int64) Column {
+	return Column{ID: id}
+}
+
+func (c Column) String() string {
+	return entityPath("columns", c.Index, c.ID)
+}
+
+// Deck identifies a deck by 1-based index or by id
+type Deck struct {
+	Index int64
+	ID    int64
+}
+
+// DeckURI identifies a deck by its 1-based index
+func DeckURI(index int64) Deck {
+	return Deck{Index: index}
+}
+
+// DeckByIDURI identifies a deck by its unique id
+func DeckByIDURI(id int64) Deck {
+	return Deck{ID: id}
+}
+
+func (d Deck) String() string {
+	return entityPath("decks", d.Index, d.ID)
+}
+
+// LayerGroup identifies a layer group by 1-based index or by id
+type LayerGroup struct {
+	Index int64
+	ID    int64
+}
+
+// LayerGroupURI identifies a layer group by its 1-based index
+func LayerGroupURI(index int64) LayerGroup {
+	return LayerGroup{Index: index}
+}
+
+// LayerGroupByIDURI identifies a layer group by its unique id
+func LayerGroupByIDURI(id int64) LayerGroup {
+	return LayerGroup{ID: id}
+}
+
+func (g LayerGroup) String() string {
+	return entityPath("layergroups", g.Index, g.ID)
+}
+
+// Effect returns the URI of a video effect instance on this layer group by 1-based index
+func (g LayerGroup) Effect(index int64) EffectInstance {
+	return EffectInstance{Owner: g.String(), Index: index}
+}
+
+// Clip identifies a clip by its position in the clip grid or by id
+type Clip struct {
+	Layer Layer
+	Index int64
+	ID    int64
+}
+
+// ClipByIDURI identifies a clip by its unique id
+func ClipByIDURI(id int64) Clip {
+	return Clip{ID: id}
+}
+
+func (c Clip) String() string {
+	if c.ID != 0 {
+		return fmt.Sprintf("/composition/clips/by-id/%d", c.ID)
+	}
+	if c.Index == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s/clips/%d", c.Layer, c.Index)
+}
+
+// Effect returns the URI of a video effect instance on this clip by 1-based index
+func (c Clip) Effect(index int64) EffectInstance {
+	return EffectInstance{Owner: c.String(), Index: index}
+}
+
+// EffectInstance identifies a video effect applied to the composition, a layer,
+// a layer group or a clip, e.g. "/composition/layers/1/effects/video/2"
+type EffectInstance struct {
+	// Owner is the path of the layer, layer group or clip, or "/composition"
+	Owner string
+	Index int64
+}
+
+// CompositionEffectURI identifies a video effect on the composition by 1-based index
+func CompositionEffectURI(index int64) EffectInstance {
+	return EffectInstance{Owner: "/composition", Index: index}
+}
+
+func (e EffectInstance) String() string {
+	return fmt.Sprintf("%s/effects/video/%d", e.Owner, e.Index)
+}
+
+// entityPath formats the path of an entity addressed by index or by id
+func entityPath(collection string, index, id int64) string {
+	switch {
+	case id != 0:
+		return fmt.Sprintf("/composition/%s/by-id/%d", collection, id)
+	case index != 0:
+		return fmt.Sprintf("/composition/%s/%d", collection, index)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,145 @@
+package uri
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		uri  URI
+		want string
+	}{
+		{EffectURI("Blow", ""), "effect:///video/Blow"},
+		{EffectURI("Blow", "Solid"), "effect:///video/Blow/Solid"},
+		{EffectURI("Hue Rotate", ""), "effect:///video/Hue%20Rotate"},
+		{SourceURI("Checkered", 0), "source:///video/Checkered"},
+		{SourceURI("Checkered", 12345), "source:///video/Checkered/12345"},
+		{FileURI("/Users/Resolume/file 1.mov"), "file:///Users/Resolume/file%201.mov"},
+		{FileURI(`C:\Users\Resolume\file 1.mov`), "file:///C:/Users/Resolume/file%201.mov"},
+		{FileURI("C:/Users/Resolume/file 1.mov"), "file:///C:/Users/Resolume/file%201.mov"},
+		{FileURI(`\\nas-hostname\Resolume\thumbnail 1.jpg`), "file://nas-hostname/Resolume/thumbnail%201.jpg"},
+		{FileURI("/Users/Resolume/a#b?.mov"), "file:///Users/Resolume/a%23b%3F.mov"},
+		{LayerURI(3), "/composition/layers/3"},
+		{LayerByIDURI(1658311520090), "/composition/layers/by-id/1658311520090"},
+		{Layer{}, ""},
+		{ColumnURI(3), "/composition/columns/3"},
+		{ColumnByIDURI(1658311520090), "/composition/columns/by-id/1658311520090"},
+		{DeckURI(3), "/composition/decks/3"},
+		{DeckByIDURI(1681349832470), "/composition/decks/by-id/1681349832470"},
+		{LayerGroupURI(2), "/composition/layergroups/2"},
+		{LayerGroupByIDURI(1658387238991), "/composition/layergroups/by-id/1658387238991"},
+		{LayerURI(1).Clip(5), "/composition/layers/1/clips/5"},
+		{ClipByIDURI(1658311744128), "/composition/clips/by-id/1658311744128"},
+		{CompositionEffectURI(3), "/composition/effects/video/3"},
+		{LayerURI(1).Effect(2), "/composition/layers/1/effects/video/2"},
+		{LayerByIDURI(1658311521181).Effect(1), "/composition/layers/by-id/1658311521181/effects/video/1"},
+		{LayerGroupURI(2).Effect(1), "/composition/layergroups/2/effects/video/1"},
+		{LayerURI(1).Clip(5).Effect(2), "/composition/layers/1/clips/5/effects/video/2"},
+		{ClipByIDURI(1658311744128).Effect(5), "/composition/clips/by-id/1658311744128/effects/video/5"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.uri.String(); got != tt.want {
+			t.Errorf("%#v.String() = %s, want %s", tt.uri, got, tt.want)
+		}
+	}
+}
+
+func TestLocalPath(t *testing.T) {
+	tests := []struct {
+		uri  string
+		want string
+	}{
+		{"file:///Users/Resolume/file%201.mov", "/Users/Resolume/file 1.mov"},
+		{"file:///C:/Users/Resolume/file%201.mov", `C:\Users\Resolume\file 1.mov`},
+		{"file://nas-hostname/Resolume/thumbnail%201.jpg", `\\nas-hostname\Resolume\thumbnail 1.jpg`},
+	}
+	for _, tt := range tests {
+		u, err := Parse(tt.uri)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.uri, err)
+		}
+		if got := u.(File).LocalPath(); got != tt.want {
+			t.Errorf("LocalPath() of %s = %q, want %q", tt.uri, got, tt.want)
+		}
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	inputs := []string{
+		"effect:///video/Blow",
+		"effect:///video/Blow/Solid",
+		"effect:///video/Hue%20Rotate",
+		"source:///video/Checkered",
+		"source:///video/Checkered/12345",
+		"file:///Users/Resolume/file%201.mov",
+		"file:///C:/Users/Resolume/file%201.mov",
+		"file://nas-hostname/Resolume/thumbnail%201.jpg",
+		"/composition/layers/3",
+		"/composition/layers/by-id/1658311520090",
+		"/composition/columns/3",
+		"/composition/columns/by-id/1658311520090",
+		"/composition/decks/3",
+		"/composition/layergroups/by-id/1658387238991",
+		"/composition/layers/1/clips/5",
+		"/composition/clips/by-id/1658311744128",
+		"/composition/effects/video/3",
+		"/composition/layers/by-id/1658311521181/effects/video/1",
+		"/composition/layergroups/2/effects/video/1",
+		"/composition/layers/1/clips/5/effects/video/2",
+		"/composition/clips/by-id/1658311744128/effects/video/5",
+	}
+
+	for _, input := range inputs {
+		u, err := Parse(input)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", input, err)
+			continue
+		}
+		if got := u.String(); got != input {
+			t.Errorf("Parse(%q).String() = %s", input, got)
+		}
+	}
+}
+
+func TestParseTypes(t *testing.T) {
+	u, err := Parse("source:///video/Checkered/12345")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if src, ok := u.(Source); !ok || src.Name != "Checkered" || src.PresetID != 12345 {
+		t.Errorf("Parse() = %#v", u)
+	}
+
+	u, err = Parse("file:///Users/Resolume/file%201.mov")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if file, ok := u.(File); !ok || file.Path != "/Users/Resolume/file 1.mov" {
+		t.Errorf("Parse() = %#v", u)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	inputs := []string{
+		"",
+		"layer:///1",
+		"effect://host/video/Blow",
+		"effect:///video",
+		"source:///video/Checkered/preset",
+		"/composition/layers/0",
+		"/composition/layers/x",
+		"/composition/layers/by-id",
+		"/composition/columns/1/clips/2",
+		"/composition/clips/3",
+		"/composition/decks/1/effects/video/1",
+		"/compositions/layers/1",
+	}
+
+	for _, input := range inputs {
+		if _, err := Parse(input); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Parse(%q) error = %v, want ErrInvalid", input, err)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/FlowingSPDG/resolume-go"
+)
+
+// paramRef points at a parameter struct inside the composition mirror
+type paramRef struct {
+	ptr reflect.Value
+}
+
+// envelopeFields are the fields of a parameter_update message that are not
+// part of the parameter
+var envelopeFields = []string{"type", "path"}
+
+// apply merges a parameter_update message into the referenced parameter
+func (r paramRef) apply(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("failed to decode parameter: %w", err)
+	}
+	for _, key := range envelopeFields {
+		delete(fields, key)
+	}
+	// An unknown parameter keeps its raw JSON, which is merged into like the
+	// fields of the typed ones
+	if p, ok := r.ptr.Interface().(*resolume.UnknownParameter); ok && p.Raw != nil {
+		var current map[string]json.RawMessage
+		if err := json.Unmarshal(p.Raw, &current); err == nil {
+			for key, value := range fields {
+				current[key] = value
+			}
+			fields = current
+		}
+	}
+
+	payload, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("failed to encode parameter: %w", err)
+	}
+	if err := json.Unmarshal(payload, r.ptr.Interface()); err != nil {
+		return fmt.Errorf("failed to decode parameter: %w", err)
+	}
+	return nil
+}
+
+// indexParameters maps the id of every parameter in the composition to its location
+func indexParameters(composition *resolume.Composition) map[int64]paramRef {
+	index := map[int64]paramRef{}
+	walk(reflect.ValueOf(composition), index)
+	return index
+}
+
+func walk(v reflect.Value, index map[int64]paramRef) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		elem := v.Elem()
+		if elem.Kind() == reflect.Struct && isParameter(elem.Type()) {
+			index[elem.FieldByName("ID").Int()] = paramRef{ptr: v}
+			return
+		}
+		walk(elem, index)
+	case reflect.Interface:
+		if !v.IsNil() {
+			walk(v.Elem(), index)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !v.Type().Field(i).IsExported() {
+				continue
+			}
+			if field := v.Field(i); field.CanAddr() {
+				walk(field.Addr(), index)
+			} else {
+				walk(field, index)
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			walk(v.Index(i).Addr(), index)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			walk(iter.Value(), index)
+		}
+	}
+}
+
+// isParameter reports whether t is one of the typed parameter structs
+func isParameter(t reflect.Type) bool {
+	_, hasID := t.FieldByName("ID")
+	_, hasValueType := t.FieldByName("ValueType")
+	return hasID && hasValueType
+}
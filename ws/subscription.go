@@ -0,0 +1,152 @@
+package ws
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// ParameterUpdate represents a parameter value pushed by Resolume
+type ParameterUpdate struct {
+	Path  string
+	ID    int64
+	Value interface{}
+	// Raw is the complete message, which also carries fields such as valuetype, min and max
+	Raw json.RawMessage
+}
+
+// Subscription represents interest in updates of a single parameter
+type Subscription struct {
+	// C receives updates when the subscription was created with Subscribe or SubscribePath.
+	// Updates are dropped if the channel buffer is full.
+	C <-chan ParameterUpdate
+
+	client *Client
+	key    string
+	fn     func(ParameterUpdate)
+
+	mu     sync.Mutex
+	ch     chan ParameterUpdate
+	closed bool
+	// calls counts the calls of fn in progress, which Unsubscribe waits for
+	calls sync.WaitGroup
+}
+
+// subscriptionBuffer is the channel buffer size of channel based subscriptions
+const subscriptionBuffer = 16
+
+// Subscribe subscribes to updates of a parameter by its unique id
+func (c *Client) Subscribe(id int64) (*Subscription, error) {
+	return c.SubscribePath(ByID(id))
+}
+
+// SubscribePath subscribes to updates of a parameter by path, e.g. "/composition/master"
+func (c *Client) SubscribePath(parameter string) (*Subscription, error) {
+	ch := make(chan ParameterUpdate, subscriptionBuffer)
+	sub := &Subscription{C: ch, ch: ch, client: c, key: parameter}
+	if err := c.subscribe(sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// SubscribeFunc subscribes to updates of a parameter by its unique id.
+// fn is called from the receiving goroutine and must not block. It may call
+// Unsubscribe or Close.
+func (c *Client) SubscribeFunc(id int64, fn func(ParameterUpdate)) (*Subscription, error) {
+	return c.SubscribePathFunc(ByID(id), fn)
+}
+
+// SubscribePathFunc subscribes to updates of a parameter by path.
+// fn is called from the receiving goroutine and must not block. It may call
+// Unsubscribe or Close.
+func (c *Client) SubscribePathFunc(parameter string, fn func(ParameterUpdate)) (*Subscription, error) {
+	sub := &Subscription{client: c, key: parameter, fn: fn}
+	if err := c.subscribe(sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (c *Client) subscribe(sub *Subscription) error {
+	c.mu.Lock()
+	first := len(c.subs[sub.key]) == 0
+	c.subs[sub.key] = append(c.subs[sub.key], sub)
+	c.mu.Unlock()
+
+	if !first {
+		return nil
+	}
+	if err := c.send(request{Action: "subscribe", Parameter: sub.key}); err != nil {
+		c.removeSubscription(sub)
+		return err
+	}
+	return nil
+}
+
+// removeSubscription removes sub and reports whether it was the last one for its key
+func (c *Client) removeSubscription(sub *Subscription) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	subs := c.subs[sub.key]
+	for i, s := range subs {
+		if s == sub {
+			subs = append(subs[:i], subs[i+1:]...)
+			break
+		}
+	}
+	if len(subs) == 0 {
+		delete(c.subs, sub.key)
+		return true
+	}
+	c.subs[sub.key] = subs
+	return false
+}
+
+// Unsubscribe stops delivering updates and closes C. A callback being called
+// is waited for, so none runs once Unsubscribe returns, unless Unsubscribe is
+// called from a callback.
+// Resolume is told to unsubscribe once no subscriptions for the parameter remain.
+func (s *Subscription) Unsubscribe() error {
+	last := s.client.removeSubscription(s)
+	s.close()
+	if !s.client.inReader() {
+		s.calls.Wait()
+	}
+	if !last {
+		return nil
+	}
+	return s.client.send(request{Action: "unsubscribe", Parameter: s.key})
+}
+
+func (s *Subscription) deliver(update ParameterUpdate) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	if s.fn != nil {
+		// fn is called without the lock so it may unsubscribe
+		s.calls.Add(1)
+		s.mu.Unlock()
+		defer s.calls.Done()
+		s.fn(update)
+		return
+	}
+	defer s.mu.Unlock()
+	select {
+	case s.ch <- update:
+	default:
+	}
+}
+
+func (s *Subscription) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	if s.ch != nil {
+		close(s.ch)
+	}
+}
@@ -0,0 +1,493 @@
+// Package ws implements a client for the Resolume WebSocket API.
+//
+// The WebSocket API pushes the full composition when connecting and whenever
+// its structure changes, and incremental updates for subscribed parameters.
+// The client keeps a live mirror of the composition and dispatches parameter
+// updates to subscriptions.
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/FlowingSPDG/resolume-go"
+	"github.com/gorilla/websocket"
+)
+
+// Client represents a Resolume WebSocket API client
+type Client struct {
+	url               string
+	dialer            *websocket.Dialer
+	header            http.Header
+	reconnectInterval time.Duration
+	onComposition     func(*resolume.Composition)
+	onError           func(error)
+
+	writeMu sync.Mutex
+	conn    *websocket.Conn
+
+	mu          sync.RWMutex
+	composition *resolume.Composition
+	params      map[int64]paramRef
+	latest      map[int64]ParameterUpdate
+	effects     *resolume.Effects
+	sources     *resolume.Sources
+	subs        map[string][]*Subscription
+	ready       chan struct{}
+	readyOnce   sync.Once
+
+	// runMu guards started, set once Connect has started the reader, which
+	// closes done when it stops
+	runMu   sync.Mutex
+	started bool
+	// reader is the id of the goroutine reading messages and calling callbacks
+	reader atomic.Uint64
+
+	closeOnce sync.Once
+	closed    chan struct{}
+	done      chan struct{}
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithDialer sets the websocket.Dialer used to connect
+func WithDialer(dialer *websocket.Dialer) Option {
+	return func(c *Client) {
+		c.dialer = dialer
+	}
+}
+
+// WithHeader sets additional HTTP headers sent with the handshake
+func WithHeader(header http.Header) Option {
+	return func(c *Client) {
+		c.header = header
+	}
+}
+
+// WithReconnectInterval sets the delay between reconnect attempts.
+// A zero or negative interval disables automatic reconnect.
+func WithReconnectInterval(interval time.Duration) Option {
+	return func(c *Client) {
+		c.reconnectInterval = interval
+	}
+}
+
+// WithCompositionHandler sets a callback invoked whenever the full composition is received
+func WithCompositionHandler(fn func(*resolume.Composition)) Option {
+	return func(c *Client) {
+		c.onComposition = fn
+	}
+}
+
+// WithErrorHandler sets a callback invoked for connection errors and error messages from Resolume
+func WithErrorHandler(fn func(error)) Option {
+	return func(c *Client) {
+		c.onError = fn
+	}
+}
+
+// NewClient creates a new Resolume WebSocket API client
+func NewClient(host, port string, opts ...Option) *Client {
+	u := &url.URL{
+		Host:   net.JoinHostPort(host, port),
+		Scheme: "ws",
+		Path:   "/api/v1",
+	}
+	return NewClientFromURL(u.String(), opts...)
+}
+
+// NewClientFromURL creates a new Resolume WebSocket API client for a URL such as
+// "ws://localhost:8080/api/v1"
+func NewClientFromURL(rawURL string, opts ...Option) *Client {
+	c := &Client{
+		url:               rawURL,
+		dialer:            websocket.DefaultDialer,
+		reconnectInterval: 2 * time.Second,
+		params:            map[int64]paramRef{},
+		latest:            map[int64]ParameterUpdate{},
+		subs:              map[string][]*Subscription{},
+		ready:             make(chan struct{}),
+		closed:            make(chan struct{}),
+		done:              make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Connect dials Resolume and starts receiving messages in the background.
+// It returns once the connection is established. A Client connects once:
+// Connect fails after a successful Connect or after Close.
+func (c *Client) Connect(ctx context.Context) error {
+	c.runMu.Lock()
+	defer c.runMu.Unlock()
+	if c.started {
+		return errors.New("already connected")
+	}
+	select {
+	case <-c.closed:
+		return errors.New("client closed")
+	default:
+	}
+
+	conn, _, err := c.dialer.DialContext(ctx, c.url, c.header)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	// Close may have been called during the dial
+	select {
+	case <-c.closed:
+		conn.Close()
+		return errors.New("client closed")
+	default:
+	}
+	c.setConn(conn)
+	c.started = true
+	go c.run(conn)
+	return nil
+}
+
+// WaitComposition blocks until the first composition has been received
+func (c *Client) WaitComposition(ctx context.Context) (*resolume.Composition, error) {
+	select {
+	case <-c.ready:
+		return c.Composition(), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close closes the connection and stops reconnecting. Unless it is called from
+// a callback, it waits for the callback being called, if any.
+func (c *Client) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		// Wait for a Connect in progress, which sees closed after dialing
+		c.runMu.Lock()
+		started := c.started
+		c.runMu.Unlock()
+
+		c.writeMu.Lock()
+		if c.conn != nil {
+			c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			err = c.conn.Close()
+		}
+		c.writeMu.Unlock()
+		if started && !c.inReader() {
+			<-c.done
+		}
+
+		c.mu.Lock()
+		for key, subs := range c.subs {
+			for _, sub := range subs {
+				sub.close()
+			}
+			delete(c.subs, key)
+		}
+		c.mu.Unlock()
+	})
+	return err
+}
+
+// Composition returns a copy of the live composition mirror, or nil if none has been received yet
+func (c *Client) Composition() *resolume.Composition {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.composition == nil {
+		return nil
+	}
+
+	// Deep copy so callers never observe concurrent updates
+	b, err := json.Marshal(c.composition)
+	if err != nil {
+		return nil
+	}
+	var composition resolume.Composition
+	if err := json.Unmarshal(b, &composition); err != nil {
+		return nil
+	}
+	return &composition
+}
+
+// Effects returns the latest list of available effects pushed by Resolume
+func (c *Client) Effects() *resolume.Effects {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.effects
+}
+
+// Sources returns the latest list of available sources pushed by Resolume
+func (c *Client) Sources() *resolume.Sources {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.sources
+}
+
+// Parameter returns the latest update received for a parameter id
+func (c *Client) Parameter(id int64) (ParameterUpdate, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	update, ok := c.latest[id]
+	return update, ok
+}
+
+// Set sets the value of a parameter by path, e.g. "/composition/layers/1/video/opacity"
+func (c *Client) Set(parameter string, value interface{}) error {
+	return c.send(request{Action: "set", Parameter: parameter, Value: value})
+}
+
+// SetByID sets the value of a parameter by its unique id
+func (c *Client) SetByID(id int64, value interface{}) error {
+	return c.Set(ByID(id), value)
+}
+
+// Trigger presses and releases an event parameter by path, e.g. "/composition/layers/1/clips/1/connect"
+func (c *Client) Trigger(parameter string) error {
+	if err := c.send(request{Action: "trigger", Parameter: parameter, Value: true}); err != nil {
+		return err
+	}
+	return c.send(request{Action: "trigger", Parameter: parameter, Value: false})
+}
+
+// ByID returns the WebSocket parameter path addressing a parameter by its unique id
+func ByID(id int64) string {
+	return fmt.Sprintf("/parameter/by-id/%d", id)
+}
+
+// request represents a message sent to Resolume
+type request struct {
+	Action    string      `json:"action"`
+	Parameter string      `json:"parameter"`
+	Value     interface{} `json:"value,omitempty"`
+}
+
+// message represents a message received from Resolume
+type message struct {
+	Type  string          `json:"type"`
+	Path  string          `json:"path"`
+	ID    int64           `json:"id"`
+	Value json.RawMessage `json:"value"`
+	Error string          `json:"error"`
+}
+
+// send writes a request to the current connection
+func (c *Client) send(req request) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if c.conn == nil {
+		return fmt.Errorf("not connected")
+	}
+	if err := c.conn.WriteJSON(req); err != nil {
+		return fmt.Errorf("failed to send %s: %w", req.Action, err)
+	}
+	return nil
+}
+
+func (c *Client) setConn(conn *websocket.Conn) {
+	c.writeMu.Lock()
+	c.conn = conn
+	c.writeMu.Unlock()
+}
+
+// run reads messages until the client is closed, reconnecting when the connection drops
+func (c *Client) run(conn *websocket.Conn) {
+	defer close(c.done)
+	c.reader.Store(goroutineID())
+	for {
+		err := c.readLoop(conn)
+		select {
+		case <-c.closed:
+			return
+		default:
+		}
+		c.reportError(fmt.Errorf("connection lost: %w", err))
+		if c.reconnectInterval <= 0 {
+			return
+		}
+
+		conn = c.reconnect()
+		if conn == nil {
+			return
+		}
+	}
+}
+
+// reconnect redials until it succeeds or the client is closed, then restores subscriptions
+func (c *Client) reconnect() *websocket.Conn {
+	for {
+		select {
+		case <-c.closed:
+			return nil
+		case <-time.After(c.reconnectInterval):
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.reconnectInterval*5)
+		conn, _, err := c.dialer.DialContext(ctx, c.url, c.header)
+		cancel()
+		if err != nil {
+			c.reportError(fmt.Errorf("failed to reconnect: %w", err))
+			continue
+		}
+		c.setConn(conn)
+
+		// Close may have raced with the dial
+		select {
+		case <-c.closed:
+			conn.Close()
+			return nil
+		default:
+		}
+
+		c.mu.RLock()
+		keys := make([]string, 0, len(c.subs))
+		for key := range c.subs {
+			keys = append(keys, key)
+		}
+		c.mu.RUnlock()
+		for _, key := range keys {
+			if err := c.send(request{Action: "subscribe", Parameter: key}); err != nil {
+				c.reportError(err)
+			}
+		}
+		return conn
+	}
+}
+
+func (c *Client) readLoop(conn *websocket.Conn) error {
+	defer conn.Close()
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		c.handle(data)
+	}
+}
+
+// handle decodes and applies a single message
+func (c *Client) handle(data []byte) {
+	var msg message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		c.reportError(fmt.Errorf("failed to decode message: %w", err))
+		return
+	}
+
+	switch msg.Type {
+	case "":
+		c.handleComposition(data)
+	case "parameter_update", "parameter_subscribed":
+		c.handleParameter(msg, data)
+	case "effects_update":
+		var effects resolume.Effects
+		if err := json.Unmarshal(msg.Value, &effects); err != nil {
+			c.reportError(fmt.Errorf("failed to decode effects: %w", err))
+			return
+		}
+		c.mu.Lock()
+		c.effects = &effects
+		c.mu.Unlock()
+	case "sources_update":
+		var sources resolume.Sources
+		if err := json.Unmarshal(msg.Value, &sources); err != nil {
+			c.reportError(fmt.Errorf("failed to decode sources: %w", err))
+			return
+		}
+		c.mu.Lock()
+		c.sources = &sources
+		c.mu.Unlock()
+	case "error":
+		c.reportError(fmt.Errorf("resolume error: %s (%s)", msg.Error, msg.Path))
+	}
+}
+
+func (c *Client) handleComposition(data []byte) {
+	var composition resolume.Composition
+	if err := json.Unmarshal(data, &composition); err != nil {
+		c.reportError(fmt.Errorf("failed to decode composition: %w", err))
+		return
+	}
+
+	c.mu.Lock()
+	c.composition = &composition
+	c.params = indexParameters(&composition)
+	c.mu.Unlock()
+	c.readyOnce.Do(func() { close(c.ready) })
+
+	if c.onComposition != nil {
+		c.onComposition(c.Composition())
+	}
+}
+
+func (c *Client) handleParameter(msg message, data []byte) {
+	var value interface{}
+	if len(msg.Value) > 0 {
+		if err := json.Unmarshal(msg.Value, &value); err != nil {
+			c.reportError(fmt.Errorf("failed to decode parameter value: %w", err))
+			return
+		}
+	}
+	update := ParameterUpdate{
+		Path:  msg.Path,
+		ID:    msg.ID,
+		Value: value,
+		Raw:   json.RawMessage(data),
+	}
+
+	c.mu.Lock()
+	c.latest[msg.ID] = update
+	if ref, ok := c.params[msg.ID]; ok {
+		if err := ref.apply(data); err != nil {
+			c.reportError(err)
+		}
+	}
+	var subs []*Subscription
+	subs = append(subs, c.subs[ByID(msg.ID)]...)
+	if msg.Path != ByID(msg.ID) {
+		subs = append(subs, c.subs[msg.Path]...)
+	}
+	c.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.deliver(update)
+	}
+}
+
+// inReader reports whether it is called from the goroutine reading messages,
+// i.e. from a callback
+func (c *Client) inReader() bool {
+	reader := c.reader.Load()
+	return reader != 0 && reader == goroutineID()
+}
+
+// goroutineID returns the id of the calling goroutine, from the header of its
+// stack trace such as "goroutine 18 [running]:"
+func goroutineID() uint64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	fields := strings.Fields(strings.TrimPrefix(string(buf[:n]), "goroutine "))
+	if len(fields) == 0 {
+		return 0
+	}
+	id, _ := strconv.ParseUint(fields[0], 10, 64)
+	return id
+}
+
+func (c *Client) reportError(err error) {
+	if c.onError != nil {
+		c.onError(err)
+	}
+}
@@ -0,0 +1,389 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FlowingSPDG/resolume-go"
+	"github.com/gorilla/websocket"
+)
+
+const testComposition = `{
+	"name": {"id": 1, "valuetype": "ParamString", "value": "Show"},
+	"master": {"id": 2, "valuetype": "ParamRange", "value": 1, "min": 0, "max": 1},
+	"layers": [
+		{
+			"id": 10,
+			"master": {"id": 11, "valuetype": "ParamRange", "value": 0.5, "min": 0, "max": 1},
+			"dashboard": {
+				"Link 1": {"id": 12, "valuetype": "ParamRange", "value": 0},
+				"Link 2": {"id": 13, "valuetype": "ParamFuture", "value": 1, "extra": true}
+			}
+		}
+	]
+}`
+
+// fakeResolume accepts WebSocket connections, sends the composition and records received requests
+type fakeResolume struct {
+	t        *testing.T
+	upgrader websocket.Upgrader
+
+	mu       sync.Mutex
+	conns    []*websocket.Conn
+	requests chan request
+}
+
+func newFakeResolume(t *testing.T) (*fakeResolume, *httptest.Server) {
+	f := &fakeResolume{t: t, requests: make(chan request, 16)}
+	server := httptest.NewServer(http.HandlerFunc(f.serve))
+	return f, server
+}
+
+func (f *fakeResolume) serve(w http.ResponseWriter, r *http.Request) {
+	conn, err := f.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		f.t.Errorf("Upgrade() error = %v", err)
+		return
+	}
+	f.mu.Lock()
+	f.conns = append(f.conns, conn)
+	f.mu.Unlock()
+
+	conn.WriteMessage(websocket.TextMessage, []byte(testComposition))
+	for {
+		var req request
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+		f.requests <- req
+	}
+}
+
+func (f *fakeResolume) send(msg string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.conns[len(f.conns)-1].WriteMessage(websocket.TextMessage, []byte(msg))
+}
+
+func (f *fakeResolume) dropConnection() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.conns[len(f.conns)-1].Close()
+}
+
+func (f *fakeResolume) expect(t *testing.T, action, parameter string) {
+	t.Helper()
+	select {
+	case req := <-f.requests:
+		if req.Action != action || req.Parameter != parameter {
+			t.Errorf("Expected %s %s, got %s %s", action, parameter, req.Action, req.Parameter)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Timed out waiting for %s %s", action, parameter)
+	}
+}
+
+func wsURL(server *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1"
+}
+
+func TestSubscribe(t *testing.T) {
+	fake, server := newFakeResolume(t)
+	defer server.Close()
+
+	client := NewClientFromURL(wsURL(server))
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Close()
+
+	composition, err := client.WaitComposition(ctx)
+	if err != nil {
+		t.Fatalf("WaitComposition() error = %v", err)
+	}
+	if composition.Name.Value != "Show" {
+		t.Errorf("Expected composition name Show, got %s", composition.Name.Value)
+	}
+
+	sub, err := client.Subscribe(11)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	fake.expect(t, "subscribe", "/parameter/by-id/11")
+
+	fake.send(`{"type": "parameter_update", "path": "/parameter/by-id/11", "id": 11, "valuetype": "ParamRange", "value": 0.25, "min": 0, "max": 1}`)
+	select {
+	case update := <-sub.C:
+		if update.Value != 0.25 {
+			t.Errorf("Expected value 0.25, got %v", update.Value)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for parameter update")
+	}
+
+	// The mirror reflects the update
+	if got := client.Composition().Layers[0].Master.Value; got != 0.25 {
+		t.Errorf("Expected mirrored layer master 0.25, got %v", got)
+	}
+
+	if err := sub.Unsubscribe(); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+	fake.expect(t, "unsubscribe", "/parameter/by-id/11")
+	if _, ok := <-sub.C; ok {
+		t.Error("Expected channel to be closed after Unsubscribe")
+	}
+}
+
+func TestSubscribeFuncDashboard(t *testing.T) {
+	fake, server := newFakeResolume(t)
+	defer server.Close()
+
+	client := NewClientFromURL(wsURL(server))
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Close()
+	if _, err := client.WaitComposition(ctx); err != nil {
+		t.Fatalf("WaitComposition() error = %v", err)
+	}
+
+	updates := make(chan ParameterUpdate, 1)
+	if _, err := client.SubscribePathFunc("/composition/layers/1/dashboard/link1", func(u ParameterUpdate) {
+		updates <- u
+	}); err != nil {
+		t.Fatalf("SubscribePathFunc() error = %v", err)
+	}
+	fake.expect(t, "subscribe", "/composition/layers/1/dashboard/link1")
+
+	fake.send(`{"type": "parameter_update", "path": "/composition/layers/1/dashboard/link1", "id": 12, "valuetype": "ParamRange", "value": 0.75}`)
+	select {
+	case <-updates:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for parameter update")
+	}
+
+	link, ok := client.Composition().Layers[0].Dashboard.Range("Link 1")
+	if !ok {
+		t.Fatal("Expected dashboard Link 1 to be a RangeParameter")
+	}
+	if link.Value != 0.75 {
+		t.Errorf("Expected mirrored dashboard value 0.75, got %v", link.Value)
+	}
+}
+
+func TestSetAndTrigger(t *testing.T) {
+	fake, server := newFakeResolume(t)
+	defer server.Close()
+
+	client := NewClientFromURL(wsURL(server))
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Close()
+
+	if err := client.SetByID(2, 0.5); err != nil {
+		t.Fatalf("SetByID() error = %v", err)
+	}
+	fake.expect(t, "set", "/parameter/by-id/2")
+
+	if err := client.Trigger("/composition/layers/1/clips/1/connect"); err != nil {
+		t.Fatalf("Trigger() error = %v", err)
+	}
+	fake.expect(t, "trigger", "/composition/layers/1/clips/1/connect")
+	fake.expect(t, "trigger", "/composition/layers/1/clips/1/connect")
+}
+
+func TestReconnect(t *testing.T) {
+	fake, server := newFakeResolume(t)
+	defer server.Close()
+
+	client := NewClientFromURL(wsURL(server), WithReconnectInterval(10*time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.SubscribePath("/composition/master"); err != nil {
+		t.Fatalf("SubscribePath() error = %v", err)
+	}
+	fake.expect(t, "subscribe", "/composition/master")
+
+	// Subscriptions are restored after reconnecting
+	fake.dropConnection()
+	fake.expect(t, "subscribe", "/composition/master")
+}
+
+func TestConnectAndClose(t *testing.T) {
+	// Close returns without a reader, whether Connect was not called or failed
+	closed := make(chan struct{})
+	go func() {
+		NewClient("localhost", "1").Close()
+		client := NewClient("127.0.0.1", "1")
+		client.Connect(context.Background())
+		client.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close() without a connection did not return")
+	}
+
+	_, server := newFakeResolume(t)
+	defer server.Close()
+	client := NewClientFromURL(wsURL(server))
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	if err := client.Connect(ctx); err == nil {
+		t.Error("Second Connect() error = nil")
+	}
+	client.Close()
+	if err := client.Connect(ctx); err == nil {
+		t.Error("Connect() after Close() error = nil")
+	}
+}
+
+func TestDeliverAfterClose(t *testing.T) {
+	called := false
+	sub := &Subscription{fn: func(ParameterUpdate) { called = true }}
+	sub.close()
+	sub.deliver(ParameterUpdate{ID: 1})
+	if called {
+		t.Error("Callback called after the subscription was closed")
+	}
+}
+
+func TestUnsubscribeWaitsForCallback(t *testing.T) {
+	fake, server := newFakeResolume(t)
+	defer server.Close()
+
+	client := NewClientFromURL(wsURL(server))
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Close()
+
+	var unsubscribed atomic.Bool
+	received := make(chan struct{}, 1)
+	sub, err := client.SubscribeFunc(11, func(ParameterUpdate) {
+		select {
+		case received <- struct{}{}:
+		default:
+		}
+		time.Sleep(time.Millisecond)
+		if unsubscribed.Load() {
+			t.Error("Callback running after Unsubscribe returned")
+		}
+	})
+	if err != nil {
+		t.Fatalf("SubscribeFunc() error = %v", err)
+	}
+	fake.expect(t, "subscribe", "/parameter/by-id/11")
+
+	stop := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				fake.send(`{"type": "parameter_update", "path": "/parameter/by-id/11", "id": 11, "value": 0.5}`)
+			}
+		}
+	}()
+	defer close(stop)
+	<-received
+	if err := sub.Unsubscribe(); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+	unsubscribed.Store(true)
+	time.Sleep(20 * time.Millisecond)
+}
+
+func TestCloseFromCallback(t *testing.T) {
+	fake, server := newFakeResolume(t)
+	defer server.Close()
+
+	client := NewClientFromURL(wsURL(server))
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+
+	closed := make(chan error, 1)
+	var sub *Subscription
+	sub, err := client.SubscribeFunc(11, func(ParameterUpdate) {
+		sub.Unsubscribe()
+		closed <- client.Close()
+	})
+	if err != nil {
+		t.Fatalf("SubscribeFunc() error = %v", err)
+	}
+	fake.expect(t, "subscribe", "/parameter/by-id/11")
+	fake.send(`{"type": "parameter_update", "path": "/parameter/by-id/11", "id": 11, "value": 0.5}`)
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Unsubscribe and Close from a callback did not return")
+	}
+}
+
+func TestMirrorUnknownParameter(t *testing.T) {
+	fake, server := newFakeResolume(t)
+	defer server.Close()
+
+	client := NewClientFromURL(wsURL(server))
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Close()
+	if _, err := client.WaitComposition(ctx); err != nil {
+		t.Fatalf("WaitComposition() error = %v", err)
+	}
+
+	updates := make(chan ParameterUpdate, 1)
+	if _, err := client.SubscribeFunc(13, func(u ParameterUpdate) { updates <- u }); err != nil {
+		t.Fatalf("SubscribeFunc() error = %v", err)
+	}
+	fake.expect(t, "subscribe", "/parameter/by-id/13")
+	fake.send(`{"type": "parameter_update", "path": "/parameter/by-id/13", "id": 13, "valuetype": "ParamFuture", "value": 2}`)
+	select {
+	case <-updates:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for parameter update")
+	}
+
+	p, ok := client.Composition().Layers[0].Dashboard["Link 2"].(*resolume.UnknownParameter)
+	if !ok {
+		t.Fatal("Expected dashboard Link 2 to be an UnknownParameter")
+	}
+	var fields map[string]interface{}
+	json.Unmarshal(p.Raw, &fields)
+	if fields["value"] != 2.0 || fields["extra"] != true || fields["type"] != nil || fields["path"] != nil {
+		t.Errorf("Mirrored parameter = %s, want the update merged without type and path", p.Raw)
+	}
+}